@@ -0,0 +1,247 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestClientServer$ ./api
+func TestClientServer(t *testing.T) {
+	jj := junjo.NewJ(memory.NewMemoryStorage())
+	server := httptest.NewServer(NewServer(jj))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.Client())
+	var err error
+
+	var topic *types.Topic
+	if topic, err = client.CreateTopic("Provisioning", types.WithTopicDescription("servers")); err != nil {
+		t.Error(err)
+		return
+	}
+	if topic.Description != "servers" {
+		t.Errorf("expected description to be sent, got %q", topic.Description)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = client.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = client.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var logging *types.TaskDefinition
+	if logging, err = client.CreateTaskDefinition("log", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	first, second := unitDag.ConnectDef(unitDag.AddTaskDefinition(provisioning), unitDag.AddTaskDefinition(logging))
+
+	var job *types.Job
+	if job, err = client.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var inbox []types.InboxTopicTaskUnit
+	if inbox, err = client.GetInboxTopic(btl.Key, topic.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox) != 1 || len(inbox[0].TaskUnits) != 1 {
+		t.Errorf("expected one available unit, got %v", inbox)
+		return
+	}
+	if inbox[0].JobID != job.Key {
+		t.Errorf("expected job %v, got %v", job.Key, inbox[0].JobID)
+		return
+	}
+
+	firstID := first.(*types.NodeTaskUnit).Unit.Key
+	secondID := second.(*types.NodeTaskUnit).Unit.Key
+
+	// the dependency is not done yet
+	if err = client.SubmitCommand(secondID, types.Command{Type: types.SuccessCmd}); !errors.Is(err, types.ErrTaskUnitNotAvailable) {
+		t.Errorf("expected %v, got %v", types.ErrTaskUnitNotAvailable, err)
+		return
+	}
+
+	if err = client.SubmitCommand(firstID, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = client.SubmitCommand(secondID, types.Command{Type: types.ErrorCmd, Details: "disk full"}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var unit *types.TaskUnit
+	if unit, err = client.GetTaskUnit(secondID); err != nil {
+		t.Error(err)
+		return
+	}
+	if unit.Status != types.ErrorStatus || unit.Error == nil || unit.Error.Error() != "disk full" {
+		t.Errorf("expected error status with its message, got %v %v", unit.Status, unit.Error)
+		return
+	}
+
+	if job, err = client.GetJob(job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.Status != types.ErrorStatus {
+		t.Errorf("expected job to roll up to %v, got %v", types.ErrorStatus, job.Status)
+		return
+	}
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestInboxEvents$ ./api
+func TestInboxEvents(t *testing.T) {
+	jj := junjo.NewJ(memory.NewMemoryStorage())
+	server := httptest.NewServer(NewServer(jj))
+	defer server.Close()
+
+	var err error
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	unitDag := jj.CreateDagTaskUnits()
+	first, second := unitDag.ConnectDef(unitDag.AddTaskDefinition(provisioning), unitDag.AddTaskDefinition(provisioning))
+	firstID := first.(*types.NodeTaskUnit).Unit.Key
+	secondID := second.(*types.NodeTaskUnit).Unit.Key
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/owners/"+string(btl.Key)+"/inbox/events", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Body.Close()
+	events := bufio.NewReader(resp.Body)
+
+	var delta InboxDelta
+	if delta, err = readDelta(events); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(delta.Added) != 1 || len(delta.Added[0].TaskUnits) != 1 || delta.Added[0].TaskUnits[0].Key != firstID {
+		t.Errorf("expected the first unit added, got %v", delta)
+		return
+	}
+
+	if err = jj.SubmitCommand(firstID, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if delta, err = readDelta(events); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(delta.Added) != 1 || delta.Added[0].TaskUnits[0].Key != secondID || len(delta.Removed) != 1 || delta.Removed[0] != firstID {
+		t.Errorf("expected the first unit replaced by the second, got %v", delta)
+		return
+	}
+}
+
+// Read the next `inbox` event, the pings are skipped
+func readDelta(events *bufio.Reader) (InboxDelta, error) {
+	var delta InboxDelta
+	event := ""
+	for {
+		line, err := events.ReadString('\n')
+		if err != nil {
+			return delta, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: ") && event == "inbox":
+			return delta, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &delta)
+		case strings.HasPrefix(line, "data: "):
+			return delta, fmt.Errorf("unexpected %v event: %v", event, line)
+		}
+	}
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestGateway$ ./api
+func TestGateway(t *testing.T) {
+	namespaces := junjo.NewNamespaces(func(namespace types.Namespace) (types.StorageInterface, error) {
+		return memory.NewMemoryStorage(), nil
+	}, junjo.WithNamespacesAuth(types.StaticTokens{
+		"b1lling": {Name: "billing", Namespaces: []types.Namespace{"billing"}, Roles: []types.RoleBinding{{Role: types.RoleAdmin}}},
+		"4dmin":   {Name: "ops", Namespaces: []types.Namespace{"billing", "payroll"}, Roles: []types.RoleBinding{{Role: types.RoleAdmin}}},
+	}))
+	server := httptest.NewServer(NewGateway(namespaces))
+	defer server.Close()
+
+	billing := NewClient(server.URL, server.Client(), WithClientToken("b1lling"))
+	payroll := NewClient(server.URL, server.Client(), WithClientToken("4dmin"), WithClientNamespace("payroll"))
+	var err error
+
+	var topic *types.Topic
+	if topic, err = billing.CreateTopic("Invoices"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = payroll.CreateTopic("Invoices"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = payroll.GetTopic(topic.Key); err == nil {
+		t.Error("expected the topic of billing not found in payroll")
+		return
+	}
+
+	denied := NewClient(server.URL, server.Client(), WithClientToken("b1lling"), WithClientNamespace("payroll"))
+	if _, err = denied.GetTopics(); !errors.Is(err, types.ErrNamespaceDenied) {
+		t.Errorf("expected %v, got %v", types.ErrNamespaceDenied, err)
+		return
+	}
+	if _, err = NewClient(server.URL, server.Client()).GetTopics(); !errors.Is(err, types.ErrUnauthenticated) {
+		t.Errorf("expected %v, got %v", types.ErrUnauthenticated, err)
+		return
+	}
+}
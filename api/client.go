@@ -0,0 +1,715 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// `Client` talks to a remote `Server`, it has the same methods than `Junjoold` so you can switch from one to another
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	namespace  types.Namespace
+	token      string
+}
+
+type ClientConfig func(c *Client)
+
+// Namespace asked to a `Gateway`, the only one of the token when empty
+func WithClientNamespace(namespace types.Namespace) ClientConfig {
+	return func(c *Client) {
+		c.namespace = namespace
+	}
+}
+
+// Bearer token sent to a `Gateway`
+func WithClientToken(token string) ClientConfig {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// NewClient creates a `Client` for a `Server` listening at `baseURL`, use `http.DefaultClient` when `httpClient` is nil
+func NewClient(baseURL string, httpClient *http.Client, cfgs ...ClientConfig) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	client := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](client)
+	}
+	return client
+}
+
+// Send a request and decode the response in `out` when it's not nil
+func (c *Client) do(method string, path string, body interface{}, out interface{}) error {
+	return c.doContext(context.Background(), method, path, body, out)
+}
+
+func (c *Client) doContext(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.namespace != "" {
+		req.Header.Set(HeaderNamespace, string(c.namespace))
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var value errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&value); err != nil || value.Error == "" {
+			return fmt.Errorf("%v %v: %v", method, path, resp.Status)
+		}
+		// keep the sentinel errors comparable with `errors.Is`
+		if resp.StatusCode == http.StatusConflict && value.Error == types.ErrTaskUnitNotAvailable.Error() {
+			return types.ErrTaskUnitNotAvailable
+		}
+		if resp.StatusCode == http.StatusConflict && strings.HasPrefix(value.Error, types.ErrConcurrencyLimitReached.Error()) {
+			return fmt.Errorf("%w%v", types.ErrConcurrencyLimitReached, strings.TrimPrefix(value.Error, types.ErrConcurrencyLimitReached.Error()))
+		}
+		if resp.StatusCode == http.StatusUnprocessableEntity && strings.HasPrefix(value.Error, types.ErrJobDependencyInvalid.Error()) {
+			return fmt.Errorf("%w%v", types.ErrJobDependencyInvalid, strings.TrimPrefix(value.Error, types.ErrJobDependencyInvalid.Error()))
+		}
+		if resp.StatusCode == http.StatusConflict && strings.HasPrefix(value.Error, types.ErrTaskUnitClaimed.Error()) {
+			return fmt.Errorf("%w%v", types.ErrTaskUnitClaimed, strings.TrimPrefix(value.Error, types.ErrTaskUnitClaimed.Error()))
+		}
+		if resp.StatusCode == http.StatusForbidden && strings.HasPrefix(value.Error, types.ErrOwnerNotMember.Error()) {
+			return fmt.Errorf("%w%v", types.ErrOwnerNotMember, strings.TrimPrefix(value.Error, types.ErrOwnerNotMember.Error()))
+		}
+		if resp.StatusCode == http.StatusForbidden && strings.HasPrefix(value.Error, types.ErrNotApprover.Error()) {
+			return fmt.Errorf("%w%v", types.ErrNotApprover, strings.TrimPrefix(value.Error, types.ErrNotApprover.Error()))
+		}
+		// a launch wraps them with the job that was refused
+		for _, deprecated := range []error{types.ErrTaskDefinitionDeprecated, types.ErrOwnerDeprecated, types.ErrTopicDeprecated} {
+			if idx := strings.Index(value.Error, deprecated.Error()); resp.StatusCode == http.StatusConflict && idx >= 0 {
+				return fmt.Errorf("%w%v", deprecated, value.Error[idx+len(deprecated.Error()):])
+			}
+		}
+		if resp.StatusCode == http.StatusBadRequest && strings.HasPrefix(value.Error, types.ErrCommandIndexInvalid.Error()) {
+			return fmt.Errorf("%w%v", types.ErrCommandIndexInvalid, strings.TrimPrefix(value.Error, types.ErrCommandIndexInvalid.Error()))
+		}
+		if resp.StatusCode == http.StatusBadRequest && strings.HasPrefix(value.Error, types.ErrMigrationInvalid.Error()) {
+			return fmt.Errorf("%w%v", types.ErrMigrationInvalid, strings.TrimPrefix(value.Error, types.ErrMigrationInvalid.Error()))
+		}
+		if resp.StatusCode == http.StatusConflict && value.Error == types.ErrApprovalExpired.Error() {
+			return types.ErrApprovalExpired
+		}
+		if resp.StatusCode == http.StatusUnprocessableEntity && strings.HasPrefix(value.Error, types.ErrReassignInvalid.Error()) {
+			return fmt.Errorf("%w%v", types.ErrReassignInvalid, strings.TrimPrefix(value.Error, types.ErrReassignInvalid.Error()))
+		}
+		if resp.StatusCode == http.StatusUnauthorized && strings.HasPrefix(value.Error, types.ErrUnauthenticated.Error()) {
+			return fmt.Errorf("%w%v", types.ErrUnauthenticated, strings.TrimPrefix(value.Error, types.ErrUnauthenticated.Error()))
+		}
+		if resp.StatusCode == http.StatusForbidden && strings.HasPrefix(value.Error, types.ErrPermissionDenied.Error()) {
+			return fmt.Errorf("%w%v", types.ErrPermissionDenied, strings.TrimPrefix(value.Error, types.ErrPermissionDenied.Error()))
+		}
+		if resp.StatusCode == http.StatusForbidden && strings.HasPrefix(value.Error, types.ErrNamespaceDenied.Error()) {
+			return fmt.Errorf("%w%v", types.ErrNamespaceDenied, strings.TrimPrefix(value.Error, types.ErrNamespaceDenied.Error()))
+		}
+		return errors.New(value.Error)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if raw, ok := out.(*[]byte); ok {
+		*raw, err = io.ReadAll(resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (c *Client) CreateTopic(name string, cfgs ...types.TopicConfig) (*types.Topic, error) {
+	value := types.NewTopic("", name, cfgs...)
+	var topic types.Topic
+	if err := c.do(http.MethodPost, "/topics", topicRequest{Name: name, Description: value.Description}, &topic); err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
+
+func (c *Client) GetTopics() ([]types.Topic, error) {
+	var topics []types.Topic
+	if err := c.do(http.MethodGet, "/topics", nil, &topics); err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
+
+func (c *Client) GetTopic(id types.TopicID) (*types.Topic, error) {
+	var topic types.Topic
+	if err := c.do(http.MethodGet, "/topics/"+url.PathEscape(string(id)), nil, &topic); err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
+
+func (c *Client) UpdateTopic(id types.TopicID, name string) (*types.Topic, error) {
+	var topic types.Topic
+	if err := c.do(http.MethodPut, "/topics/"+url.PathEscape(string(id)), topicRequest{Name: name}, &topic); err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
+
+func (c *Client) DeprecateTopic(id types.TopicID) error {
+	return c.do(http.MethodDelete, "/topics/"+url.PathEscape(string(id)), nil, nil)
+}
+
+func (c *Client) CreateOwner(name string, cfgs ...types.OwnerConfig) (*types.Owner, error) {
+	value := types.NewOwner("", name, cfgs...)
+	var owner types.Owner
+	if err := c.do(http.MethodPost, "/owners", ownerRequest{Name: name, Description: value.Description, Members: value.Members}, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+func (c *Client) GetOwners() ([]types.Owner, error) {
+	var owners []types.Owner
+	if err := c.do(http.MethodGet, "/owners", nil, &owners); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+func (c *Client) GetOwner(ownerID types.OwnerID) (*types.Owner, error) {
+	var owner types.Owner
+	if err := c.do(http.MethodGet, "/owners/"+url.PathEscape(string(ownerID)), nil, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+func (c *Client) UpdateOwner(ownerID types.OwnerID, name string) (*types.Owner, error) {
+	var owner types.Owner
+	if err := c.do(http.MethodPut, "/owners/"+url.PathEscape(string(ownerID)), ownerRequest{Name: name}, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+func (c *Client) DeprecateOwner(ownerID types.OwnerID) (*types.Owner, error) {
+	var owner types.Owner
+	if err := c.do(http.MethodDelete, "/owners/"+url.PathEscape(string(ownerID)), nil, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+func (c *Client) CreateTaskDefinition(name string, ownerID types.OwnerID, cfgs ...types.TaskDefinitionConfig) (*types.TaskDefinition, error) {
+	value := types.NewUnitDescription("", name, ownerID, cfgs...)
+	body := definitionRequest{
+		Name:        name,
+		OwnerID:     ownerID,
+		Description: value.Description,
+		Identifier:  value.Identifier,
+		Approval:    value.Approval,
+	}
+	var definition types.TaskDefinition
+	if err := c.do(http.MethodPost, "/definitions", body, &definition); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (c *Client) GetTaskDefinitions() ([]types.TaskDefinition, error) {
+	var definitions []types.TaskDefinition
+	if err := c.do(http.MethodGet, "/definitions", nil, &definitions); err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+func (c *Client) GetTaskDefinition(id types.TaskDefinitionID) (*types.TaskDefinition, error) {
+	var definition types.TaskDefinition
+	if err := c.do(http.MethodGet, "/definitions/"+url.PathEscape(string(id)), nil, &definition); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (c *Client) UpdateTaskDefinition(id types.TaskDefinitionID, ownerID types.OwnerID, name string, description string, identifier string) (*types.TaskDefinition, error) {
+	body := definitionRequest{
+		Name:        name,
+		OwnerID:     ownerID,
+		Description: description,
+		Identifier:  identifier,
+	}
+	var definition types.TaskDefinition
+	if err := c.do(http.MethodPut, "/definitions/"+url.PathEscape(string(id)), body, &definition); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (c *Client) GetTaskDefinitionVersion(id types.TaskDefinitionID, version int) (*types.TaskDefinition, error) {
+	var definition types.TaskDefinition
+	if err := c.do(http.MethodGet, fmt.Sprintf("/definitions/%v/versions/%v", url.PathEscape(string(id)), version), nil, &definition); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (c *Client) GetTaskDefinitionVersions(id types.TaskDefinitionID) ([]types.TaskDefinition, error) {
+	var definitions []types.TaskDefinition
+	if err := c.do(http.MethodGet, "/definitions/"+url.PathEscape(string(id))+"/versions", nil, &definitions); err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+func (c *Client) MigrateTaskDefinition(from types.TaskDefinitionID, to types.TaskDefinitionID) ([]types.TaskUnitID, error) {
+	var migrated []types.TaskUnitID
+	if err := c.do(http.MethodPost, "/definitions/"+url.PathEscape(string(from))+"/migrate", migrateRequest{To: string(to)}, &migrated); err != nil {
+		return nil, err
+	}
+	return migrated, nil
+}
+
+func (c *Client) DeprecateTaskDefinition(id types.TaskDefinitionID) error {
+	return c.do(http.MethodDelete, "/definitions/"+url.PathEscape(string(id)), nil, nil)
+}
+
+func (c *Client) LaunchJob(topicID types.TopicID, workUnitDag *types.WorkUnitDag, cfgs ...types.JobConfig) (*types.Job, error) {
+	value := types.NewJob("", cfgs...)
+	var job types.Job
+	if err := c.do(http.MethodPost, "/topics/"+url.PathEscape(string(topicID))+"/jobs", jobRequest{Dag: workUnitDag, Data: value.Data, Priority: value.Priority, DependsOn: value.DependsOn, UpstreamPolicy: value.Upstream}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (c *Client) GetJobs(topicID types.TopicID) ([]types.Job, error) {
+	var jobs []types.Job
+	if err := c.do(http.MethodGet, "/topics/"+url.PathEscape(string(topicID))+"/jobs", nil, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (c *Client) GetJob(jobID types.JobID) (*types.Job, error) {
+	var job types.Job
+	if err := c.do(http.MethodGet, "/jobs/"+url.PathEscape(string(jobID)), nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (c *Client) CancelJob(jobID types.JobID) error {
+	return c.do(http.MethodPost, "/jobs/"+url.PathEscape(string(jobID))+"/cancel", nil, nil)
+}
+
+func (c *Client) AddJobDependencies(jobID types.JobID, upstreamIDs ...types.JobID) error {
+	return c.do(http.MethodPost, "/jobs/"+url.PathEscape(string(jobID))+"/dependencies", dependenciesRequest{DependsOn: upstreamIDs}, nil)
+}
+
+func (c *Client) GetDependentJobs(jobID types.JobID) ([]types.Job, error) {
+	var jobs []types.Job
+	if err := c.do(http.MethodGet, "/jobs/"+url.PathEscape(string(jobID))+"/dependents", nil, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (c *Client) RenderJobDOT(jobID types.JobID) ([]byte, error) {
+	var dot []byte
+	if err := c.do(http.MethodGet, "/jobs/"+url.PathEscape(string(jobID))+"/dot", nil, &dot); err != nil {
+		return nil, err
+	}
+	return dot, nil
+}
+
+func (c *Client) GetTasks(jobID types.JobID) ([]types.Task, error) {
+	var tasks []types.Task
+	if err := c.do(http.MethodGet, "/jobs/"+url.PathEscape(string(jobID))+"/tasks", nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (c *Client) GetTask(taskID types.TaskID) (*types.Task, error) {
+	var task types.Task
+	if err := c.do(http.MethodGet, "/tasks/"+url.PathEscape(string(taskID)), nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (c *Client) CancelTask(taskID types.TaskID) error {
+	return c.do(http.MethodPost, "/tasks/"+url.PathEscape(string(taskID))+"/cancel", nil, nil)
+}
+
+func (c *Client) RenderTaskDOT(taskID types.TaskID) ([]byte, error) {
+	var dot []byte
+	if err := c.do(http.MethodGet, "/tasks/"+url.PathEscape(string(taskID))+"/dot", nil, &dot); err != nil {
+		return nil, err
+	}
+	return dot, nil
+}
+
+func (c *Client) GetTaskUnits(taskID types.TaskID) ([]types.TaskUnit, error) {
+	var units []TaskUnit
+	if err := c.do(http.MethodGet, "/tasks/"+url.PathEscape(string(taskID))+"/units", nil, &units); err != nil {
+		return nil, err
+	}
+	return FromTaskUnits(units), nil
+}
+
+func (c *Client) GetTaskUnitState(taskUnitID types.TaskUnitID) (*types.TaskUnitState, error) {
+	var state types.TaskUnitState
+	if err := c.do(http.MethodGet, "/units/"+url.PathEscape(string(taskUnitID))+"/state", nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (c *Client) GetTaskUnitStateAt(taskUnitID types.TaskUnitID, index int) (*types.TaskUnitState, error) {
+	var state types.TaskUnitState
+	if err := c.do(http.MethodGet, fmt.Sprintf("/units/%v/state?at=%v", url.PathEscape(string(taskUnitID)), index), nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (c *Client) GetTaskUnit(taskUnitID types.TaskUnitID) (*types.TaskUnit, error) {
+	var unit TaskUnit
+	if err := c.do(http.MethodGet, "/units/"+url.PathEscape(string(taskUnitID)), nil, &unit); err != nil {
+		return nil, err
+	}
+	value := FromTaskUnit(unit)
+	return &value, nil
+}
+
+func (c *Client) SubmitCommand(taskUnitID types.TaskUnitID, cmd types.Command) error {
+	return c.do(http.MethodPost, "/units/"+url.PathEscape(string(taskUnitID))+"/commands", cmd, nil)
+}
+
+func (c *Client) SetOwnerMembers(ownerID types.OwnerID, members ...types.OwnerID) (*types.Owner, error) {
+	var owner types.Owner
+	if err := c.do(http.MethodPut, "/owners/"+url.PathEscape(string(ownerID))+"/members", membersRequest{Members: members}, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+func (c *Client) MigrateOwner(from types.OwnerID, to types.OwnerID, reason string) ([]types.TaskUnitID, error) {
+	var migrated []types.TaskUnitID
+	if err := c.do(http.MethodPost, "/owners/"+url.PathEscape(string(from))+"/migrate", migrateRequest{To: string(to), Reason: reason}, &migrated); err != nil {
+		return nil, err
+	}
+	return migrated, nil
+}
+
+func (c *Client) ClaimTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID) error {
+	return c.do(http.MethodPost, "/units/"+url.PathEscape(string(taskUnitID))+"/claim", claimRequest{OwnerID: ownerID}, nil)
+}
+
+func (c *Client) ReleaseTaskUnit(taskUnitID types.TaskUnitID) error {
+	return c.do(http.MethodPost, "/units/"+url.PathEscape(string(taskUnitID))+"/release", nil, nil)
+}
+
+func (c *Client) ReassignTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID, reason string) error {
+	return c.do(http.MethodPost, "/units/"+url.PathEscape(string(taskUnitID))+"/reassign", reassignRequest{OwnerID: ownerID, Reason: reason}, nil)
+}
+
+func (c *Client) Approve(taskUnitID types.TaskUnitID, ownerID types.OwnerID, comment string) error {
+	return c.do(http.MethodPost, "/units/"+url.PathEscape(string(taskUnitID))+"/approve", reviewRequest{OwnerID: ownerID, Comment: comment}, nil)
+}
+
+func (c *Client) Reject(taskUnitID types.TaskUnitID, ownerID types.OwnerID, reason string) error {
+	return c.do(http.MethodPost, "/units/"+url.PathEscape(string(taskUnitID))+"/reject", reviewRequest{OwnerID: ownerID, Comment: reason}, nil)
+}
+
+func (c *Client) GetInbox(ownerID types.OwnerID, cfgs ...types.QueryConfig) ([]types.InboxAllTaskUnit, error) {
+	var inbox []InboxTaskUnit
+	if err := c.do(http.MethodGet, "/owners/"+url.PathEscape(string(ownerID))+"/inbox?"+inboxQuery(cfgs).Encode(), nil, &inbox); err != nil {
+		return nil, err
+	}
+	return FromInbox(inbox), nil
+}
+
+// Long poll the server until the inbox is not empty, one request every `junjo.InboxRecheck` at most
+func (c *Client) WaitInbox(ctx context.Context, ownerID types.OwnerID, cfgs ...types.QueryConfig) ([]types.InboxAllTaskUnit, error) {
+	query := inboxQuery(cfgs)
+	query.Set("wait", junjo.InboxRecheck.String())
+	path := "/owners/" + url.PathEscape(string(ownerID)) + "/inbox?" + query.Encode()
+	for {
+		var inbox []InboxTaskUnit
+		if err := c.doContext(ctx, http.MethodGet, path, nil, &inbox); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if len(inbox) > 0 {
+			return FromInbox(inbox), nil
+		}
+	}
+}
+
+func (c *Client) GetInboxTopic(ownerID types.OwnerID, topicID types.TopicID, cfgs ...types.QueryConfig) ([]types.InboxTopicTaskUnit, error) {
+	var inbox []InboxTaskUnit
+	query := inboxQuery(cfgs)
+	query.Set("topic", string(topicID))
+	path := "/owners/" + url.PathEscape(string(ownerID)) + "/inbox?" + query.Encode()
+	if err := c.do(http.MethodGet, path, nil, &inbox); err != nil {
+		return nil, err
+	}
+	values := make([]types.InboxTopicTaskUnit, 0, len(inbox))
+	for i := 0; i < len(inbox); i++ {
+		values = append(values, types.InboxTopicTaskUnit{
+			JobID:     inbox[i].JobID,
+			TaskID:    inbox[i].TaskID,
+			TaskUnits: FromTaskUnits(inbox[i].TaskUnits),
+			Priority:  inbox[i].Priority,
+		})
+	}
+	return values, nil
+}
+
+func (c *Client) SaveTemplate(name string, workUnitDag *types.WorkUnitDag, cfgs ...types.TemplateConfig) (*types.Template, error) {
+	value := types.NewTemplate("", name, nil, cfgs...)
+	body := templateRequest{
+		Name:        name,
+		Description: value.Description,
+		Dag:         workUnitDag,
+		Layout:      value.Layout,
+	}
+	var template types.Template
+	if err := c.do(http.MethodPost, "/templates", body, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (c *Client) GetTemplate(id types.TemplateID) (*types.Template, error) {
+	var template types.Template
+	if err := c.do(http.MethodGet, "/templates/"+url.PathEscape(string(id)), nil, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (c *Client) GetTemplates() ([]types.Template, error) {
+	var templates []types.Template
+	if err := c.do(http.MethodGet, "/templates", nil, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (c *Client) GetTemplateVersions(name string) ([]types.Template, error) {
+	var templates []types.Template
+	if err := c.do(http.MethodGet, "/templates?name="+url.QueryEscape(name), nil, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (c *Client) LaunchTemplate(topicID types.TopicID, id types.TemplateID, cfgs ...types.JobConfig) (*types.Job, error) {
+	value := types.NewJob("", cfgs...)
+	var job types.Job
+	if err := c.do(http.MethodPost, "/templates/"+url.PathEscape(string(id))+"/jobs", launchTemplateRequest{TopicID: topicID, Data: value.Data, Priority: value.Priority}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Only the priority of the configs is sent, the server sets the data and the batch of each job
+func (c *Client) LaunchBatch(topicID types.TopicID, workUnitDag *types.WorkUnitDag, params []map[string]string, cfgs ...types.JobConfig) (types.BatchID, error) {
+	value := types.NewJob("", cfgs...)
+	var progress types.BatchProgress
+	if err := c.do(http.MethodPost, "/topics/"+url.PathEscape(string(topicID))+"/batches", batchRequest{Dag: workUnitDag, Params: params, Priority: value.Priority}, &progress); err != nil {
+		return "", err
+	}
+	return progress.Key, nil
+}
+
+func (c *Client) GetBatch(batchID types.BatchID) (*types.BatchProgress, error) {
+	var progress types.BatchProgress
+	if err := c.do(http.MethodGet, "/batches/"+url.PathEscape(string(batchID)), nil, &progress); err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+func (c *Client) CancelBatch(batchID types.BatchID) error {
+	return c.do(http.MethodPost, "/batches/"+url.PathEscape(string(batchID))+"/cancel", nil, nil)
+}
+
+// Query parameters of the inbox, only the aging is supported
+func inboxQuery(cfgs []types.QueryConfig) url.Values {
+	params := types.NewQuery(cfgs...)
+	query := url.Values{}
+	if params.Aging != nil {
+		query.Set("aging", params.Aging.String())
+	}
+	if params.Topic != nil {
+		query.Set("topic", string(*params.Topic))
+	}
+	return query
+}
+
+func (c *Client) SetConcurrencyLimit(limit types.ConcurrencyLimit) error {
+	return c.do(http.MethodPut, "/limits", limit, nil)
+}
+
+func (c *Client) GetConcurrencyLimits() ([]types.ConcurrencyLimit, error) {
+	var limits []types.ConcurrencyLimit
+	if err := c.do(http.MethodGet, "/limits", nil, &limits); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func (c *Client) GetAuditRecords() ([]types.AuditRecord, error) {
+	var records []types.AuditRecord
+	if err := c.do(http.MethodGet, "/audit", nil, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (c *Client) CreateSchedule(topicID types.TopicID, name string, cron string, cfgs ...types.ScheduleConfig) (*types.Schedule, error) {
+	value := types.NewSchedule("", topicID, name, cron, cfgs...)
+	body := scheduleRequest{
+		Name:       name,
+		Cron:       cron,
+		Timezone:   value.Timezone,
+		TemplateID: value.TemplateID,
+		Dag:        value.Dag,
+		Data:       value.Data,
+		Priority:   value.Priority,
+		Overlap:    value.Overlap,
+		CatchUp:    value.CatchUp,
+	}
+	var schedule types.Schedule
+	if err := c.do(http.MethodPost, "/topics/"+url.PathEscape(string(topicID))+"/schedules", body, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (c *Client) GetSchedule(id types.ScheduleID) (*types.Schedule, error) {
+	var schedule types.Schedule
+	if err := c.do(http.MethodGet, "/schedules/"+url.PathEscape(string(id)), nil, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (c *Client) GetSchedules(topicID types.TopicID) ([]types.Schedule, error) {
+	path := "/schedules"
+	if topicID != "" {
+		path = "/topics/" + url.PathEscape(string(topicID)) + "/schedules"
+	}
+	var schedules []types.Schedule
+	if err := c.do(http.MethodGet, path, nil, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (c *Client) DeleteSchedule(id types.ScheduleID) error {
+	return c.do(http.MethodDelete, "/schedules/"+url.PathEscape(string(id)), nil, nil)
+}
+
+func (c *Client) PauseSchedule(id types.ScheduleID) (*types.Schedule, error) {
+	var schedule types.Schedule
+	if err := c.do(http.MethodPost, "/schedules/"+url.PathEscape(string(id))+"/pause", nil, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (c *Client) ResumeSchedule(id types.ScheduleID) (*types.Schedule, error) {
+	var schedule types.Schedule
+	if err := c.do(http.MethodPost, "/schedules/"+url.PathEscape(string(id))+"/resume", nil, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (c *Client) CreateWebhook(url string, cfgs ...types.WebhookConfig) (*types.Webhook, error) {
+	value := types.NewWebhook("", url, cfgs...)
+	body := webhookRequest{
+		URL:     url,
+		Secret:  value.Secret,
+		TopicID: value.TopicID,
+		OwnerID: value.OwnerID,
+		Events:  value.Events,
+	}
+	var webhook types.Webhook
+	if err := c.do(http.MethodPost, "/webhooks", body, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) GetWebhook(id types.WebhookID) (*types.Webhook, error) {
+	var webhook types.Webhook
+	if err := c.do(http.MethodGet, "/webhooks/"+url.PathEscape(string(id)), nil, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) GetWebhooks(topicID types.TopicID) ([]types.Webhook, error) {
+	path := "/webhooks"
+	if topicID != "" {
+		path += "?" + url.Values{"topic": {string(topicID)}}.Encode()
+	}
+	var webhooks []types.Webhook
+	if err := c.do(http.MethodGet, path, nil, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (c *Client) DeleteWebhook(id types.WebhookID) error {
+	return c.do(http.MethodDelete, "/webhooks/"+url.PathEscape(string(id)), nil, nil)
+}
+
+func (c *Client) GetDeliveries(id types.WebhookID) ([]types.Delivery, error) {
+	var deliveries []types.Delivery
+	if err := c.do(http.MethodGet, "/webhooks/"+url.PathEscape(string(id))+"/deliveries", nil, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// The inbox as server-sent events, instead of polling `/owners/{id}/inbox`
+///
+///	id: 1
+///	event: inbox
+///	data: {"added":[{"jobID":"...","taskID":"...","taskUnits":[...],"priority":0}],"removed":[]}
+///
+/// The first event adds the whole inbox, the next ones only what changed (`InboxDelta`)
+/// A `: ping` comment is sent when nothing changed for `junjo.InboxRecheck` so the proxies keep the connection
+
+// Stream the changes of the inbox of the owner until the client leaves
+func (s *Server) inboxEvents(w http.ResponseWriter, r *http.Request, ownerID types.OwnerID) {
+	cfgs, err := inboxConfigs(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	offered := map[types.TaskUnitID]bool{}
+	for id := 1; ; {
+		changed := s.jj.InboxChanged(ownerID)
+		inbox, err := s.jj.GetInbox(ownerID, cfgs...)
+		if err != nil {
+			writeEvent(w, "error", id, errorResponse{Error: err.Error()})
+			flusher.Flush()
+			return
+		}
+		if delta := inboxDelta(offered, inbox); id == 1 || len(delta.Added) > 0 || len(delta.Removed) > 0 {
+			if err = writeEvent(w, "inbox", id, delta); err != nil {
+				return
+			}
+			id++
+		} else if _, err = io.WriteString(w, ": ping\n\n"); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		recheck := time.NewTimer(junjo.InboxRecheck)
+		select {
+		case <-r.Context().Done():
+			recheck.Stop()
+			return
+		case <-changed:
+		case <-recheck.C:
+		}
+		recheck.Stop()
+	}
+}
+
+// Compare the inbox with the units already offered, then remember it
+func inboxDelta(offered map[types.TaskUnitID]bool, inbox []types.InboxAllTaskUnit) InboxDelta {
+	delta := InboxDelta{
+		Added:   []InboxTaskUnit{},
+		Removed: []types.TaskUnitID{},
+	}
+	current := map[types.TaskUnitID]bool{}
+	for i := 0; i < len(inbox); i++ {
+		added := []types.TaskUnit{}
+		for k := 0; k < len(inbox[i].TaskUnits); k++ {
+			current[inbox[i].TaskUnits[k].Key] = true
+			if !offered[inbox[i].TaskUnits[k].Key] {
+				added = append(added, inbox[i].TaskUnits[k])
+			}
+		}
+		if len(added) == 0 {
+			continue
+		}
+		entry := inbox[i]
+		entry.TaskUnits = added
+		delta.Added = append(delta.Added, ToInbox([]types.InboxAllTaskUnit{entry})...)
+	}
+	for taskUnitID := range offered {
+		if !current[taskUnitID] {
+			delta.Removed = append(delta.Removed, taskUnitID)
+			delete(offered, taskUnitID)
+		}
+	}
+	sort.Slice(delta.Removed, func(i, k int) bool { return delta.Removed[i] < delta.Removed[k] })
+	for taskUnitID := range current {
+		offered[taskUnitID] = true
+	}
+	return delta
+}
+
+func writeEvent(w io.Writer, event string, id int, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, data)
+	return err
+}
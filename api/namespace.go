@@ -0,0 +1,58 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// The `Gateway` serves the same api for every namespace of a `junjo.Namespaces`
+///
+///	Authorization: Bearer <token>
+///	X-Junjo-Namespace: billing
+///
+/// The header can be omitted when the token reaches a single namespace, 401 without a known token and 403 outside of its namespaces or roles
+/// The template editor is served without a token, it can only reach a gateway without authentication
+
+const HeaderNamespace = "X-Junjo-Namespace"
+
+type Gateway struct {
+	namespaces *junjo.Namespaces
+}
+
+func NewGateway(namespaces *junjo.Namespaces) *Gateway {
+	return &Gateway{
+		namespaces: namespaces,
+	}
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if path[0] == "ui" || path[0] == "" {
+		(&Server{}).ServeHTTP(w, r)
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	jj, err := g.namespaces.Access(token, types.Namespace(r.Header.Get(HeaderNamespace)))
+	if err != nil {
+		writeError(w, namespaceStatus(err), err)
+		return
+	}
+	// the engine is bound to the principal of the token, it only lives for the request
+	NewServer(jj).ServeHTTP(w, r)
+}
+
+func namespaceStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, types.ErrNamespaceDenied):
+		return http.StatusForbidden
+	case errors.Is(err, types.ErrNamespaceInvalid):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+func (s *Server) topicSchedules(w http.ResponseWriter, r *http.Request, topicID types.TopicID) {
+	switch r.Method {
+	case http.MethodGet:
+		schedules, err := s.jj.GetSchedules(topicID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, schedules)
+
+	case http.MethodPost:
+		var body scheduleRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		schedule, err := s.jj.CreateSchedule(topicID, body.Name, body.Cron, body.configs()...)
+		if err != nil {
+			writeError(w, scheduleStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, schedule)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) schedules(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		schedules, err := s.jj.GetSchedules("")
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, schedules)
+
+	case len(path) == 1 && r.Method == http.MethodGet:
+		schedule, err := s.jj.GetSchedule(types.ScheduleID(path[0]))
+		if err != nil {
+			writeError(w, scheduleStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, schedule)
+
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		if err := s.jj.DeleteSchedule(types.ScheduleID(path[0])); err != nil {
+			writeError(w, scheduleStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && (path[1] == "pause" || path[1] == "resume") && r.Method == http.MethodPost:
+		var schedule *types.Schedule
+		var err error
+		if path[1] == "pause" {
+			schedule, err = s.jj.PauseSchedule(types.ScheduleID(path[0]))
+		} else {
+			schedule, err = s.jj.ResumeSchedule(types.ScheduleID(path[0]))
+		}
+		if err != nil {
+			writeError(w, scheduleStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, schedule)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func scheduleStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrScheduleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, types.ErrScheduleInvalid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,810 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// `Server` expose the `Junjoold` api over HTTP with JSON bodies
+///
+/// GET    /topics                     POST   /topics
+/// GET    /topics/{id}                PUT    /topics/{id}        DELETE /topics/{id} (deprecate)
+/// GET    /topics/{id}/jobs           POST   /topics/{id}/jobs   (create and assign a job from a `WorkUnitDag`)
+///                                    POST   /topics/{id}/batches (one job per set of parameters)
+/// GET    /batches/{id}               POST   /batches/{id}/cancel
+/// GET    /topics/{id}/schedules      POST   /topics/{id}/schedules
+/// GET    /schedules                  GET    /schedules/{id}     DELETE /schedules/{id}  POST /schedules/{id}/pause  POST /schedules/{id}/resume
+/// GET    /webhooks?topic={topicID}   POST   /webhooks           (the secret is never sent back)
+/// GET    /webhooks/{id}              DELETE /webhooks/{id}      GET    /webhooks/{id}/deliveries
+/// GET    /owners                     POST   /owners
+/// GET    /owners/{id}                PUT    /owners/{id}        DELETE /owners/{id} (deprecate)
+///                                    PUT    /owners/{id}/members (a pool when not empty)
+///                                    POST   /owners/{id}/migrate (its pending units go to a replacement, returns their ids)
+/// GET    /owners/{id}/inbox?topic={topicID}&aging={duration}&wait={duration}   (ordered by priority then age, `wait` blocks until it's not empty)
+/// GET    /owners/{id}/inbox/events?topic={topicID}&aging={duration}           (server-sent events of the units added and removed)
+/// GET    /definitions                POST   /definitions
+/// GET    /definitions/{id}           PUT    /definitions/{id}   DELETE /definitions/{id} (deprecate)
+/// GET    /definitions/{id}/versions  GET    /definitions/{id}/versions/{n} (a deprecated one can't be launched, 409)
+///                                    POST   /definitions/{id}/migrate (its pending units go to a replacement, returns their ids)
+/// GET    /jobs/{id}                  POST   /jobs/{id}/cancel   GET    /jobs/{id}/tasks    GET /jobs/{id}/dot
+/// GET    /tasks/{id}                 POST   /tasks/{id}/cancel  GET    /tasks/{id}/units   GET /tasks/{id}/dot
+/// GET    /units/{id}                 POST   /units/{id}/commands (409 when its dependencies or a concurrency limit refuse it)
+///                                    POST   /units/{id}/claim   POST   /units/{id}/release (409 when another member claimed it or its limits are full)
+///                                    POST   /units/{id}/reassign (422 when the owner is unknown or already has it)
+///                                    POST   /units/{id}/approve POST   /units/{id}/reject  (403 when the owner isn't an approver)
+/// GET    /units/{id}/state?at={n}    (its data patched by the commands, by the first n ones with `at`)
+/// GET    /limits                     PUT    /limits             (a `ConcurrencyLimit`, a max of zero removes it)
+/// GET    /templates?name={name}      POST   /templates          (save the next version)  POST /templates/validate
+/// GET    /templates/{id}             GET    /templates/{id}/versions                     POST /templates/{id}/jobs
+/// GET    /audit                      (the permission checks, for the admins)
+/// GET    /ui/                        web editor of the templates
+
+var (
+	ErrRouteNotFound = errors.New("route not found")
+)
+
+type Server struct {
+	jj *junjo.Junjoold
+}
+
+func NewServer(jj *junjo.Junjoold) *Server {
+	return &Server{
+		jj: jj,
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch path[0] {
+	case "topics":
+		s.topics(w, r, path[1:])
+	case "owners":
+		s.owners(w, r, path[1:])
+	case "definitions":
+		s.definitions(w, r, path[1:])
+	case "jobs":
+		s.jobs(w, r, path[1:])
+	case "tasks":
+		s.tasks(w, r, path[1:])
+	case "units":
+		s.units(w, r, path[1:])
+	case "limits":
+		s.limits(w, r, path[1:])
+	case "templates":
+		s.templates(w, r, path[1:])
+	case "batches":
+		s.batches(w, r, path[1:])
+	case "schedules":
+		s.schedules(w, r, path[1:])
+	case "webhooks":
+		s.webhooks(w, r, path[1:])
+	case "audit":
+		s.audit(w, r, path[1:])
+	case "ui":
+		s.ui(w, r)
+	case "":
+		http.Redirect(w, r, "/ui/", http.StatusFound)
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) topics(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		topics, err := s.jj.GetTopics()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		values := make([]types.Topic, 0, len(topics))
+		for i := 0; i < len(topics); i++ {
+			values = append(values, ToTopic(topics[i]))
+		}
+		writeJSON(w, http.StatusOK, values)
+
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var body topicRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		topic, err := s.jj.CreateTopic(body.Name, types.WithTopicDescription(body.Description))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, ToTopic(*topic))
+
+	case len(path) == 1 && r.Method == http.MethodGet:
+		topic, err := s.jj.GetTopic(types.TopicID(path[0]))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToTopic(*topic))
+
+	case len(path) == 1 && r.Method == http.MethodPut:
+		var body topicRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		topic, err := s.jj.UpdateTopic(types.TopicID(path[0]), body.Name)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToTopic(*topic))
+
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		if err := s.jj.DeprecateTopic(types.TopicID(path[0])); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && path[1] == "jobs" && r.Method == http.MethodGet:
+		jobs, err := s.jj.GetJobs(types.TopicID(path[0]))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		values := make([]types.Job, 0, len(jobs))
+		for i := 0; i < len(jobs); i++ {
+			values = append(values, ToJob(jobs[i]))
+		}
+		writeJSON(w, http.StatusOK, values)
+
+	case len(path) == 2 && path[1] == "jobs" && r.Method == http.MethodPost:
+		var body jobRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if body.Dag == nil {
+			writeError(w, http.StatusBadRequest, errors.New("a dag is required"))
+			return
+		}
+		job, err := s.jj.LaunchJob(types.TopicID(path[0]), body.Dag,
+			types.WithJobData(body.Data),
+			types.WithJobPriority(body.Priority),
+			types.WithJobDependsOn(body.DependsOn...),
+			types.WithJobUpstreamPolicy(body.UpstreamPolicy))
+		if err != nil {
+			writeError(w, dependencyStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, ToJob(*job))
+
+	case len(path) == 2 && path[1] == "schedules":
+		s.topicSchedules(w, r, types.TopicID(path[0]))
+
+	case len(path) == 2 && path[1] == "batches" && r.Method == http.MethodPost:
+		var body batchRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if body.Dag == nil {
+			writeError(w, http.StatusBadRequest, errors.New("a dag is required"))
+			return
+		}
+		batchID, err := s.jj.LaunchBatch(types.TopicID(path[0]), body.Dag, body.Params, types.WithJobPriority(body.Priority))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		progress, err := s.jj.GetBatch(batchID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, progress)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) batches(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+		return
+	}
+	batchID := types.BatchID(path[0])
+
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		progress, err := s.jj.GetBatch(batchID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, progress)
+
+	case len(path) == 2 && path[1] == "cancel" && r.Method == http.MethodPost:
+		if err := s.jj.CancelBatch(batchID); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, types.ErrBatchNotFound) {
+				status = http.StatusNotFound
+			}
+			writeError(w, status, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) owners(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		owners, err := s.jj.GetOwners()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, owners)
+
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var body ownerRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		owner, err := s.jj.CreateOwner(body.Name, types.WithOwnerDescription(body.Description), types.WithOwnerMembers(body.Members...))
+		if err != nil {
+			writeError(w, poolStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, owner)
+
+	case len(path) == 1 && r.Method == http.MethodGet:
+		owner, err := s.jj.GetOwner(types.OwnerID(path[0]))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, owner)
+
+	case len(path) == 1 && r.Method == http.MethodPut:
+		var body ownerRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		owner, err := s.jj.UpdateOwner(types.OwnerID(path[0]), body.Name)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, owner)
+
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		owner, err := s.jj.DeprecateOwner(types.OwnerID(path[0]))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, owner)
+
+	case len(path) == 2 && path[1] == "migrate" && r.Method == http.MethodPost:
+		var body migrateRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		migrated, err := s.jj.MigrateOwner(types.OwnerID(path[0]), types.OwnerID(body.To), body.Reason)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, migrated)
+
+	case len(path) == 2 && path[1] == "members" && r.Method == http.MethodPut:
+		var body membersRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		owner, err := s.jj.SetOwnerMembers(types.OwnerID(path[0]), body.Members...)
+		if err != nil {
+			writeError(w, poolStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, owner)
+
+	case len(path) == 2 && path[1] == "inbox" && r.Method == http.MethodGet:
+		ownerID := types.OwnerID(path[0])
+		cfgs, err := inboxConfigs(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		var inbox []types.InboxAllTaskUnit
+		if value := r.URL.Query().Get("wait"); value != "" {
+			wait, err := time.ParseDuration(value)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("wait: %w", err))
+				return
+			}
+			// long poll, an empty inbox when nothing came during the wait
+			ctx, cancel := context.WithTimeout(r.Context(), wait)
+			inbox, err = s.jj.WaitInbox(ctx, ownerID, cfgs...)
+			cancel()
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				err = nil
+			}
+		} else {
+			inbox, err = s.jj.GetInbox(ownerID, cfgs...)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToInbox(inbox))
+
+	case len(path) == 3 && path[1] == "inbox" && path[2] == "events" && r.Method == http.MethodGet:
+		s.inboxEvents(w, r, types.OwnerID(path[0]))
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) definitions(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		definitions, err := s.jj.GetTaskDefinitions()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, definitions)
+
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var body definitionRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		cfgs := []types.TaskDefinitionConfig{
+			types.WithTaskDefDescription(body.Description),
+			types.WithTaskDefIdentifier(body.Identifier),
+		}
+		if body.Approval != nil {
+			cfgs = append(cfgs,
+				types.WithTaskDefApproval(body.Approval.Required, body.Approval.Approvers...),
+				types.WithTaskDefApprovalExpiry(body.Approval.ExpiresAfter))
+		}
+		definition, err := s.jj.CreateTaskDefinition(body.Name, body.OwnerID, cfgs...)
+		if err != nil {
+			writeError(w, approvalStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, definition)
+
+	case len(path) == 1 && r.Method == http.MethodGet:
+		definition, err := s.jj.GetTaskDefinition(types.TaskDefinitionID(path[0]))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, definition)
+
+	case len(path) == 1 && r.Method == http.MethodPut:
+		var body definitionRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		definition, err := s.jj.UpdateTaskDefinition(types.TaskDefinitionID(path[0]), body.OwnerID, body.Name, body.Description, body.Identifier)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, definition)
+
+	case len(path) == 2 && path[1] == "migrate" && r.Method == http.MethodPost:
+		var body migrateRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		migrated, err := s.jj.MigrateTaskDefinition(types.TaskDefinitionID(path[0]), types.TaskDefinitionID(body.To))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, migrated)
+
+	case len(path) == 2 && path[1] == "versions" && r.Method == http.MethodGet:
+		versions, err := s.jj.GetTaskDefinitionVersions(types.TaskDefinitionID(path[0]))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, versions)
+
+	case len(path) == 3 && path[1] == "versions" && r.Method == http.MethodGet:
+		version, err := strconv.Atoi(path[2])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		definition, err := s.jj.GetTaskDefinitionVersion(types.TaskDefinitionID(path[0]), version)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, definition)
+
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		if err := s.jj.DeprecateTaskDefinition(types.TaskDefinitionID(path[0])); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) jobs(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+		return
+	}
+	jobID := types.JobID(path[0])
+
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		job, err := s.jj.GetJob(jobID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToJob(*job))
+
+	case len(path) == 2 && path[1] == "cancel" && r.Method == http.MethodPost:
+		if err := s.jj.CancelJob(jobID); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && path[1] == "dependencies" && r.Method == http.MethodPost:
+		var body dependenciesRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if err := s.jj.AddJobDependencies(jobID, body.DependsOn...); err != nil {
+			writeError(w, dependencyStatus(err), err)
+			return
+		}
+		job, err := s.jj.GetJob(jobID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToJob(*job))
+
+	case len(path) == 2 && path[1] == "dependents" && r.Method == http.MethodGet:
+		jobs, err := s.jj.GetDependentJobs(jobID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		values := make([]types.Job, 0, len(jobs))
+		for i := 0; i < len(jobs); i++ {
+			values = append(values, ToJob(jobs[i]))
+		}
+		writeJSON(w, http.StatusOK, values)
+
+	case len(path) == 2 && path[1] == "tasks" && r.Method == http.MethodGet:
+		tasks, err := s.jj.GetTasks(jobID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		values := make([]types.Task, 0, len(tasks))
+		for i := 0; i < len(tasks); i++ {
+			values = append(values, ToTask(tasks[i]))
+		}
+		writeJSON(w, http.StatusOK, values)
+
+	case len(path) == 2 && path[1] == "dot" && r.Method == http.MethodGet:
+		dot, err := s.jj.RenderJobDOT(jobID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeDOT(w, dot)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) tasks(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+		return
+	}
+	taskID := types.TaskID(path[0])
+
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		task, err := s.jj.GetTask(taskID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToTask(*task))
+
+	case len(path) == 2 && path[1] == "cancel" && r.Method == http.MethodPost:
+		if err := s.jj.CancelTask(taskID); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && path[1] == "units" && r.Method == http.MethodGet:
+		units, err := s.jj.GetTaskUnits(taskID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToTaskUnits(units))
+
+	case len(path) == 2 && path[1] == "dot" && r.Method == http.MethodGet:
+		dot, err := s.jj.RenderTaskDOT(taskID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeDOT(w, dot)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) units(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+		return
+	}
+	taskUnitID := types.TaskUnitID(path[0])
+
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		unit, err := s.jj.GetTaskUnit(taskUnitID)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToTaskUnit(*unit))
+
+	case len(path) == 2 && path[1] == "state" && r.Method == http.MethodGet:
+		var state *types.TaskUnitState
+		var err error
+		if at := r.URL.Query().Get("at"); at != "" {
+			var index int
+			if index, err = strconv.Atoi(at); err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			state, err = s.jj.GetTaskUnitStateAt(taskUnitID, index)
+		} else {
+			state, err = s.jj.GetTaskUnitState(taskUnitID)
+		}
+		if err != nil {
+			status := http.StatusNotFound
+			if errors.Is(err, types.ErrCommandIndexInvalid) {
+				status = http.StatusBadRequest
+			}
+			writeError(w, status, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, state)
+
+	case len(path) == 2 && path[1] == "commands" && r.Method == http.MethodPost:
+		var cmd types.Command
+		if !readJSON(w, r, &cmd) {
+			return
+		}
+		if err := s.jj.SubmitCommand(taskUnitID, cmd); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, types.ErrTaskUnitNotAvailable) || errors.Is(err, types.ErrTaskUnitClosed) || errors.Is(err, types.ErrConcurrencyLimitReached) {
+				status = http.StatusConflict
+			}
+			writeError(w, status, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && path[1] == "claim" && r.Method == http.MethodPost:
+		var body claimRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if err := s.jj.ClaimTaskUnit(taskUnitID, body.OwnerID); err != nil {
+			writeError(w, poolStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && path[1] == "release" && r.Method == http.MethodPost:
+		if err := s.jj.ReleaseTaskUnit(taskUnitID); err != nil {
+			writeError(w, poolStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && (path[1] == "approve" || path[1] == "reject") && r.Method == http.MethodPost:
+		var body reviewRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		review := s.jj.Approve
+		if path[1] == "reject" {
+			review = s.jj.Reject
+		}
+		if err := review(taskUnitID, body.OwnerID, body.Comment); err != nil {
+			writeError(w, approvalStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && path[1] == "reassign" && r.Method == http.MethodPost:
+		var body reassignRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if err := s.jj.ReassignTaskUnit(taskUnitID, body.OwnerID, body.Reason); err != nil {
+			writeError(w, reassignStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) limits(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		limits, err := s.jj.GetConcurrencyLimits()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, limits)
+
+	case len(path) == 0 && r.Method == http.MethodPut:
+		var limit types.ConcurrencyLimit
+		if !readJSON(w, r, &limit) {
+			return
+		}
+		if err := s.jj.SetConcurrencyLimit(limit); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func (s *Server) audit(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 0 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+		return
+	}
+	records, err := s.jj.GetAuditRecords()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// Query of the inbox endpoints
+func inboxConfigs(r *http.Request) ([]types.QueryConfig, error) {
+	cfgs := []types.QueryConfig{}
+	if value := r.URL.Query().Get("aging"); value != "" {
+		aging, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("aging: %w", err)
+		}
+		cfgs = append(cfgs, types.WithQueryAging(aging))
+	}
+	if topicID := r.URL.Query().Get("topic"); topicID != "" {
+		cfgs = append(cfgs, types.WithQueryTopic(types.TopicID(topicID)))
+	}
+	return cfgs, nil
+}
+
+func decodeJSON(r *http.Request, value interface{}) error {
+	return json.NewDecoder(r.Body).Decode(value)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	if err := decodeJSON(r, value); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	// the roles are checked by every route
+	if errors.Is(err, types.ErrPermissionDenied) {
+		status = http.StatusForbidden
+	}
+	// so are the deprecations by every new work
+	if errors.Is(err, types.ErrTaskDefinitionDeprecated) || errors.Is(err, types.ErrOwnerDeprecated) || errors.Is(err, types.ErrTopicDeprecated) {
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeDOT(w http.ResponseWriter, dot []byte) {
+	w.Header().Set("Content-Type", "text/vnd.graphviz")
+	w.WriteHeader(http.StatusOK)
+	w.Write(dot)
+}
+
+// A dependency which is missing or creates a cycle is unprocessable
+func poolStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrTaskUnitClaimed), errors.Is(err, types.ErrTaskUnitNotAvailable), errors.Is(err, types.ErrTaskUnitClosed), errors.Is(err, types.ErrConcurrencyLimitReached):
+		return http.StatusConflict
+	case errors.Is(err, types.ErrOwnerNotMember):
+		return http.StatusForbidden
+	case errors.Is(err, types.ErrOwnerPoolInvalid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+func approvalStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrTaskUnitNotAvailable), errors.Is(err, types.ErrTaskUnitClosed), errors.Is(err, types.ErrApprovalExpired):
+		return http.StatusConflict
+	case errors.Is(err, types.ErrNotApprover):
+		return http.StatusForbidden
+	case errors.Is(err, types.ErrApprovalInvalid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+func reassignStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrTaskUnitNotAvailable), errors.Is(err, types.ErrTaskUnitClosed):
+		return http.StatusConflict
+	case errors.Is(err, types.ErrReassignInvalid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+func dependencyStatus(err error) int {
+	if errors.Is(err, types.ErrJobDependencyInvalid) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
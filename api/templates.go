@@ -0,0 +1,129 @@
+package api
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"net/http"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+func (s *Server) templates(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet && r.URL.Query().Get("name") != "":
+		templates, err := s.jj.GetTemplateVersions(r.URL.Query().Get("name"))
+		if err != nil {
+			writeError(w, templateStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, templates)
+
+	case len(path) == 0 && r.Method == http.MethodGet:
+		templates, err := s.jj.GetTemplates()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, templates)
+
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var body templateRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if body.Dag == nil {
+			writeError(w, http.StatusBadRequest, errors.New("a dag is required"))
+			return
+		}
+		template, err := s.jj.SaveTemplate(
+			body.Name,
+			body.Dag,
+			types.WithTemplateDescription(body.Description),
+			types.WithTemplateLayout(body.Layout))
+		if err != nil {
+			writeError(w, templateStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, template)
+
+	case len(path) == 1 && path[0] == "validate" && r.Method == http.MethodPost:
+		var body templateRequest
+		if err := decodeJSON(r, &body); err != nil {
+			writeJSON(w, http.StatusOK, validateResponse{Error: err.Error()})
+			return
+		}
+		if body.Dag == nil {
+			writeJSON(w, http.StatusOK, validateResponse{Error: "a dag is required"})
+			return
+		}
+		if err := s.jj.ValidateTemplate(body.Dag); err != nil {
+			writeJSON(w, http.StatusOK, validateResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, validateResponse{Valid: true})
+
+	case len(path) == 1 && r.Method == http.MethodGet:
+		template, err := s.jj.GetTemplate(types.TemplateID(path[0]))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, template)
+
+	case len(path) == 2 && path[1] == "versions" && r.Method == http.MethodGet:
+		template, err := s.jj.GetTemplate(types.TemplateID(path[0]))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		templates, err := s.jj.GetTemplateVersions(template.Name)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, templates)
+
+	case len(path) == 2 && path[1] == "jobs" && r.Method == http.MethodPost:
+		var body launchTemplateRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		job, err := s.jj.LaunchTemplate(body.TopicID, types.TemplateID(path[0]), types.WithJobData(body.Data), types.WithJobPriority(body.Priority))
+		if err != nil {
+			writeError(w, templateStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, ToJob(*job))
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func templateStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, types.ErrTemplateInvalid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+// The editor is a single page talking to the api above
+func (s *Server) ui(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/ui" {
+		http.Redirect(w, r, "/ui/", http.StatusFound)
+		return
+	}
+	files, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	http.StripPrefix("/ui/", http.FileServer(http.FS(files))).ServeHTTP(w, r)
+}
@@ -0,0 +1,422 @@
+// Editor of the `Template` served by the junjo `Server`
+// The DAG is sent with the same JSON format than `WorkUnitDag.MarshalJSON`, the server validates it with `AcyclicGraph.Validate`
+(function () {
+	"use strict";
+
+	const NODE_WIDTH = 170;
+	const NODE_HEIGHT = 48;
+	const SVG = "http://www.w3.org/2000/svg";
+
+	const state = {
+		definitions: {},
+		owners: {},
+		nodes: [],
+		edges: [],
+		selected: null,
+		templateID: null, // saved version used to launch jobs
+	};
+
+	const $ = (id) => document.getElementById(id);
+	const canvas = $("canvas");
+
+	let counter = 0;
+	function newID() {
+		if (window.crypto && crypto.randomUUID) {
+			return crypto.randomUUID();
+		}
+		counter++;
+		return "vertex-" + Date.now() + "-" + counter;
+	}
+
+	async function api(method, path, body) {
+		const options = { method: method, headers: {} };
+		if (body !== undefined) {
+			options.body = JSON.stringify(body);
+			options.headers["Content-Type"] = "application/json";
+		}
+		const response = await fetch(path, options);
+		const text = await response.text();
+		const value = text ? JSON.parse(text) : null;
+		if (!response.ok) {
+			throw new Error(value && value.error ? value.error : response.statusText);
+		}
+		return value;
+	}
+
+	function parseData(text) {
+		const data = {};
+		text.split("\n").forEach((line) => {
+			const index = line.indexOf("=");
+			if (index > 0) {
+				data[line.slice(0, index).trim()] = line.slice(index + 1).trim();
+			}
+		});
+		return data;
+	}
+
+	function formatData(data) {
+		return Object.keys(data || {}).sort().map((key) => key + "=" + data[key]).join("\n");
+	}
+
+	function setStatus(text, valid) {
+		const status = $("status");
+		status.textContent = text;
+		status.className = valid === undefined ? "" : (valid ? "valid" : "invalid");
+	}
+
+	// WorkUnitDag JSON of the canvas
+	function toDag() {
+		const used = {};
+		const vertices = state.nodes.map((node) => {
+			used[node.definitionID] = state.definitions[node.definitionID];
+			return { id: node.id, definitionID: node.definitionID, status: "none", data: node.data };
+		});
+		return {
+			version: 1,
+			definitions: Object.values(used).filter(Boolean),
+			vertices: vertices,
+			edges: state.edges.map((edge) => ({ source: edge.source, target: edge.target })),
+		};
+	}
+
+	function toLayout() {
+		const layout = {};
+		state.nodes.forEach((node) => { layout[node.id] = { x: node.x, y: node.y }; });
+		return layout;
+	}
+
+	let validateTimer = null;
+	function changed() {
+		render();
+		clearTimeout(validateTimer);
+		validateTimer = setTimeout(validate, 200);
+	}
+
+	async function validate() {
+		if (state.nodes.length === 0) {
+			setStatus("empty template", false);
+			return;
+		}
+		try {
+			const result = await api("POST", "/templates/validate", { dag: toDag() });
+			setStatus(result.valid ? "valid" : result.error, result.valid);
+		} catch (err) {
+			setStatus(err.message, false);
+		}
+	}
+
+	function svg(name, attributes, parent) {
+		const element = document.createElementNS(SVG, name);
+		Object.keys(attributes).forEach((key) => element.setAttribute(key, attributes[key]));
+		if (parent) {
+			parent.appendChild(element);
+		}
+		return element;
+	}
+
+	function nodeByID(id) {
+		return state.nodes.find((node) => node.id === id);
+	}
+
+	function render() {
+		const edges = $("edges");
+		const nodes = $("nodes");
+		edges.innerHTML = "";
+		nodes.innerHTML = "";
+
+		const targets = {};
+		state.edges.forEach((edge, index) => {
+			const source = nodeByID(edge.source);
+			const target = nodeByID(edge.target);
+			if (!source || !target) {
+				return;
+			}
+			targets[edge.target] = true;
+			const line = svg("line", {
+				class: "edge",
+				x1: source.x + NODE_WIDTH, y1: source.y + NODE_HEIGHT / 2,
+				x2: target.x, y2: target.y + NODE_HEIGHT / 2,
+			}, edges);
+			line.addEventListener("click", () => {
+				state.edges.splice(index, 1);
+				changed();
+			});
+		});
+
+		state.nodes.forEach((node) => {
+			const definition = state.definitions[node.definitionID];
+			const owner = definition ? state.owners[definition.ownerID] : null;
+			const classes = ["node"];
+			if (state.selected === node.id) {
+				classes.push("selected");
+			}
+			if (!targets[node.id]) {
+				classes.push("root");
+			}
+			const group = svg("g", { class: classes.join(" "), transform: "translate(" + node.x + "," + node.y + ")", "data-id": node.id }, nodes);
+			svg("rect", { width: NODE_WIDTH, height: NODE_HEIGHT }, group);
+			svg("text", { x: 10, y: 20 }, group).textContent = definition ? definition.name : "unknown definition";
+			svg("text", { x: 10, y: 37, class: "owner" }, group).textContent = owner ? owner.name : "";
+			const handle = svg("circle", { class: "handle", cx: NODE_WIDTH, cy: NODE_HEIGHT / 2, r: 7 }, group);
+
+			group.addEventListener("mousedown", (event) => startMove(event, node));
+			handle.addEventListener("mousedown", (event) => startConnect(event, node));
+		});
+
+		renderPanel();
+	}
+
+	function renderPanel() {
+		const node = nodeByID(state.selected);
+		$("node-panel").hidden = !node;
+		if (!node) {
+			return;
+		}
+		const definition = state.definitions[node.definitionID];
+		$("node-definition").textContent = definition ? definition.name + " (" + definition.id + ")" : node.definitionID;
+		if (document.activeElement !== $("node-data")) {
+			$("node-data").value = formatData(node.data);
+		}
+	}
+
+	function point(event) {
+		const box = canvas.getBoundingClientRect();
+		return { x: event.clientX - box.left, y: event.clientY - box.top };
+	}
+
+	let moving = null;
+	let connecting = null;
+
+	function startMove(event, node) {
+		if (event.button !== 0 || connecting) {
+			return;
+		}
+		const at = point(event);
+		moving = { node: node, dx: at.x - node.x, dy: at.y - node.y };
+		state.selected = node.id;
+		render();
+	}
+
+	function startConnect(event, node) {
+		event.stopPropagation();
+		connecting = { source: node };
+		const pending = $("pending");
+		pending.setAttribute("x1", node.x + NODE_WIDTH);
+		pending.setAttribute("y1", node.y + NODE_HEIGHT / 2);
+		pending.setAttribute("x2", node.x + NODE_WIDTH);
+		pending.setAttribute("y2", node.y + NODE_HEIGHT / 2);
+		pending.setAttribute("visibility", "visible");
+	}
+
+	canvas.addEventListener("mousemove", (event) => {
+		const at = point(event);
+		if (moving) {
+			moving.node.x = Math.max(0, at.x - moving.dx);
+			moving.node.y = Math.max(0, at.y - moving.dy);
+			render();
+		}
+		if (connecting) {
+			$("pending").setAttribute("x2", at.x);
+			$("pending").setAttribute("y2", at.y);
+		}
+	});
+
+	window.addEventListener("mouseup", (event) => {
+		if (moving) {
+			moving = null;
+			changed();
+		}
+		if (connecting) {
+			$("pending").setAttribute("visibility", "hidden");
+			const element = event.target.closest ? event.target.closest(".node") : null;
+			const target = element ? nodeByID(element.getAttribute("data-id")) : null;
+			const source = connecting.source;
+			connecting = null;
+			if (!target || target.id === source.id) {
+				return;
+			}
+			const exists = state.edges.some((edge) => edge.source === source.id && edge.target === target.id);
+			if (!exists) {
+				state.edges.push({ source: source.id, target: target.id });
+				changed();
+			}
+		}
+	});
+
+	canvas.addEventListener("click", (event) => {
+		if (event.target === canvas) {
+			state.selected = null;
+			render();
+		}
+	});
+
+	canvas.addEventListener("dragover", (event) => event.preventDefault());
+	canvas.addEventListener("drop", (event) => {
+		event.preventDefault();
+		const definitionID = event.dataTransfer.getData("text/plain");
+		if (!state.definitions[definitionID]) {
+			return;
+		}
+		const at = point(event);
+		const node = { id: newID(), definitionID: definitionID, data: {}, x: Math.max(0, at.x - NODE_WIDTH / 2), y: Math.max(0, at.y - NODE_HEIGHT / 2) };
+		state.nodes.push(node);
+		state.selected = node.id;
+		changed();
+	});
+
+	function removeNode(id) {
+		state.nodes = state.nodes.filter((node) => node.id !== id);
+		state.edges = state.edges.filter((edge) => edge.source !== id && edge.target !== id);
+		if (state.selected === id) {
+			state.selected = null;
+		}
+		changed();
+	}
+
+	$("node-delete").addEventListener("click", () => removeNode(state.selected));
+	$("node-data").addEventListener("input", () => {
+		const node = nodeByID(state.selected);
+		if (node) {
+			node.data = parseData($("node-data").value);
+		}
+	});
+
+	window.addEventListener("keydown", (event) => {
+		const tag = document.activeElement ? document.activeElement.tagName : "";
+		if ((event.key === "Delete" || event.key === "Backspace") && state.selected && tag !== "INPUT" && tag !== "TEXTAREA") {
+			removeNode(state.selected);
+		}
+	});
+
+	async function loadDefinitions() {
+		const owners = await api("GET", "/owners");
+		(owners || []).forEach((owner) => { state.owners[owner.id] = owner; });
+
+		const definitions = await api("GET", "/definitions");
+		const list = $("definition-list");
+		list.innerHTML = "";
+		(definitions || []).sort((a, b) => a.name.localeCompare(b.name)).forEach((definition) => {
+			state.definitions[definition.id] = definition;
+			const item = document.createElement("li");
+			item.className = "definition";
+			item.draggable = true;
+			item.textContent = definition.name;
+			const owner = state.owners[definition.ownerID];
+			const small = document.createElement("small");
+			small.textContent = (owner ? owner.name : definition.ownerID) + (definition.description ? " - " + definition.description : "");
+			item.appendChild(small);
+			item.addEventListener("dragstart", (event) => event.dataTransfer.setData("text/plain", definition.id));
+			list.appendChild(item);
+		});
+	}
+
+	async function loadTopics() {
+		const topics = await api("GET", "/topics");
+		const select = $("topic");
+		select.innerHTML = "";
+		(topics || []).filter((topic) => !topic.deprecated).forEach((topic) => {
+			const option = document.createElement("option");
+			option.value = topic.id;
+			option.textContent = topic.name;
+			select.appendChild(option);
+		});
+	}
+
+	async function loadTemplates(selectedName) {
+		const templates = await api("GET", "/templates");
+		const select = $("templates");
+		select.innerHTML = "<option value=\"\">new template</option>";
+		(templates || []).forEach((template) => {
+			const option = document.createElement("option");
+			option.value = template.id;
+			option.textContent = template.name + " (v" + template.version + ")";
+			option.selected = template.name === selectedName;
+			select.appendChild(option);
+		});
+	}
+
+	async function loadVersions(templateID) {
+		const select = $("versions");
+		select.innerHTML = "";
+		if (!templateID) {
+			return;
+		}
+		const versions = await api("GET", "/templates/" + encodeURIComponent(templateID) + "/versions");
+		versions.slice().reverse().forEach((template) => {
+			const option = document.createElement("option");
+			option.value = template.id;
+			option.textContent = "v" + template.version + " - " + new Date(template.createdAt).toLocaleString();
+			option.selected = template.id === templateID;
+			select.appendChild(option);
+		});
+	}
+
+	async function openTemplate(templateID) {
+		if (!templateID) {
+			state.nodes = [];
+			state.edges = [];
+			state.selected = null;
+			state.templateID = null;
+			$("name").value = "";
+			$("description").value = "";
+			await loadVersions(null);
+			changed();
+			return;
+		}
+		const template = await api("GET", "/templates/" + encodeURIComponent(templateID));
+		const layout = template.layout || {};
+		state.nodes = template.dag.vertices.map((vertex, index) => {
+			const at = layout[vertex.id] || { x: 40 + (index % 4) * 220, y: 40 + Math.floor(index / 4) * 100 };
+			return { id: vertex.id, definitionID: vertex.definitionID, data: vertex.data || {}, x: at.x, y: at.y };
+		});
+		state.edges = template.dag.edges.map((edge) => ({ source: edge.source, target: edge.target }));
+		state.selected = null;
+		state.templateID = template.id;
+		$("name").value = template.name;
+		$("description").value = template.description;
+		await loadVersions(template.id);
+		changed();
+	}
+
+	$("templates").addEventListener("change", () => openTemplate($("templates").value).catch((err) => setStatus(err.message, false)));
+	$("versions").addEventListener("change", () => openTemplate($("versions").value).catch((err) => setStatus(err.message, false)));
+
+	$("save").addEventListener("click", async () => {
+		try {
+			const template = await api("POST", "/templates", {
+				name: $("name").value.trim(),
+				description: $("description").value,
+				dag: toDag(),
+				layout: toLayout(),
+			});
+			state.templateID = template.id;
+			await loadTemplates(template.name);
+			await loadVersions(template.id);
+			setStatus("saved " + template.name + " v" + template.version, true);
+		} catch (err) {
+			setStatus(err.message, false);
+		}
+	});
+
+	$("launch").addEventListener("submit", async (event) => {
+		event.preventDefault();
+		const result = $("launch-result");
+		if (!state.templateID) {
+			result.textContent = "save the template first";
+			return;
+		}
+		try {
+			const job = await api("POST", "/templates/" + encodeURIComponent(state.templateID) + "/jobs", {
+				topicID: $("topic").value,
+				data: parseData($("launch-data").value),
+			});
+			result.textContent = "job " + job.id + " launched";
+		} catch (err) {
+			result.textContent = err.message;
+		}
+	});
+
+	Promise.all([loadDefinitions(), loadTopics(), loadTemplates()])
+		.then(() => changed())
+		.catch((err) => setStatus(err.message, false));
+})();
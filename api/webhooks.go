@@ -0,0 +1,72 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+func (s *Server) webhooks(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		webhooks, err := s.jj.GetWebhooks(types.TopicID(r.URL.Query().Get("topic")))
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		values := make([]types.Webhook, 0, len(webhooks))
+		for i := 0; i < len(webhooks); i++ {
+			values = append(values, ToWebhook(webhooks[i]))
+		}
+		writeJSON(w, http.StatusOK, values)
+
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var body webhookRequest
+		if !readJSON(w, r, &body) {
+			return
+		}
+		webhook, err := s.jj.CreateWebhook(body.URL, body.configs()...)
+		if err != nil {
+			writeError(w, webhookStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, ToWebhook(*webhook))
+
+	case len(path) == 1 && r.Method == http.MethodGet:
+		webhook, err := s.jj.GetWebhook(types.WebhookID(path[0]))
+		if err != nil {
+			writeError(w, webhookStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ToWebhook(*webhook))
+
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		if err := s.jj.DeleteWebhook(types.WebhookID(path[0])); err != nil {
+			writeError(w, webhookStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(path) == 2 && path[1] == "deliveries" && r.Method == http.MethodGet:
+		deliveries, err := s.jj.GetDeliveries(types.WebhookID(path[0]))
+		if err != nil {
+			writeError(w, webhookStatus(err), err)
+			return
+		}
+		writeJSON(w, http.StatusOK, deliveries)
+
+	default:
+		writeError(w, http.StatusNotFound, ErrRouteNotFound)
+	}
+}
+
+func webhookStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrWebhookNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, types.ErrWebhookInvalid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
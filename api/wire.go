@@ -0,0 +1,255 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// The entities are sent without their runtime pointers (`Topic.Jobs`, `Job.Tasks`, `Task.TaskUnits`, `TaskUnit.DependsOn`), fetch them with their own endpoints
+/// `TaskUnit.Error` is an interface that can't be decoded, it's sent as a message
+
+// TaskUnit as sent over the wire
+type TaskUnit struct {
+	types.TaskUnit
+	Error string `json:"error,omitempty"`
+}
+
+// Inbox of an owner as sent over the wire
+type InboxTaskUnit struct {
+	TopicID   types.TopicID  `json:"topicID,omitempty"`
+	JobID     types.JobID    `json:"jobID"`
+	TaskID    types.TaskID   `json:"taskID"`
+	TaskUnits []TaskUnit     `json:"taskUnits"`
+	Priority  types.Priority `json:"priority"`
+}
+
+// Event of `/owners/{id}/inbox/events`, the first one adds the whole inbox
+type InboxDelta struct {
+	Added   []InboxTaskUnit    `json:"added"`   // new units, grouped by task like the inbox
+	Removed []types.TaskUnitID `json:"removed"` // units no longer offered
+}
+
+// Body of the creation and update requests
+type topicRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ownerRequest struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Members     []types.OwnerID `json:"members"`
+}
+
+type membersRequest struct {
+	Members []types.OwnerID `json:"members"`
+}
+
+type claimRequest struct {
+	OwnerID types.OwnerID `json:"ownerID"`
+}
+
+type reviewRequest struct {
+	OwnerID types.OwnerID `json:"ownerID"`
+	Comment string        `json:"comment"` // the reason of a rejection
+}
+
+type reassignRequest struct {
+	OwnerID types.OwnerID `json:"ownerID"`
+	Reason  string        `json:"reason"`
+}
+
+// `To` is the replacement owner or definition
+type migrateRequest struct {
+	To     string `json:"to"`
+	Reason string `json:"reason"` // only for the owners
+}
+
+type definitionRequest struct {
+	Name        string          `json:"name"`
+	OwnerID     types.OwnerID   `json:"ownerID"`
+	Description string          `json:"description"`
+	Identifier  string          `json:"identifier"`
+	Approval    *types.Approval `json:"approval,omitempty"` // only on creation
+}
+
+type jobRequest struct {
+	Dag            *types.WorkUnitDag   `json:"dag"`
+	Data           map[string]string    `json:"data"`
+	Priority       types.Priority       `json:"priority"`
+	DependsOn      []types.JobID        `json:"dependsOn"`
+	UpstreamPolicy types.UpstreamPolicy `json:"upstreamPolicy"`
+}
+
+type dependenciesRequest struct {
+	DependsOn []types.JobID `json:"dependsOn"`
+}
+
+type batchRequest struct {
+	Dag      *types.WorkUnitDag  `json:"dag"`
+	Params   []map[string]string `json:"params"`
+	Priority types.Priority      `json:"priority"`
+}
+
+type scheduleRequest struct {
+	Name       string              `json:"name"`
+	Cron       string              `json:"cron"`
+	Timezone   string              `json:"timezone"`
+	TemplateID types.TemplateID    `json:"templateID"`
+	Dag        json.RawMessage     `json:"dag"`
+	Data       map[string]string   `json:"data"`
+	Priority   types.Priority      `json:"priority"`
+	Overlap    types.OverlapPolicy `json:"overlap"`
+	CatchUp    bool                `json:"catchUp"`
+}
+
+func (r scheduleRequest) configs() []types.ScheduleConfig {
+	cfgs := []types.ScheduleConfig{
+		types.WithScheduleTimezone(r.Timezone),
+		types.WithScheduleTemplate(r.TemplateID),
+		types.WithScheduleDag(r.Dag),
+		types.WithSchedulePriority(r.Priority),
+		types.WithScheduleCatchUp(r.CatchUp),
+	}
+	if r.Data != nil {
+		cfgs = append(cfgs, types.WithScheduleData(r.Data))
+	}
+	if r.Overlap != "" {
+		cfgs = append(cfgs, types.WithScheduleOverlap(r.Overlap))
+	}
+	return cfgs
+}
+
+type templateRequest struct {
+	Name        string                                 `json:"name"`
+	Description string                                 `json:"description"`
+	Dag         *types.WorkUnitDag                     `json:"dag"`
+	Layout      map[types.TaskUnitID]types.Coordinates `json:"layout"`
+}
+
+type launchTemplateRequest struct {
+	TopicID  types.TopicID     `json:"topicID"`
+	Data     map[string]string `json:"data"`
+	Priority types.Priority    `json:"priority"`
+}
+
+type webhookRequest struct {
+	URL     string            `json:"url"`
+	Secret  string            `json:"secret"`
+	TopicID types.TopicID     `json:"topicID"`
+	OwnerID types.OwnerID     `json:"ownerID"`
+	Events  []types.EventType `json:"events"`
+}
+
+func (r webhookRequest) configs() []types.WebhookConfig {
+	cfgs := []types.WebhookConfig{
+		types.WithWebhookSecret(r.Secret),
+		types.WithWebhookTopic(r.TopicID),
+		types.WithWebhookOwner(r.OwnerID),
+	}
+	if r.Events != nil {
+		cfgs = append(cfgs, types.WithWebhookEvents(r.Events...))
+	}
+	return cfgs
+}
+
+type validateResponse struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ToTopic strips the runtime fields of a `Topic` before sending it
+func ToTopic(topic types.Topic) types.Topic {
+	topic.Jobs = nil
+	return topic
+}
+
+// ToJob strips the runtime fields of a `Job` before sending it
+func ToJob(job types.Job) types.Job {
+	job.Tasks = nil
+	return job
+}
+
+// ToTask strips the runtime fields of a `Task` before sending it
+func ToTask(task types.Task) types.Task {
+	task.TaskUnits = nil
+	return task
+}
+
+// ToWebhook strips the secret of a `Webhook` before sending it, it's only given at the creation
+func ToWebhook(webhook types.Webhook) types.Webhook {
+	webhook.Secret = ""
+	return webhook
+}
+
+// ToTaskUnit converts a `TaskUnit` before sending it
+func ToTaskUnit(unit types.TaskUnit) TaskUnit {
+	value := TaskUnit{TaskUnit: unit}
+	value.TaskUnit.DependsOn = nil
+	value.TaskUnit.Error = nil
+	if unit.Error != nil {
+		value.Error = unit.Error.Error()
+	}
+	return value
+}
+
+// ToInbox converts an inbox before sending it
+func ToInbox(inbox []types.InboxAllTaskUnit) []InboxTaskUnit {
+	values := make([]InboxTaskUnit, 0, len(inbox))
+	for i := 0; i < len(inbox); i++ {
+		values = append(values, InboxTaskUnit{
+			TopicID:   inbox[i].TopicID,
+			JobID:     inbox[i].JobID,
+			TaskID:    inbox[i].TaskID,
+			TaskUnits: ToTaskUnits(inbox[i].TaskUnits),
+			Priority:  inbox[i].Priority,
+		})
+	}
+	return values
+}
+
+// FromInbox converts back a received inbox
+func FromInbox(inbox []InboxTaskUnit) []types.InboxAllTaskUnit {
+	values := make([]types.InboxAllTaskUnit, 0, len(inbox))
+	for i := 0; i < len(inbox); i++ {
+		values = append(values, types.InboxAllTaskUnit{
+			TopicID:   inbox[i].TopicID,
+			JobID:     inbox[i].JobID,
+			TaskID:    inbox[i].TaskID,
+			TaskUnits: FromTaskUnits(inbox[i].TaskUnits),
+			Priority:  inbox[i].Priority,
+		})
+	}
+	return values
+}
+
+func ToTaskUnits(units []types.TaskUnit) []TaskUnit {
+	values := make([]TaskUnit, 0, len(units))
+	for i := 0; i < len(units); i++ {
+		values = append(values, ToTaskUnit(units[i]))
+	}
+	return values
+}
+
+// FromTaskUnit converts back a received `TaskUnit`
+func FromTaskUnit(unit TaskUnit) types.TaskUnit {
+	value := unit.TaskUnit
+	if unit.Error != "" {
+		value.Error = errors.New(unit.Error)
+	}
+	return value
+}
+
+func FromTaskUnits(units []TaskUnit) []types.TaskUnit {
+	values := make([]types.TaskUnit, 0, len(units))
+	for i := 0; i < len(units); i++ {
+		values = append(values, FromTaskUnit(units[i]))
+	}
+	return values
+}
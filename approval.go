@@ -0,0 +1,227 @@
+package junjo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Approval gates are units of a `TaskDefinition` with an `Approval`, the approvers review them from their inbox
+///
+///	jj.Approve(unit.Key, alice.Key, "backups checked")
+///	jj.SubmitCommand(unit.Key, types.Command{Type: types.RejectCmd, Details: "wrong rack", Data: map[string]string{types.Reviewer: string(bob.Key)}})
+///
+/// The gates past their expiry are failed by `ExpireApprovals`, the `Scheduler` calls it on each check, without one it has to be called by hand
+/// Until then an expired gate is no longer offered in the inbox and its reviews are refused
+
+func (j *Junjoold) validateApproval(approval *types.Approval) error {
+	if approval == nil {
+		return nil
+	}
+	if len(approval.Approvers) == 0 {
+		return fmt.Errorf("%w: no approver", types.ErrApprovalInvalid)
+	}
+	if approval.Required < 1 || approval.Required > len(approval.Approvers) {
+		return fmt.Errorf("%w: %v approval(s) required from %v approver(s)", types.ErrApprovalInvalid, approval.Required, len(approval.Approvers))
+	}
+	if approval.ExpiresAfter < 0 {
+		return fmt.Errorf("%w: negative expiry", types.ErrApprovalInvalid)
+	}
+	for i := 0; i < len(approval.Approvers); i++ {
+		has, err := j.storageImplementation.HasOwner(approval.Approvers[i])
+		if err != nil {
+			return err
+		}
+		if !has {
+			return fmt.Errorf("%w: approver %v not found", types.ErrApprovalInvalid, approval.Approvers[i])
+		}
+	}
+	return nil
+}
+
+// Approve a gate, it succeeds once it has the approvals it requires
+// Approving again a gate already approved by the same owner does nothing
+func (j *Junjoold) Approve(taskUnitID types.TaskUnitID, ownerID types.OwnerID, comment string) error {
+	if err := j.authorizeUnit(types.ActionUnitCommand, taskUnitID, ownerID); err != nil {
+		return err
+	}
+	unit, approval, err := j.reviewable(taskUnitID, ownerID)
+	if err != nil {
+		return err
+	}
+	approvals := types.Approvals(unit.Commands)
+	for i := 0; i < len(approvals); i++ {
+		if approvals[i] == ownerID {
+			return nil
+		}
+	}
+	if err = j.storageImplementation.AddTaskUnitCommand(taskUnitID, reviewCommand(types.ApproveCmd, ownerID, comment)); err != nil {
+		return err
+	}
+	if len(approvals)+1 < approval.Required {
+		// the reviewer doesn't see it anymore
+		j.notifyTask(unit.TaskID)
+		return nil
+	}
+	if err = j.storageImplementation.UpdateTaskUnitStatus(taskUnitID, types.SuccessStatus, nil); err != nil {
+		return err
+	}
+	return j.rollup(unit.TaskID)
+}
+
+// Reject a gate, it fails with the reason of the reviewer
+func (j *Junjoold) Reject(taskUnitID types.TaskUnitID, ownerID types.OwnerID, reason string) error {
+	if err := j.authorizeUnit(types.ActionUnitCommand, taskUnitID, ownerID); err != nil {
+		return err
+	}
+	unit, _, err := j.reviewable(taskUnitID, ownerID)
+	if err != nil {
+		return err
+	}
+	if err = j.storageImplementation.AddTaskUnitCommand(taskUnitID, reviewCommand(types.RejectCmd, ownerID, reason)); err != nil {
+		return err
+	}
+	if err = j.storageImplementation.UpdateTaskUnitStatus(taskUnitID, types.ErrorStatus, fmt.Errorf("rejected by %v: %v", ownerID, reason)); err != nil {
+		return err
+	}
+	return j.rollup(unit.TaskID)
+}
+
+// Fail the gates still waiting for reviews past their expiry, counted from the moment they became available
+// A gate still blocked by its dependencies or by its job doesn't expire
+// A principal only expires the gates of the topics it can cancel jobs on, the other topics are skipped
+func (j *Junjoold) ExpireApprovals(now time.Time) ([]types.TaskUnitID, error) {
+	expired := []types.TaskUnitID{}
+	topics, err := j.storageImplementation.GetTopics()
+	if err != nil {
+		return nil, err
+	}
+	for _, topic := range topics {
+		if err = j.authorizeTopic(types.ActionJobCancel, topic.Key); errors.Is(err, types.ErrPermissionDenied) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		jobs, err := j.storageImplementation.GetJobs(topic.Key)
+		if err != nil {
+			return expired, err
+		}
+		for _, job := range jobs {
+			if job.Status == types.SuccessStatus || job.Status == types.ErrorStatus {
+				continue
+			}
+			tasks, err := j.storageImplementation.GetTasks(job.Key)
+			if err != nil {
+				return expired, err
+			}
+			for _, task := range tasks {
+				units, err := j.storageImplementation.GetTaskUnits(task.Key)
+				if err != nil {
+					return expired, err
+				}
+				for _, unit := range units {
+					if unit.Status != types.NoneStatus || unit.TaskDefinitionID == "" {
+						continue
+					}
+					// the expiry of the version the gate pinned
+					definition, err := j.unitDefinition(&unit)
+					if err != nil {
+						return expired, err
+					}
+					approval := definition.Approval
+					if approval == nil || approval.ExpiresAfter <= 0 {
+						continue
+					}
+					// the clock only runs once the gate can be reviewed
+					available, err := j.canChangeStatus(&unit)
+					if err != nil {
+						return expired, err
+					}
+					if !available {
+						continue
+					}
+					if unit.AvailableAt.IsZero() {
+						// available before it was recorded, the clock starts now
+						if err = j.storageImplementation.MarkTaskUnitAvailable(unit.Key, now); err != nil {
+							return expired, err
+						}
+						continue
+					}
+					if !approval.Expired(unit.AvailableAt, now) {
+						continue
+					}
+					if err = j.expireApproval(&unit); err != nil {
+						return expired, err
+					}
+					expired = append(expired, unit.Key)
+				}
+			}
+		}
+	}
+	return expired, nil
+}
+
+func (j *Junjoold) expireApproval(unit *types.TaskUnit) error {
+	if err := j.storageImplementation.UpdateTaskUnitStatus(unit.Key, types.ErrorStatus, types.ErrApprovalExpired); err != nil {
+		return err
+	}
+	return j.rollup(unit.TaskID)
+}
+
+// The gate can be reviewed by the owner now, an expired gate fails on the way
+func (j *Junjoold) reviewable(taskUnitID types.TaskUnitID, ownerID types.OwnerID) (*types.TaskUnit, *types.Approval, error) {
+	unit, err := j.storageImplementation.GetTaskUnit(taskUnitID)
+	if err != nil {
+		return nil, nil, err
+	}
+	approval, err := j.approvalGate(unit)
+	if err != nil {
+		return nil, nil, err
+	}
+	if approval == nil {
+		return nil, nil, fmt.Errorf("%w: unit %v is not an approval gate", types.ErrApprovalInvalid, taskUnitID)
+	}
+	if !approval.Allows(ownerID) {
+		return nil, nil, fmt.Errorf("%w: %v can't review %v", types.ErrNotApprover, ownerID, taskUnitID)
+	}
+	if unit.Status != types.NoneStatus {
+		return nil, nil, types.ErrTaskUnitNotAvailable
+	}
+	available, err := j.canChangeStatus(unit)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !available {
+		return nil, nil, types.ErrTaskUnitNotAvailable
+	}
+	if approval.Expired(unit.AvailableAt, time.Now()) {
+		if err = j.expireApproval(unit); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, types.ErrApprovalExpired
+	}
+	return unit, approval, nil
+}
+
+// Approval of the definition of the unit, nil when it's not a gate
+func (j *Junjoold) approvalGate(unit *types.TaskUnit) (*types.Approval, error) {
+	if unit.TaskDefinitionID == "" {
+		return nil, nil
+	}
+	definition, err := j.unitDefinition(unit)
+	if err != nil {
+		return nil, err
+	}
+	return definition.Approval, nil
+}
+
+func reviewCommand(cmdType types.CommandType, ownerID types.OwnerID, details string) types.Command {
+	return types.Command{
+		Type:    cmdType,
+		Details: details,
+		Data:    map[string]string{types.Reviewer: string(ownerID)},
+	}
+}
@@ -0,0 +1,276 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestApprovalGate$ .
+func TestApprovalGate(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Datacenter"); err != nil {
+		t.Error(err)
+		return
+	}
+	owners := map[string]*types.Owner{}
+	for _, name := range []string{"ops", "alice", "bob", "carol"} {
+		if owners[name], err = jj.CreateOwner(name); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if _, err = jj.CreateTaskDefinition("approve nobody", owners["ops"].Key, types.WithTaskDefApproval(1)); !errors.Is(err, types.ErrApprovalInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrApprovalInvalid, err)
+		return
+	}
+	var gate, wipe *types.TaskDefinition
+	if gate, err = jj.CreateTaskDefinition("approve the wipe", owners["ops"].Key,
+		types.WithTaskDefApproval(2, owners["alice"].Key, owners["bob"].Key),
+		types.WithTaskDefApprovalExpiry(time.Hour)); err != nil {
+		t.Error(err)
+		return
+	}
+	if wipe, err = jj.CreateTaskDefinition("wipe", owners["ops"].Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	launch := func() (types.TaskUnitID, types.TaskUnitID) {
+		unitDag := jj.CreateDagTaskUnits()
+		review, wiping := unitDag.ConnectDef(unitDag.AddTaskDefinition(gate), unitDag.AddTaskDefinition(wipe))
+		if _, err := jj.LaunchJob(topic.Key, unitDag); err != nil {
+			t.Error(err)
+		}
+		return review.(*types.NodeTaskUnit).Unit.Key, wiping.(*types.NodeTaskUnit).Unit.Key
+	}
+	offered := func(name string) []types.TaskUnitID {
+		inbox, err := jj.GetInbox(owners[name].Key)
+		if err != nil {
+			t.Error(err)
+		}
+		ids := []types.TaskUnitID{}
+		for i := 0; i < len(inbox); i++ {
+			for k := 0; k < len(inbox[i].TaskUnits); k++ {
+				ids = append(ids, inbox[i].TaskUnits[k].Key)
+			}
+		}
+		return ids
+	}
+
+	reviewID, wipeID := launch()
+	if len(offered("ops")) != 0 || len(offered("alice")) != 1 || len(offered("bob")) != 1 {
+		t.Error("expected the gate offered to the approvers only")
+		return
+	}
+	if err = jj.SubmitCommand(reviewID, types.Command{Type: types.SuccessCmd}); !errors.Is(err, types.ErrTaskUnitNotAvailable) {
+		t.Errorf("expected a gate to refuse a success, got %v", err)
+		return
+	}
+	if err = jj.Approve(reviewID, owners["carol"].Key, "looks fine"); !errors.Is(err, types.ErrNotApprover) {
+		t.Errorf("expected %v, got %v", types.ErrNotApprover, err)
+		return
+	}
+	if err = jj.Approve(reviewID, owners["alice"].Key, "backups checked"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(offered("alice")) != 0 || len(offered("bob")) != 1 || len(offered("ops")) != 0 {
+		t.Error("expected the gate waiting for bob")
+		return
+	}
+	approve := types.Command{Type: types.ApproveCmd, Details: "go", Data: map[string]string{types.Reviewer: string(owners["bob"].Key)}}
+	if err = jj.SubmitCommand(reviewID, approve); err != nil {
+		t.Error(err)
+		return
+	}
+	if ids := offered("ops"); len(ids) != 1 || ids[0] != wipeID {
+		t.Errorf("expected the wipe offered once approved, got %v", ids)
+		return
+	}
+
+	reviewID, _ = launch()
+	if err = jj.Reject(reviewID, owners["bob"].Key, "wrong rack"); err != nil {
+		t.Error(err)
+		return
+	}
+	var unit *types.TaskUnit
+	if unit, err = jj.GetTaskUnit(reviewID); err != nil {
+		t.Error(err)
+		return
+	}
+	if unit.Status != types.ErrorStatus || unit.Error == nil || unit.Error.Error() != "rejected by "+string(owners["bob"].Key)+": wrong rack" {
+		t.Errorf("expected the gate failed with the reason, got %v %v", unit.Status, unit.Error)
+		return
+	}
+
+	reviewID, _ = launch()
+	var expired []types.TaskUnitID
+	if expired, err = jj.ExpireApprovals(time.Now().Add(2 * time.Hour)); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(expired) != 1 || expired[0] != reviewID {
+		t.Errorf("expected the last gate expired, got %v", expired)
+		return
+	}
+	if err = jj.Approve(reviewID, owners["alice"].Key, "late"); !errors.Is(err, types.ErrTaskUnitNotAvailable) {
+		t.Errorf("expected the expired gate closed, got %v", err)
+		return
+	}
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestApprovalExpiryAvailability$ .
+func TestApprovalExpiryAvailability(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Datacenter"); err != nil {
+		t.Error(err)
+		return
+	}
+	var ops, alice *types.Owner
+	if ops, err = jj.CreateOwner("ops"); err != nil {
+		t.Error(err)
+		return
+	}
+	if alice, err = jj.CreateOwner("alice"); err != nil {
+		t.Error(err)
+		return
+	}
+	var backup, gate *types.TaskDefinition
+	if backup, err = jj.CreateTaskDefinition("backup", ops.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if gate, err = jj.CreateTaskDefinition("approve the wipe", ops.Key,
+		types.WithTaskDefApproval(1, alice.Key),
+		types.WithTaskDefApprovalExpiry(time.Hour)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	backing, review := unitDag.ConnectDef(unitDag.AddTaskDefinition(backup), unitDag.AddTaskDefinition(gate))
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	backupID, reviewID := backing.(*types.NodeTaskUnit).Unit.Key, review.(*types.NodeTaskUnit).Unit.Key
+
+	// the backup takes longer than the expiry, the gate can't be reviewed yet so its clock doesn't run
+	var expired []types.TaskUnitID
+	if expired, err = jj.ExpireApprovals(time.Now().Add(2 * time.Hour)); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(expired) != 0 {
+		t.Errorf("expected the blocked gate kept, got %v", expired)
+		return
+	}
+
+	if err = jj.SubmitCommand(backupID, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	var unit *types.TaskUnit
+	if unit, err = jj.GetTaskUnit(reviewID); err != nil {
+		t.Error(err)
+		return
+	}
+	if unit.AvailableAt.IsZero() {
+		t.Error("expected the gate available once the backup succeeded")
+		return
+	}
+	if expired, err = jj.ExpireApprovals(unit.AvailableAt.Add(30 * time.Minute)); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(expired) != 0 {
+		t.Errorf("expected the gate kept within its expiry, got %v", expired)
+		return
+	}
+	if expired, err = jj.ExpireApprovals(unit.AvailableAt.Add(2 * time.Hour)); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(expired) != 1 || expired[0] != reviewID {
+		t.Errorf("expected the gate expired an hour after it became available, got %v", expired)
+		return
+	}
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestApprovalExpiryInbox$ .
+func TestApprovalExpiryInbox(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Datacenter"); err != nil {
+		t.Error(err)
+		return
+	}
+	var ops, alice *types.Owner
+	if ops, err = jj.CreateOwner("ops"); err != nil {
+		t.Error(err)
+		return
+	}
+	if alice, err = jj.CreateOwner("alice"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = jj.CreateTaskDefinition("approve nobody", ops.Key, types.WithTaskDefApprovalExpiry(time.Hour)); !errors.Is(err, types.ErrApprovalInvalid) {
+		t.Errorf("expected an expiry without approvers refused, got %v", err)
+		return
+	}
+	// the expiry given first is kept
+	var gate *types.TaskDefinition
+	if gate, err = jj.CreateTaskDefinition("approve the wipe", ops.Key,
+		types.WithTaskDefApprovalExpiry(50*time.Millisecond),
+		types.WithTaskDefApproval(1, alice.Key)); err != nil {
+		t.Error(err)
+		return
+	}
+	if gate.Approval.ExpiresAfter != 50*time.Millisecond {
+		t.Errorf("expected the expiry kept, got %v", gate.Approval.ExpiresAfter)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	reviewID := unitDag.AddTaskDefinition(gate)().(*types.NodeTaskUnit).Unit.Key
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	var inbox []types.InboxAllTaskUnit
+	if inbox, err = jj.GetInbox(alice.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox) != 1 {
+		t.Errorf("expected the gate offered, got %v", inbox)
+		return
+	}
+
+	// nothing ran `ExpireApprovals` yet, the gate still leaves the inbox
+	time.Sleep(100 * time.Millisecond)
+	if inbox, err = jj.GetInbox(alice.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox) != 0 {
+		t.Errorf("expected the expired gate hidden, got %v", inbox)
+		return
+	}
+	if err = jj.Approve(reviewID, alice.Key, "late"); !errors.Is(err, types.ErrApprovalExpired) {
+		t.Errorf("expected %v, got %v", types.ErrApprovalExpired, err)
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package junjo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// A batch is the same `WorkUnitDag` launched once for each set of parameters, like provisioning a whole rack
+/// Each `Job` receives its parameters as `Job.Data` and its own `TaskUnit` cloned from the DAG
+
+// Launch one `Job` for each set of parameters, either all the jobs are created or none
+// Every job is created before the first one is offered, a failure removes all of them and reports what couldn't be removed
+// The configs are applied to every job, like `types.WithJobPriority`
+func (j *Junjoold) LaunchBatch(topicID types.TopicID, workUnitDag *types.WorkUnitDag, params []map[string]string, cfgs ...types.JobConfig) (types.BatchID, error) {
+	if err := j.authorizeTopic(types.ActionJobLaunch, topicID); err != nil {
+		return "", err
+	}
+	if len(params) == 0 {
+		return "", fmt.Errorf("%w: a batch needs at least one set of parameters", types.ErrJobGraphInvalid)
+	}
+
+	// the dag might come from json without storage, we need ours to clone it
+	content, err := json.Marshal(workUnitDag)
+	if err != nil {
+		return "", err
+	}
+	workUnitDag = j.CreateDagTaskUnits()
+	if err = json.Unmarshal(content, workUnitDag); err != nil {
+		return "", err
+	}
+
+	uuid, err := j.storageImplementation.NewUUID()
+	if err != nil {
+		return "", err
+	}
+	batchID := types.BatchID(uuid)
+
+	// `CreateJobGraph` is atomic for one job, we remove the previous ones ourselves
+	jobs := []*types.Job{}
+	remove := func(err error) error {
+		errs := []error{err}
+		for k := 0; k < len(jobs); k++ {
+			errs = append(errs, j.storageImplementation.DeleteJob(jobs[k].Key))
+		}
+		return errors.Join(errs...)
+	}
+
+	for i := 0; i < len(params); i++ {
+		var job *types.Job
+		var clone *types.WorkUnitDag
+		if clone, err = workUnitDag.Clone(); err == nil {
+			jobCfgs := append([]types.JobConfig{}, cfgs...)
+			jobCfgs = append(jobCfgs, types.WithJobData(params[i]), types.WithJobBatchID(batchID))
+			job, err = j.createJob(topicID, clone, jobCfgs...)
+		}
+		if err != nil {
+			return "", remove(fmt.Errorf("job %v of the batch: %w", i, err))
+		}
+		jobs = append(jobs, job)
+	}
+
+	for i := 0; i < len(jobs); i++ {
+		if err = j.startJob(jobs[i]); err != nil {
+			return "", remove(fmt.Errorf("job %v of the batch: %w", i, err))
+		}
+	}
+
+	return batchID, nil
+}
+
+// Count the jobs of the batch by status
+func (j *Junjoold) GetBatch(batchID types.BatchID) (*types.BatchProgress, error) {
+	jobs, err := j.storageImplementation.GetBatchJobs(batchID)
+	if err != nil {
+		return nil, err
+	}
+	// the jobs of a batch share their topic
+	if len(jobs) > 0 {
+		if err = j.authorize(types.ActionJobRead, types.Scope{TopicID: jobs[0].TopicID}, string(batchID)); err != nil {
+			return nil, err
+		}
+	}
+
+	progress := &types.BatchProgress{
+		Key:      batchID,
+		Total:    len(jobs),
+		Statuses: map[types.StatusType]int{},
+		JobIDs:   []types.JobID{},
+	}
+	statuses := []types.StatusType{}
+	for i := 0; i < len(jobs); i++ {
+		progress.TopicID = jobs[i].TopicID
+		progress.Statuses[jobs[i].Status]++
+		progress.JobIDs = append(progress.JobIDs, jobs[i].Key)
+		statuses = append(statuses, jobs[i].Status)
+	}
+	progress.Status = types.RollupStatus(statuses)
+
+	return progress, nil
+}
+
+// Cancel every `Job` of the batch
+func (j *Junjoold) CancelBatch(batchID types.BatchID) error {
+	jobs, err := j.storageImplementation.GetBatchJobs(batchID)
+	if err != nil {
+		return err
+	}
+	// the jobs of a batch share their topic
+	if len(jobs) > 0 {
+		if err = j.authorize(types.ActionJobCancel, types.Scope{TopicID: jobs[0].TopicID}, string(batchID)); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < len(jobs); i++ {
+		if err = j.cancelJob(jobs[i].Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package junjo
+
+import (
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestLaunchBatch$ .
+func TestLaunchBatch(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var racks *types.Owner
+	if racks, err = jj.CreateOwner("Racks"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	unitDag.ConnectDef(unitDag.AddTaskDefinition(provisioning), unitDag.AddTaskDefinition(provisioning))
+	// every job keeps the owner given to the units of the dag
+	for _, vertex := range unitDag.Graph().Vertices() {
+		vertex.(*types.NodeTaskUnit).Unit.OwnerID = racks.Key
+	}
+
+	params := []map[string]string{
+		{"machine": "rack-1"},
+		{"machine": "rack-2"},
+		{"machine": "rack-3"},
+	}
+
+	var batchID types.BatchID
+	if batchID, err = jj.LaunchBatch(topic.Key, unitDag, params); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var progress *types.BatchProgress
+	if progress, err = jj.GetBatch(batchID); err != nil {
+		t.Error(err)
+		return
+	}
+	if progress.Total != len(params) || progress.TopicID != topic.Key {
+		t.Errorf("expected %v jobs on %v, got %v", len(params), topic.Key, progress)
+		return
+	}
+
+	units := map[types.TaskUnitID]bool{}
+	machines := map[string]bool{}
+	for i := 0; i < len(progress.JobIDs); i++ {
+		var job *types.Job
+		if job, err = jj.GetJob(progress.JobIDs[i]); err != nil {
+			t.Error(err)
+			return
+		}
+		if job.BatchID != batchID {
+			t.Errorf("expected job %v in batch %v, got %v", job.Key, batchID, job.BatchID)
+			return
+		}
+		machines[job.Data["machine"]] = true
+
+		var tasks []types.Task
+		if tasks, err = jj.GetTasks(job.Key); err != nil {
+			t.Error(err)
+			return
+		}
+		for k := 0; k < len(tasks); k++ {
+			for l := 0; l < len(tasks[k].TaskUnitIDs); l++ {
+				if units[tasks[k].TaskUnitIDs[l]] {
+					t.Errorf("unit %v shared between jobs", tasks[k].TaskUnitIDs[l])
+					return
+				}
+				units[tasks[k].TaskUnitIDs[l]] = true
+
+				var unit *types.TaskUnit
+				if unit, err = jj.GetTaskUnit(tasks[k].TaskUnitIDs[l]); err != nil {
+					t.Error(err)
+					return
+				}
+				if unit.OwnerID != racks.Key || unit.DefinitionVersion != provisioning.Version {
+					t.Errorf("expected unit %v owned by %v on version %v, got %v on %v", unit.Key, racks.Key, provisioning.Version, unit.OwnerID, unit.DefinitionVersion)
+					return
+				}
+			}
+		}
+	}
+	if len(machines) != len(params) || len(units) != 2*len(params) {
+		t.Errorf("expected %v distinct machines and %v units, got %v and %v", len(params), 2*len(params), machines, len(units))
+		return
+	}
+
+	if err = jj.CancelBatch(batchID); err != nil {
+		t.Error(err)
+		return
+	}
+	if progress, err = jj.GetBatch(batchID); err != nil {
+		t.Error(err)
+		return
+	}
+	if progress.Status != types.ErrorStatus {
+		t.Errorf("expected a cancelled batch to be %v, got %v", types.ErrorStatus, progress.Status)
+		return
+	}
+}
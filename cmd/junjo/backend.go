@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/api"
+	"github.com/davidroman0O/junjo/sqlite"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// Everything the cli need, implemented by `Junjoold` for a local store and by `api.Client` for a remote server
+type backend interface {
+	CreateTopic(name string, cfgs ...types.TopicConfig) (*types.Topic, error)
+	GetTopics() ([]types.Topic, error)
+	GetTopic(id types.TopicID) (*types.Topic, error)
+	UpdateTopic(id types.TopicID, name string) (*types.Topic, error)
+	DeprecateTopic(id types.TopicID) error
+
+	CreateOwner(name string, cfgs ...types.OwnerConfig) (*types.Owner, error)
+	GetOwners() ([]types.Owner, error)
+	GetOwner(ownerID types.OwnerID) (*types.Owner, error)
+	UpdateOwner(ownerID types.OwnerID, name string) (*types.Owner, error)
+	DeprecateOwner(ownerID types.OwnerID) (*types.Owner, error)
+	SetOwnerMembers(ownerID types.OwnerID, members ...types.OwnerID) (*types.Owner, error)
+
+	CreateTaskDefinition(name string, ownerID types.OwnerID, cfgs ...types.TaskDefinitionConfig) (*types.TaskDefinition, error)
+	GetTaskDefinitions() ([]types.TaskDefinition, error)
+	GetTaskDefinition(id types.TaskDefinitionID) (*types.TaskDefinition, error)
+	UpdateTaskDefinition(id types.TaskDefinitionID, ownerID types.OwnerID, name string, description string, identifier string) (*types.TaskDefinition, error)
+	DeprecateTaskDefinition(id types.TaskDefinitionID) error
+	GetTaskDefinitionVersions(id types.TaskDefinitionID) ([]types.TaskDefinition, error)
+	MigrateTaskDefinition(from types.TaskDefinitionID, to types.TaskDefinitionID) ([]types.TaskUnitID, error)
+	MigrateOwner(from types.OwnerID, to types.OwnerID, reason string) ([]types.TaskUnitID, error)
+
+	LaunchJob(topicID types.TopicID, workUnitDag *types.WorkUnitDag, cfgs ...types.JobConfig) (*types.Job, error)
+	GetJobs(topicID types.TopicID) ([]types.Job, error)
+	GetJob(jobID types.JobID) (*types.Job, error)
+	CancelJob(jobID types.JobID) error
+	AddJobDependencies(jobID types.JobID, upstreamIDs ...types.JobID) error
+	GetDependentJobs(jobID types.JobID) ([]types.Job, error)
+	RenderJobDOT(jobID types.JobID) ([]byte, error)
+
+	LaunchBatch(topicID types.TopicID, workUnitDag *types.WorkUnitDag, params []map[string]string, cfgs ...types.JobConfig) (types.BatchID, error)
+	GetBatch(batchID types.BatchID) (*types.BatchProgress, error)
+	CancelBatch(batchID types.BatchID) error
+
+	GetTasks(jobID types.JobID) ([]types.Task, error)
+	GetTask(taskID types.TaskID) (*types.Task, error)
+	CancelTask(taskID types.TaskID) error
+	RenderTaskDOT(taskID types.TaskID) ([]byte, error)
+
+	GetTaskUnits(taskID types.TaskID) ([]types.TaskUnit, error)
+	GetTaskUnit(taskUnitID types.TaskUnitID) (*types.TaskUnit, error)
+	GetTaskUnitState(taskUnitID types.TaskUnitID) (*types.TaskUnitState, error)
+	GetTaskUnitStateAt(taskUnitID types.TaskUnitID, index int) (*types.TaskUnitState, error)
+	SubmitCommand(taskUnitID types.TaskUnitID, cmd types.Command) error
+	ClaimTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID) error
+	ReleaseTaskUnit(taskUnitID types.TaskUnitID) error
+	ReassignTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID, reason string) error
+	Approve(taskUnitID types.TaskUnitID, ownerID types.OwnerID, comment string) error
+	Reject(taskUnitID types.TaskUnitID, ownerID types.OwnerID, reason string) error
+
+	SaveTemplate(name string, workUnitDag *types.WorkUnitDag, cfgs ...types.TemplateConfig) (*types.Template, error)
+	GetTemplates() ([]types.Template, error)
+	GetTemplateVersions(name string) ([]types.Template, error)
+	LaunchTemplate(topicID types.TopicID, id types.TemplateID, cfgs ...types.JobConfig) (*types.Job, error)
+
+	CreateSchedule(topicID types.TopicID, name string, cron string, cfgs ...types.ScheduleConfig) (*types.Schedule, error)
+	GetSchedule(id types.ScheduleID) (*types.Schedule, error)
+	GetSchedules(topicID types.TopicID) ([]types.Schedule, error)
+	DeleteSchedule(id types.ScheduleID) error
+	PauseSchedule(id types.ScheduleID) (*types.Schedule, error)
+	ResumeSchedule(id types.ScheduleID) (*types.Schedule, error)
+
+	CreateWebhook(url string, cfgs ...types.WebhookConfig) (*types.Webhook, error)
+	GetWebhook(id types.WebhookID) (*types.Webhook, error)
+	GetWebhooks(topicID types.TopicID) ([]types.Webhook, error)
+	DeleteWebhook(id types.WebhookID) error
+	GetDeliveries(id types.WebhookID) ([]types.Delivery, error)
+
+	SetConcurrencyLimit(limit types.ConcurrencyLimit) error
+	GetConcurrencyLimits() ([]types.ConcurrencyLimit, error)
+	GetAuditRecords() ([]types.AuditRecord, error)
+
+	GetInbox(ownerID types.OwnerID, cfgs ...types.QueryConfig) ([]types.InboxAllTaskUnit, error)
+	WaitInbox(ctx context.Context, ownerID types.OwnerID, cfgs ...types.QueryConfig) ([]types.InboxAllTaskUnit, error)
+}
+
+// Local store, a SQLite file where each change of the command is written in its own transaction, see `sqlite.Store`
+// The file is only locked while a change is written so a command can run next to a server on the same store
+// Every namespace is in the same file, the engine only sees the one it was opened for
+type localBackend struct {
+	*junjo.Junjoold
+	store *sqlite.Store
+}
+
+func openLocal(path string, namespace types.Namespace) (*localBackend, error) {
+	if namespace == "" {
+		namespace = types.DefaultNamespace
+	}
+	store, err := sqlite.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localBackend{
+		Junjoold: junjo.NewJ(store.Storage().Namespace(namespace)),
+		store:    store,
+	}, nil
+}
+
+// Release the store, every change was already written
+func (l *localBackend) close() error {
+	return l.store.Close()
+}
+
+func openRemote(server string, namespace types.Namespace, token string) *api.Client {
+	return api.NewClient(server, nil, api.WithClientNamespace(namespace), api.WithClientToken(token))
+}
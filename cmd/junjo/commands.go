@@ -0,0 +1,1003 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/davidroman0O/junjo/api"
+	"github.com/davidroman0O/junjo/types"
+)
+
+func subcommand(noun string, args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("%w: %v needs a subcommand", ErrUsage, noun)
+	}
+	return args[0], args[1:], nil
+}
+
+func topicCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("topic", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		if _, err = parseArgs(flag.NewFlagSet("topic list", flag.ContinueOnError), args, 0); err != nil {
+			return err
+		}
+		topics, err := b.GetTopics()
+		if err != nil {
+			return err
+		}
+		return out.topics(topics)
+
+	case "create":
+		fs := flag.NewFlagSet("topic create", flag.ContinueOnError)
+		description := fs.String("description", "", "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		topic, err := b.CreateTopic(values[0], types.WithTopicDescription(*description))
+		if err != nil {
+			return err
+		}
+		return out.topics([]types.Topic{*topic})
+
+	case "rename":
+		values, err := parseArgs(flag.NewFlagSet("topic rename", flag.ContinueOnError), args, 2)
+		if err != nil {
+			return err
+		}
+		topic, err := b.UpdateTopic(types.TopicID(values[0]), values[1])
+		if err != nil {
+			return err
+		}
+		return out.topics([]types.Topic{*topic})
+
+	case "deprecate":
+		values, err := parseArgs(flag.NewFlagSet("topic deprecate", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		if err = b.DeprecateTopic(types.TopicID(values[0])); err != nil {
+			return err
+		}
+		topic, err := b.GetTopic(types.TopicID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.topics([]types.Topic{*topic})
+	}
+
+	return fmt.Errorf("%w: unknown topic subcommand %q", ErrUsage, verb)
+}
+
+func ownerCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("owner", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		if _, err = parseArgs(flag.NewFlagSet("owner list", flag.ContinueOnError), args, 0); err != nil {
+			return err
+		}
+		owners, err := b.GetOwners()
+		if err != nil {
+			return err
+		}
+		return out.owners(owners)
+
+	case "create":
+		fs := flag.NewFlagSet("owner create", flag.ContinueOnError)
+		description := fs.String("description", "", "")
+		members := ownerIDs{}
+		fs.Var(&members, "member", "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		owner, err := b.CreateOwner(values[0], types.WithOwnerDescription(*description), types.WithOwnerMembers(members...))
+		if err != nil {
+			return err
+		}
+		return out.owners([]types.Owner{*owner})
+
+	case "members":
+		fs := flag.NewFlagSet("owner members", flag.ContinueOnError)
+		if err = fs.Parse(args); err != nil {
+			return fmt.Errorf("%w: %v", ErrUsage, err)
+		}
+		if fs.NArg() < 1 {
+			return fmt.Errorf("%w: owner members expects an owner", ErrUsage)
+		}
+		members := []types.OwnerID{}
+		for _, member := range fs.Args()[1:] {
+			members = append(members, types.OwnerID(member))
+		}
+		owner, err := b.SetOwnerMembers(types.OwnerID(fs.Arg(0)), members...)
+		if err != nil {
+			return err
+		}
+		return out.owners([]types.Owner{*owner})
+
+	case "rename":
+		values, err := parseArgs(flag.NewFlagSet("owner rename", flag.ContinueOnError), args, 2)
+		if err != nil {
+			return err
+		}
+		owner, err := b.UpdateOwner(types.OwnerID(values[0]), values[1])
+		if err != nil {
+			return err
+		}
+		return out.owners([]types.Owner{*owner})
+
+	case "deprecate":
+		values, err := parseArgs(flag.NewFlagSet("owner deprecate", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		owner, err := b.DeprecateOwner(types.OwnerID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.owners([]types.Owner{*owner})
+
+	case "migrate":
+		fs := flag.NewFlagSet("owner migrate", flag.ContinueOnError)
+		reason := fs.String("reason", "", "")
+		values, err := parseArgs(fs, args, 2)
+		if err != nil {
+			return err
+		}
+		migrated, err := b.MigrateOwner(types.OwnerID(values[0]), types.OwnerID(values[1]), *reason)
+		if err != nil {
+			return err
+		}
+		return migratedUnits(b, out, migrated)
+	}
+
+	return fmt.Errorf("%w: unknown owner subcommand %q", ErrUsage, verb)
+}
+
+func definitionCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("definition", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		if _, err = parseArgs(flag.NewFlagSet("definition list", flag.ContinueOnError), args, 0); err != nil {
+			return err
+		}
+		definitions, err := b.GetTaskDefinitions()
+		if err != nil {
+			return err
+		}
+		return out.definitions(definitions)
+
+	case "create":
+		fs := flag.NewFlagSet("definition create", flag.ContinueOnError)
+		ownerID := fs.String("owner", "", "")
+		description := fs.String("description", "", "")
+		identifier := fs.String("identifier", "", "")
+		approvers := ownerIDs{}
+		fs.Var(&approvers, "approver", "")
+		approvals := fs.Int("approvals", 1, "")
+		expires := fs.Duration("expires", 0, "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		if *ownerID == "" {
+			return fmt.Errorf("%w: definition create needs -owner", ErrUsage)
+		}
+		cfgs := []types.TaskDefinitionConfig{
+			types.WithTaskDefDescription(*description),
+			types.WithTaskDefIdentifier(*identifier),
+		}
+		if len(approvers) > 0 {
+			cfgs = append(cfgs, types.WithTaskDefApproval(*approvals, approvers...), types.WithTaskDefApprovalExpiry(*expires))
+		}
+		definition, err := b.CreateTaskDefinition(values[0], types.OwnerID(*ownerID), cfgs...)
+		if err != nil {
+			return err
+		}
+		return out.definitions([]types.TaskDefinition{*definition})
+
+	case "update":
+		fs := flag.NewFlagSet("definition update", flag.ContinueOnError)
+		ownerID := fs.String("owner", "", "")
+		name := fs.String("name", "", "")
+		description := fs.String("description", "", "")
+		identifier := fs.String("identifier", "", "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		id := types.TaskDefinitionID(values[0])
+		current, err := b.GetTaskDefinition(id)
+		if err != nil {
+			return err
+		}
+		// only the given flags are changed
+		visited := map[string]bool{}
+		fs.Visit(func(f *flag.Flag) { visited[f.Name] = true })
+		if visited["owner"] {
+			current.OwnerID = types.OwnerID(*ownerID)
+		}
+		if visited["name"] {
+			current.Name = *name
+		}
+		if visited["description"] {
+			current.Description = *description
+		}
+		if visited["identifier"] {
+			current.Identifier = *identifier
+		}
+		definition, err := b.UpdateTaskDefinition(id, current.OwnerID, current.Name, current.Description, current.Identifier)
+		if err != nil {
+			return err
+		}
+		return out.definitions([]types.TaskDefinition{*definition})
+
+	case "versions":
+		values, err := parseArgs(flag.NewFlagSet("definition versions", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		versions, err := b.GetTaskDefinitionVersions(types.TaskDefinitionID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.definitions(versions)
+
+	case "migrate":
+		values, err := parseArgs(flag.NewFlagSet("definition migrate", flag.ContinueOnError), args, 2)
+		if err != nil {
+			return err
+		}
+		migrated, err := b.MigrateTaskDefinition(types.TaskDefinitionID(values[0]), types.TaskDefinitionID(values[1]))
+		if err != nil {
+			return err
+		}
+		return migratedUnits(b, out, migrated)
+
+	case "deprecate":
+		values, err := parseArgs(flag.NewFlagSet("definition deprecate", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		if err = b.DeprecateTaskDefinition(types.TaskDefinitionID(values[0])); err != nil {
+			return err
+		}
+		definition, err := b.GetTaskDefinition(types.TaskDefinitionID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.definitions([]types.TaskDefinition{*definition})
+	}
+
+	return fmt.Errorf("%w: unknown definition subcommand %q", ErrUsage, verb)
+}
+
+func jobCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("job", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		values, err := parseArgs(flag.NewFlagSet("job list", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		jobs, err := b.GetJobs(types.TopicID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.jobs(jobs)
+
+	case "show":
+		values, err := parseArgs(flag.NewFlagSet("job show", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		job, err := b.GetJob(types.JobID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.jobs([]types.Job{*job})
+
+	case "create":
+		fs := flag.NewFlagSet("job create", flag.ContinueOnError)
+		dagPath := fs.String("dag", "", "")
+		priority := fs.Int("priority", 0, "")
+		upstream := fs.String("upstream", "", "")
+		data := keyValues{}
+		fs.Var(data, "data", "")
+		after := jobIDs{}
+		fs.Var(&after, "after", "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		workUnitDag, err := readDag(*dagPath)
+		if err != nil {
+			return err
+		}
+		job, err := b.LaunchJob(types.TopicID(values[0]), workUnitDag,
+			types.WithJobData(data),
+			types.WithJobPriority(types.Priority(*priority)),
+			types.WithJobDependsOn(after...),
+			types.WithJobUpstreamPolicy(types.UpstreamPolicy(*upstream)))
+		if err != nil {
+			return err
+		}
+		return out.jobs([]types.Job{*job})
+
+	case "depend":
+		fs := flag.NewFlagSet("job depend", flag.ContinueOnError)
+		on := jobIDs{}
+		fs.Var(&on, "on", "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		if len(on) == 0 {
+			return fmt.Errorf("%w: job depend needs -on", ErrUsage)
+		}
+		if err = b.AddJobDependencies(types.JobID(values[0]), on...); err != nil {
+			return err
+		}
+		job, err := b.GetJob(types.JobID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.jobs([]types.Job{*job})
+
+	case "dependents":
+		values, err := parseArgs(flag.NewFlagSet("job dependents", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		jobs, err := b.GetDependentJobs(types.JobID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.jobs(jobs)
+
+	case "cancel":
+		values, err := parseArgs(flag.NewFlagSet("job cancel", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		if err = b.CancelJob(types.JobID(values[0])); err != nil {
+			return err
+		}
+		job, err := b.GetJob(types.JobID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.jobs([]types.Job{*job})
+
+	case "dot":
+		values, err := parseArgs(flag.NewFlagSet("job dot", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		dot, err := b.RenderJobDOT(types.JobID(values[0]))
+		if err != nil {
+			return err
+		}
+		_, err = out.w.Write(dot)
+		return err
+	}
+
+	return fmt.Errorf("%w: unknown job subcommand %q", ErrUsage, verb)
+}
+
+func batchCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("batch", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "create":
+		fs := flag.NewFlagSet("batch create", flag.ContinueOnError)
+		dagPath := fs.String("dag", "", "")
+		paramsPath := fs.String("params", "", "")
+		priority := fs.Int("priority", 0, "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		workUnitDag, err := readDag(*dagPath)
+		if err != nil {
+			return err
+		}
+		if *paramsPath == "" {
+			return fmt.Errorf("%w: batch create needs -params", ErrUsage)
+		}
+		content, err := os.ReadFile(*paramsPath)
+		if err != nil {
+			return err
+		}
+		var params []map[string]string
+		if err = json.Unmarshal(content, &params); err != nil {
+			return fmt.Errorf("%v: %w", *paramsPath, err)
+		}
+		batchID, err := b.LaunchBatch(types.TopicID(values[0]), workUnitDag, params, types.WithJobPriority(types.Priority(*priority)))
+		if err != nil {
+			return err
+		}
+		progress, err := b.GetBatch(batchID)
+		if err != nil {
+			return err
+		}
+		return out.batch(progress)
+
+	case "show":
+		values, err := parseArgs(flag.NewFlagSet("batch show", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		progress, err := b.GetBatch(types.BatchID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.batch(progress)
+
+	case "cancel":
+		values, err := parseArgs(flag.NewFlagSet("batch cancel", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		if err = b.CancelBatch(types.BatchID(values[0])); err != nil {
+			return err
+		}
+		progress, err := b.GetBatch(types.BatchID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.batch(progress)
+	}
+
+	return fmt.Errorf("%w: unknown batch subcommand %q", ErrUsage, verb)
+}
+
+func scheduleCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("schedule", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		fs := flag.NewFlagSet("schedule list", flag.ContinueOnError)
+		topicID := fs.String("topic", "", "")
+		if _, err = parseArgs(fs, args, 0); err != nil {
+			return err
+		}
+		schedules, err := b.GetSchedules(types.TopicID(*topicID))
+		if err != nil {
+			return err
+		}
+		return out.schedules(schedules)
+
+	case "create":
+		fs := flag.NewFlagSet("schedule create", flag.ContinueOnError)
+		cron := fs.String("cron", "", "")
+		templateID := fs.String("template", "", "")
+		dagPath := fs.String("dag", "", "")
+		data := keyValues{}
+		fs.Var(data, "data", "")
+		priority := fs.Int("priority", 0, "")
+		overlap := fs.String("overlap", string(types.OverlapSkip), "")
+		catchUp := fs.Bool("catch-up", false, "")
+		timezone := fs.String("timezone", "", "")
+		values, err := parseArgs(fs, args, 2)
+		if err != nil {
+			return err
+		}
+		cfgs := []types.ScheduleConfig{
+			types.WithScheduleTemplate(types.TemplateID(*templateID)),
+			types.WithScheduleData(data),
+			types.WithSchedulePriority(types.Priority(*priority)),
+			types.WithScheduleOverlap(types.OverlapPolicy(*overlap)),
+			types.WithScheduleCatchUp(*catchUp),
+			types.WithScheduleTimezone(*timezone),
+		}
+		if *dagPath != "" {
+			content, err := os.ReadFile(*dagPath)
+			if err != nil {
+				return err
+			}
+			cfgs = append(cfgs, types.WithScheduleDag(content))
+		}
+		schedule, err := b.CreateSchedule(types.TopicID(values[0]), values[1], *cron, cfgs...)
+		if err != nil {
+			return err
+		}
+		return out.schedules([]types.Schedule{*schedule})
+	}
+
+	values, err := parseArgs(flag.NewFlagSet("schedule "+verb, flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	id := types.ScheduleID(values[0])
+
+	var schedule *types.Schedule
+	switch verb {
+	case "show":
+		schedule, err = b.GetSchedule(id)
+	case "pause":
+		schedule, err = b.PauseSchedule(id)
+	case "resume":
+		schedule, err = b.ResumeSchedule(id)
+	case "delete":
+		return b.DeleteSchedule(id)
+	default:
+		return fmt.Errorf("%w: unknown schedule subcommand %q", ErrUsage, verb)
+	}
+	if err != nil {
+		return err
+	}
+	return out.schedules([]types.Schedule{*schedule})
+}
+
+func webhookCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("webhook", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		fs := flag.NewFlagSet("webhook list", flag.ContinueOnError)
+		topicID := fs.String("topic", "", "")
+		if _, err = parseArgs(fs, args, 0); err != nil {
+			return err
+		}
+		webhooks, err := b.GetWebhooks(types.TopicID(*topicID))
+		if err != nil {
+			return err
+		}
+		return out.webhooks(webhooks)
+
+	case "create":
+		fs := flag.NewFlagSet("webhook create", flag.ContinueOnError)
+		topicID := fs.String("topic", "", "")
+		ownerID := fs.String("owner", "", "")
+		secret := fs.String("secret", "", "")
+		events := eventTypes{}
+		fs.Var(&events, "event", "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		webhook, err := b.CreateWebhook(values[0],
+			types.WithWebhookTopic(types.TopicID(*topicID)),
+			types.WithWebhookOwner(types.OwnerID(*ownerID)),
+			types.WithWebhookSecret(*secret),
+			types.WithWebhookEvents(events...))
+		if err != nil {
+			return err
+		}
+		return out.webhooks([]types.Webhook{*webhook})
+	}
+
+	values, err := parseArgs(flag.NewFlagSet("webhook "+verb, flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	id := types.WebhookID(values[0])
+
+	switch verb {
+	case "show":
+		webhook, err := b.GetWebhook(id)
+		if err != nil {
+			return err
+		}
+		return out.webhooks([]types.Webhook{*webhook})
+	case "deliveries":
+		deliveries, err := b.GetDeliveries(id)
+		if err != nil {
+			return err
+		}
+		return out.deliveries(deliveries)
+	case "delete":
+		return b.DeleteWebhook(id)
+	}
+	return fmt.Errorf("%w: unknown webhook subcommand %q", ErrUsage, verb)
+}
+
+func limitCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("limit", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		if _, err = parseArgs(flag.NewFlagSet("limit list", flag.ContinueOnError), args, 0); err != nil {
+			return err
+		}
+		limits, err := b.GetConcurrencyLimits()
+		if err != nil {
+			return err
+		}
+		return out.limits(limits)
+
+	case "set":
+		fs := flag.NewFlagSet("limit set", flag.ContinueOnError)
+		ownerID := fs.String("owner", "", "")
+		definitionID := fs.String("definition", "", "")
+		topicID := fs.String("topic", "", "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		maximum, err := strconv.Atoi(values[0])
+		if err != nil {
+			return fmt.Errorf("%w: max %q is not a number", ErrUsage, values[0])
+		}
+		if err = b.SetConcurrencyLimit(types.NewConcurrencyLimit(maximum,
+			types.WithLimitOwner(types.OwnerID(*ownerID)),
+			types.WithLimitTaskDefinition(types.TaskDefinitionID(*definitionID)),
+			types.WithLimitTopic(types.TopicID(*topicID)),
+		)); err != nil {
+			return err
+		}
+		limits, err := b.GetConcurrencyLimits()
+		if err != nil {
+			return err
+		}
+		return out.limits(limits)
+	}
+
+	return fmt.Errorf("%w: unknown limit subcommand %q", ErrUsage, verb)
+}
+
+func auditCmd(b backend, out *printer, args []string) error {
+	if _, err := parseArgs(flag.NewFlagSet("audit", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+	records, err := b.GetAuditRecords()
+	if err != nil {
+		return err
+	}
+	return out.audit(records)
+}
+
+func taskCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("task", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		values, err := parseArgs(flag.NewFlagSet("task list", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		tasks, err := b.GetTasks(types.JobID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.tasks(tasks)
+
+	case "units":
+		values, err := parseArgs(flag.NewFlagSet("task units", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		units, err := b.GetTaskUnits(types.TaskID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.units(units)
+
+	case "cancel":
+		values, err := parseArgs(flag.NewFlagSet("task cancel", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		if err = b.CancelTask(types.TaskID(values[0])); err != nil {
+			return err
+		}
+		task, err := b.GetTask(types.TaskID(values[0]))
+		if err != nil {
+			return err
+		}
+		return out.tasks([]types.Task{*task})
+
+	case "dot":
+		values, err := parseArgs(flag.NewFlagSet("task dot", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		dot, err := b.RenderTaskDOT(types.TaskID(values[0]))
+		if err != nil {
+			return err
+		}
+		_, err = out.w.Write(dot)
+		return err
+	}
+
+	return fmt.Errorf("%w: unknown task subcommand %q", ErrUsage, verb)
+}
+
+func templateCmd(b backend, out *printer, args []string) error {
+	verb, args, err := subcommand("template", args)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "list":
+		if _, err = parseArgs(flag.NewFlagSet("template list", flag.ContinueOnError), args, 0); err != nil {
+			return err
+		}
+		templates, err := b.GetTemplates()
+		if err != nil {
+			return err
+		}
+		return out.templates(templates)
+
+	case "versions":
+		values, err := parseArgs(flag.NewFlagSet("template versions", flag.ContinueOnError), args, 1)
+		if err != nil {
+			return err
+		}
+		templates, err := b.GetTemplateVersions(values[0])
+		if err != nil {
+			return err
+		}
+		return out.templates(templates)
+
+	case "save":
+		fs := flag.NewFlagSet("template save", flag.ContinueOnError)
+		dagPath := fs.String("dag", "", "")
+		description := fs.String("description", "", "")
+		values, err := parseArgs(fs, args, 1)
+		if err != nil {
+			return err
+		}
+		workUnitDag, err := readDag(*dagPath)
+		if err != nil {
+			return err
+		}
+		template, err := b.SaveTemplate(values[0], workUnitDag, types.WithTemplateDescription(*description))
+		if err != nil {
+			return err
+		}
+		return out.templates([]types.Template{*template})
+
+	case "launch":
+		fs := flag.NewFlagSet("template launch", flag.ContinueOnError)
+		data := keyValues{}
+		fs.Var(data, "data", "")
+		priority := fs.Int("priority", 0, "")
+		values, err := parseArgs(fs, args, 2)
+		if err != nil {
+			return err
+		}
+		job, err := b.LaunchTemplate(types.TopicID(values[1]), types.TemplateID(values[0]), types.WithJobData(data), types.WithJobPriority(types.Priority(*priority)))
+		if err != nil {
+			return err
+		}
+		return out.jobs([]types.Job{*job})
+	}
+
+	return fmt.Errorf("%w: unknown template subcommand %q", ErrUsage, verb)
+}
+
+// Read a `WorkUnitDag` JSON file
+func readDag(path string) (*types.WorkUnitDag, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: -dag is required", ErrUsage)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	workUnitDag := types.NewWorkUnitDag(nil)
+	if err = json.Unmarshal(content, workUnitDag); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return workUnitDag, nil
+}
+
+func inboxCmd(b backend, out *printer, args []string) error {
+	fs := flag.NewFlagSet("inbox", flag.ContinueOnError)
+	topicID := fs.String("topic", "", "")
+	aging := fs.Duration("aging", 0, "")
+	wait := fs.Duration("wait", 0, "")
+	values, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	ownerID := types.OwnerID(values[0])
+	cfgs := []types.QueryConfig{}
+	if *aging > 0 {
+		cfgs = append(cfgs, types.WithQueryAging(*aging))
+	}
+	if *topicID != "" {
+		cfgs = append(cfgs, types.WithQueryTopic(types.TopicID(*topicID)))
+	}
+
+	var inbox []types.InboxAllTaskUnit
+	if *wait > 0 {
+		// nothing came during the wait, the inbox is empty
+		ctx, cancel := context.WithTimeout(context.Background(), *wait)
+		defer cancel()
+		if inbox, err = b.WaitInbox(ctx, ownerID, cfgs...); errors.Is(err, context.DeadlineExceeded) {
+			err = nil
+		}
+	} else {
+		inbox, err = b.GetInbox(ownerID, cfgs...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return out.inbox(api.ToInbox(inbox))
+}
+
+func claimCmd(b backend, out *printer, args []string) error {
+	values, err := parseArgs(flag.NewFlagSet("claim", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+	unitID := types.TaskUnitID(values[0])
+	if err = b.ClaimTaskUnit(unitID, types.OwnerID(values[1])); err != nil {
+		return err
+	}
+	unit, err := b.GetTaskUnit(unitID)
+	if err != nil {
+		return err
+	}
+	return out.units([]types.TaskUnit{*unit})
+}
+
+func releaseCmd(b backend, out *printer, args []string) error {
+	values, err := parseArgs(flag.NewFlagSet("release", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	unitID := types.TaskUnitID(values[0])
+	if err = b.ReleaseTaskUnit(unitID); err != nil {
+		return err
+	}
+	unit, err := b.GetTaskUnit(unitID)
+	if err != nil {
+		return err
+	}
+	return out.units([]types.TaskUnit{*unit})
+}
+
+// `approve` and `reject` review an approval gate
+func reviewCmd(b backend, out *printer, verb string, args []string) error {
+	fs := flag.NewFlagSet(verb, flag.ContinueOnError)
+	comment := fs.String("comment", "", "")
+	reason := fs.String("reason", "", "")
+	values, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	unitID := types.TaskUnitID(values[0])
+	if verb == "reject" {
+		if *reason == "" {
+			return fmt.Errorf("%w: reject needs -reason", ErrUsage)
+		}
+		err = b.Reject(unitID, types.OwnerID(values[1]), *reason)
+	} else {
+		err = b.Approve(unitID, types.OwnerID(values[1]), *comment)
+	}
+	if err != nil {
+		return err
+	}
+	unit, err := b.GetTaskUnit(unitID)
+	if err != nil {
+		return err
+	}
+	return out.units([]types.TaskUnit{*unit})
+}
+
+// Print the units moved by a migration
+func migratedUnits(b backend, out *printer, migrated []types.TaskUnitID) error {
+	units := []types.TaskUnit{}
+	for i := 0; i < len(migrated); i++ {
+		unit, err := b.GetTaskUnit(migrated[i])
+		if err != nil {
+			return err
+		}
+		units = append(units, *unit)
+	}
+	return out.units(units)
+}
+
+func reassignCmd(b backend, out *printer, args []string) error {
+	fs := flag.NewFlagSet("reassign", flag.ContinueOnError)
+	reason := fs.String("reason", "", "")
+	values, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	unitID := types.TaskUnitID(values[0])
+	if err = b.ReassignTaskUnit(unitID, types.OwnerID(values[1]), *reason); err != nil {
+		return err
+	}
+	unit, err := b.GetTaskUnit(unitID)
+	if err != nil {
+		return err
+	}
+	return out.units([]types.TaskUnit{*unit})
+}
+
+func stateCmd(b backend, out *printer, args []string) error {
+	fs := flag.NewFlagSet("state", flag.ContinueOnError)
+	at := fs.Int("at", -1, "")
+	values, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	unitID := types.TaskUnitID(values[0])
+	var state *types.TaskUnitState
+	if *at < 0 {
+		state, err = b.GetTaskUnitState(unitID)
+	} else {
+		state, err = b.GetTaskUnitStateAt(unitID, *at)
+	}
+	if err != nil {
+		return err
+	}
+	return out.state(state)
+}
+
+func commandCmd(b backend, out *printer, args []string) error {
+	fs := flag.NewFlagSet("command", flag.ContinueOnError)
+	cmdType := fs.String("type", "", "")
+	status := fs.String("status", "", "")
+	details := fs.String("details", "", "")
+	data := keyValues{}
+	fs.Var(data, "data", "")
+	values, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if *cmdType == "" {
+		return fmt.Errorf("%w: command needs -type", ErrUsage)
+	}
+
+	unitID := types.TaskUnitID(values[0])
+	err = b.SubmitCommand(unitID, types.Command{
+		Type:    types.CommandType(*cmdType),
+		Status:  types.StatusType(*status),
+		Details: *details,
+		Data:    data,
+	})
+	if err != nil {
+		return err
+	}
+
+	unit, err := b.GetTaskUnit(unitID)
+	if err != nil {
+		return err
+	}
+	return out.units([]types.TaskUnit{*unit})
+}
@@ -1,13 +1,14 @@
 package main
 
 import (
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
-	"os/signal"
-	"syscall"
+	"strings"
 
-	"github.com/davidroman0O/junjo/data"
-	"github.com/davidroman0O/seigyo"
+	"github.com/davidroman0O/junjo/types"
 )
 
 // What i want is to be able to launch any kind of process easily
@@ -25,40 +26,338 @@ import (
 // - workflow engine, only task engine, the dag is the rule, workers will get data to do some work and report
 // - integrating all different kind of network protocols
 // - no favorite way of doing things
+
+/// `junjo` administrate a junjo store, either a local store file or a remote server
+///
+///	junjo [--store ./junjo.sqlite | --server http://localhost:8080] [--namespace billing] [--output table|json] <command> ...
+///
+/// The local store is a SQLite file, each change is written as soon as it is made so commands can run next to `junjo serve` on the same file
+/// Every namespace is kept in the same store file, `--namespace` picks the one a command works on
+
+const usage = `usage: junjo [flags] <command> [arguments]
+
+flags:
+  --store <file>          local SQLite store file (default ./junjo.sqlite, env JUNJO_STORE)
+  --server <url>          remote junjo server, overrides --store (env JUNJO_SERVER)
+  --namespace <name>      namespace of the store or of the server (default the only one of the token or default, env JUNJO_NAMESPACE)
+  --token <token>         token of the server, see serve -tokens (env JUNJO_TOKEN)
+  --output table|json     output format (default table)
+
+commands:
+  topic list
+  topic create <name> [-description <text>]
+  topic rename <topic> <name>
+  topic deprecate <topic>
+
+  owner list
+  owner create <name> [-description <text>] [-member <owner>]...
+                          a pool when it has members, they share its units
+  owner members <owner> [<member>...]
+                          replace the members, none turns the pool back into an owner
+  owner rename <owner> <name>
+  owner deprecate <owner>
+                          no new unit can go to it, it keeps the ones it started
+  owner migrate <owner> <replacement> [-reason <text>]
+                          its units not started yet go to the replacement
+
+  definition list
+  definition create <name> -owner <owner> [-description <text>] [-identifier <id>]
+                    [-approver <owner>]... [-approvals n] [-expires <duration>]
+                          with approvers its units are approval gates, they need n approvals (1 by default)
+  definition update <definition> [-owner <owner>] [-name <name>] [-description <text>] [-identifier <id>]
+                          saves a new version, the units already created keep theirs
+  definition versions <definition>
+  definition deprecate <definition>
+                          new jobs can't use it anymore, its versions stay readable
+  definition migrate <definition> <replacement>
+                          its units not started yet use the current version of the replacement
+
+  job list <topic>
+  job show <job>
+  job create <topic> -dag <file.json> [-data key=value]... [-priority n]
+                          [-after <job>]... [-upstream block|cancel|ignore]
+                          wait for other jobs, the policy handles their failure
+  job depend <job> -on <job>...
+  job dependents <job>
+  job cancel <job>
+  job dot <job>
+
+  batch create <topic> -dag <file.json> -params <file.json> [-priority n]
+                          one job per object of the params array
+  batch show <batch>
+  batch cancel <batch>
+
+  task list <job>
+  task units <task>
+  task cancel <task>
+  task dot <task>
+
+  template list
+  template versions <name>
+  template save <name> -dag <file.json> [-description <text>]
+  template launch <template> <topic> [-data key=value]... [-priority n]
+
+  schedule list [-topic <topic>]
+  schedule show <schedule>
+  schedule create <topic> <name> -cron <expression> -template <template>|-dag <file.json> [-data key=value]...
+                  [-priority n] [-overlap skip|queue|allow] [-catch-up] [-timezone <zone>]
+  schedule pause <schedule>
+  schedule resume <schedule>
+  schedule delete <schedule>
+
+  webhook list [-topic <topic>]
+  webhook show <webhook>
+  webhook create <url> -topic <topic>|-owner <owner> [-event unit.available|unit.failed|job.completed]... [-secret <secret>]
+                          every event when no -event, the deliveries are signed with the secret
+  webhook deliveries <webhook>
+  webhook delete <webhook>
+
+  limit list
+  limit set <max> -owner <owner>|-definition <definition> [-topic <topic>]
+                          cap the queued and in progress units, a max of 0 removes the limit
+
+  audit                   the permission checks of the principals of the tokens, for the admins
+
+  inbox <owner> [-topic <topic>] [-aging <duration>] [-wait <duration>]
+                          highest priority first then the oldest, -aging adds one to the priority per duration waited
+                          -wait blocks until there is work or the duration is over
+  claim <unit> <owner>    take a unit of a pool, the other members stop seeing it and its commands need -data claimant=<owner>
+  release <unit>          give it back to the pool
+  reassign <unit> <owner> [-reason <text>]
+                          hand the unit to another owner than the one of its definition, a started unit goes back to none
+  approve <unit> <owner> [-comment <text>]
+  reject <unit> <owner> -reason <text>
+                          review an approval gate, a rejection fails it with the reason
+  command <unit> -type progress|success|error|pause|log|delegate [-status <status>] [-details <text>] [-data key=value]...
+                          delegate expects -data to=<owner>, the details are the reason
+  state <unit> [-at n]    its data patched by the -data of its progress, pause, success and error commands
+                          -at only applies the first n commands, an empty value removes the key
+
+  tree [topic]            topics, jobs, tasks and units as a tree
+  dag [topic]             same with the units indented by their depth in the task
+
+  serve [-addr :8080] [-grpc-addr :9090] [-tokens tokens.json] [-reap-ttl 24h] [-reap-interval 1h] [-reap-enforce] [-schedule-interval 15s]
+        [-webhook-interval 5s]
+                          serve the local store over HTTP, the template editor is on /ui/
+                          with -grpc-addr the same api is served over gRPC, see rpc/proto
+                          with -tokens ({"token": {"name": "ci", "namespaces": ["billing"], "roles": [{"role": "admin"}]}})
+                          the namespaces of the tokens are served from the store, every request needs a token reaching
+                          its namespace and the roles of the token allowing the change (admin, topic-manager, owner, viewer)
+                          without -tokens only the default namespace is served and everything is allowed
+                          the schedules are launched and the approval gates expired while serving, -schedule-interval 0 disables them
+                          the webhooks are delivered while serving, -webhook-interval 0 disables them
+                          with -reap-ttl the drafts older than the ttl are reported, and deleted with -reap-enforce
+`
+
+var (
+	ErrUsage = errors.New("invalid usage")
+)
+
 func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, ErrUsage) {
+			fmt.Fprint(os.Stderr, usage)
+		}
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("junjo", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	store := fs.String("store", envOr("JUNJO_STORE", "./junjo.sqlite"), "")
+	server := fs.String("server", os.Getenv("JUNJO_SERVER"), "")
+	namespace := fs.String("namespace", os.Getenv("JUNJO_NAMESPACE"), "")
+	token := fs.String("token", os.Getenv("JUNJO_TOKEN"), "")
+	output := fs.String("output", outputTable, "")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	if *output != outputTable && *output != outputJSON {
+		return fmt.Errorf("%w: unknown output %q", ErrUsage, *output)
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	out := &printer{w: stdout, format: *output}
+
+	if *namespace != "" {
+		if err := types.ValidateNamespace(types.Namespace(*namespace)); err != nil {
+			return fmt.Errorf("%w: %v", ErrUsage, err)
+		}
+	}
+
+	if args[0] == "serve" {
+		if *server != "" || *namespace != "" {
+			return fmt.Errorf("%w: serve only works with a local store, its namespaces come from -tokens", ErrUsage)
+		}
+		return serve(*store, args[1:])
+	}
 
-	// Create a channel to receive OS signals.
-	sigCh := make(chan os.Signal, 1)
+	if *server != "" {
+		return dispatch(openRemote(*server, types.Namespace(*namespace), *token), out, args)
+	}
+
+	local, err := openLocal(*store, types.Namespace(*namespace))
+	if err != nil {
+		return err
+	}
+	defer local.close()
+	return dispatch(local, out, args)
+}
+
+func dispatch(b backend, out *printer, args []string) error {
+	switch args[0] {
+	case "topic", "topics":
+		return topicCmd(b, out, args[1:])
+	case "owner", "owners":
+		return ownerCmd(b, out, args[1:])
+	case "definition", "definitions":
+		return definitionCmd(b, out, args[1:])
+	case "job", "jobs":
+		return jobCmd(b, out, args[1:])
+	case "batch", "batches":
+		return batchCmd(b, out, args[1:])
+	case "schedule", "schedules":
+		return scheduleCmd(b, out, args[1:])
+	case "webhook", "webhooks":
+		return webhookCmd(b, out, args[1:])
+	case "limit", "limits":
+		return limitCmd(b, out, args[1:])
+	case "audit":
+		return auditCmd(b, out, args[1:])
+	case "task", "tasks":
+		return taskCmd(b, out, args[1:])
+	case "template", "templates":
+		return templateCmd(b, out, args[1:])
+	case "inbox":
+		return inboxCmd(b, out, args[1:])
+	case "claim":
+		return claimCmd(b, out, args[1:])
+	case "release":
+		return releaseCmd(b, out, args[1:])
+	case "reassign":
+		return reassignCmd(b, out, args[1:])
+	case "approve", "reject":
+		return reviewCmd(b, out, args[0], args[1:])
+	case "command":
+		return commandCmd(b, out, args[1:])
+	case "state":
+		return stateCmd(b, out, args[1:])
+	case "tree":
+		return treeCmd(b, out, args[1:], false)
+	case "dag":
+		return treeCmd(b, out, args[1:], true)
+	default:
+		return fmt.Errorf("%w: unknown command %q", ErrUsage, args[0])
+	}
+}
+
+func envOr(key string, value string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return value
+}
+
+// Parse the flags wherever they are, `junjo job create <topic> -dag file.json` and `junjo job create -dag file.json <topic>` are the same
+func parseArgs(fs *flag.FlagSet, args []string, expected int) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	positionals := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positionals = append(positionals, args[0])
+		args = args[1:]
+	}
+	if len(positionals) != expected {
+		return nil, fmt.Errorf("%w: %v expects %v argument(s)", ErrUsage, fs.Name(), expected)
+	}
+	return positionals, nil
+}
+
+// Repeatable `-after <job>` flag
+type jobIDs []types.JobID
+
+func (ids *jobIDs) String() string {
+	values := []string{}
+	for i := 0; i < len(*ids); i++ {
+		values = append(values, string((*ids)[i]))
+	}
+	return strings.Join(values, ",")
+}
+
+func (ids *jobIDs) Set(value string) error {
+	if value == "" {
+		return errors.New("expected a job id")
+	}
+	*ids = append(*ids, types.JobID(value))
+	return nil
+}
 
-	// Notify sigCh when receiving SIGINT or SIGTERM signals.
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+// Repeatable `-member <owner>` flag
+type ownerIDs []types.OwnerID
 
-	bootstrap := seigyo.New[*data.GlobalState, interface{}](&data.GlobalState{})
+func (ids *ownerIDs) String() string {
+	values := []string{}
+	for i := 0; i < len(*ids); i++ {
+		values = append(values, string((*ids)[i]))
+	}
+	return strings.Join(values, ",")
+}
 
-	bootstrap.RegisterProcess(
-		"graph",
-		seigyo.ProcessConfig[*data.GlobalState, interface{}]{
-			Process: &data.GraphNetProcess{
-				Pid: "graph",
-			},
-			ShouldRecover:         true,
-			RunMaxRetries:         99,
-			InitMaxRetries:        99,
-			DeinitMaxRetries:      99,
-			MessageSendMaxRetries: 99,
-		})
+func (ids *ownerIDs) Set(value string) error {
+	if value == "" {
+		return errors.New("expected an owner id")
+	}
+	*ids = append(*ids, types.OwnerID(value))
+	return nil
+}
 
-	errCh := bootstrap.Start()
+// Repeatable `-event <type>` flag
+type eventTypes []types.EventType
 
-	go func() {
-		<-sigCh
-		bootstrap.Stop()
-	}()
+func (events *eventTypes) String() string {
+	values := []string{}
+	for i := 0; i < len(*events); i++ {
+		values = append(values, string((*events)[i]))
+	}
+	return strings.Join(values, ",")
+}
 
-	for err := range errCh {
-		log.Println("error:", err)
+func (events *eventTypes) Set(value string) error {
+	if value == "" {
+		return errors.New("expected an event type")
 	}
+	*events = append(*events, types.EventType(value))
+	return nil
+}
 
-	bootstrap.Stop()
+// Repeatable `-data key=value` flag
+type keyValues map[string]string
 
+func (kv keyValues) String() string {
+	pairs := []string{}
+	for k, v := range kv {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (kv keyValues) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	kv[key] = val
+	return nil
 }
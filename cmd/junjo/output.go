@@ -0,0 +1,376 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/davidroman0O/junjo/api"
+	"github.com/davidroman0O/junjo/types"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (p *printer) json(value interface{}) error {
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func (p *printer) table(headers []string, rows [][]string) error {
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for i := 0; i < len(rows); i++ {
+		fmt.Fprintln(w, strings.Join(rows[i], "\t"))
+	}
+	return w.Flush()
+}
+
+func (p *printer) topics(topics []types.Topic) error {
+	if p.format == outputJSON {
+		values := make([]types.Topic, 0, len(topics))
+		for i := 0; i < len(topics); i++ {
+			values = append(values, api.ToTopic(topics[i]))
+		}
+		return p.json(values)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(topics); i++ {
+		rows = append(rows, []string{
+			string(topics[i].Key),
+			topics[i].Name,
+			topics[i].Description,
+			fmt.Sprint(len(topics[i].JobIDs)),
+			fmt.Sprint(topics[i].Deprecated),
+		})
+	}
+	return p.table([]string{"ID", "NAME", "DESCRIPTION", "JOBS", "DEPRECATED"}, rows)
+}
+
+func (p *printer) owners(owners []types.Owner) error {
+	if p.format == outputJSON {
+		return p.json(owners)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(owners); i++ {
+		members := []string{}
+		for j := 0; j < len(owners[i].Members); j++ {
+			members = append(members, string(owners[i].Members[j]))
+		}
+		rows = append(rows, []string{
+			string(owners[i].Key),
+			owners[i].Name,
+			owners[i].Description,
+			strings.Join(members, ","),
+			fmt.Sprint(owners[i].Deprecated),
+		})
+	}
+	return p.table([]string{"ID", "NAME", "DESCRIPTION", "MEMBERS", "DEPRECATED"}, rows)
+}
+
+func (p *printer) definitions(definitions []types.TaskDefinition) error {
+	if p.format == outputJSON {
+		return p.json(definitions)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(definitions); i++ {
+		approval := ""
+		if gate := definitions[i].Approval; gate != nil {
+			approval = fmt.Sprintf("%v of %v", gate.Required, len(gate.Approvers))
+			if gate.ExpiresAfter > 0 {
+				approval += fmt.Sprintf(" within %v", gate.ExpiresAfter)
+			}
+		}
+		version := fmt.Sprint(definitions[i].Version)
+		if definitions[i].Deprecated {
+			version += " deprecated"
+		}
+		rows = append(rows, []string{
+			string(definitions[i].Key),
+			definitions[i].Name,
+			version,
+			string(definitions[i].OwnerID),
+			definitions[i].Identifier,
+			approval,
+			definitions[i].Description,
+		})
+	}
+	return p.table([]string{"ID", "NAME", "VERSION", "OWNER", "IDENTIFIER", "APPROVAL", "DESCRIPTION"}, rows)
+}
+
+func (p *printer) jobs(jobs []types.Job) error {
+	if p.format == outputJSON {
+		values := make([]types.Job, 0, len(jobs))
+		for i := 0; i < len(jobs); i++ {
+			values = append(values, api.ToJob(jobs[i]))
+		}
+		return p.json(values)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(jobs); i++ {
+		rows = append(rows, []string{
+			string(jobs[i].Key),
+			string(jobs[i].Status),
+			string(jobs[i].TopicID),
+			fmt.Sprint(jobs[i].Priority),
+			fmt.Sprint(len(jobs[i].TaskIDs)),
+			fmt.Sprint(len(jobs[i].DependsOn)),
+		})
+	}
+	return p.table([]string{"ID", "STATUS", "TOPIC", "PRIORITY", "TASKS", "AFTER"}, rows)
+}
+
+func (p *printer) tasks(tasks []types.Task) error {
+	if p.format == outputJSON {
+		values := make([]types.Task, 0, len(tasks))
+		for i := 0; i < len(tasks); i++ {
+			values = append(values, api.ToTask(tasks[i]))
+		}
+		return p.json(values)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(tasks); i++ {
+		rows = append(rows, []string{
+			string(tasks[i].Key),
+			string(tasks[i].Status),
+			string(tasks[i].JobID),
+			fmt.Sprint(len(tasks[i].TaskUnitIDs)),
+		})
+	}
+	return p.table([]string{"ID", "STATUS", "JOB", "UNITS"}, rows)
+}
+
+func (p *printer) units(units []types.TaskUnit) error {
+	if p.format == outputJSON {
+		return p.json(api.ToTaskUnits(units))
+	}
+	rows := [][]string{}
+	for i := 0; i < len(units); i++ {
+		dependsOn := []string{}
+		for j := 0; j < len(units[i].DependsOnIDs); j++ {
+			dependsOn = append(dependsOn, string(units[i].DependsOnIDs[j]))
+		}
+		errMessage := ""
+		if units[i].Error != nil {
+			errMessage = units[i].Error.Error()
+		}
+		// with the version the unit was created with
+		definition := string(units[i].TaskDefinitionID)
+		if units[i].DefinitionVersion > 0 {
+			definition = fmt.Sprintf("%v@%v", definition, units[i].DefinitionVersion)
+		}
+		rows = append(rows, []string{
+			string(units[i].Key),
+			definition,
+			string(units[i].Status),
+			strings.Join(dependsOn, ","),
+			string(units[i].ParentID),
+			fmt.Sprint(len(units[i].Commands)),
+			string(units[i].OwnerID),
+			string(units[i].ClaimedBy),
+			errMessage,
+		})
+	}
+	return p.table([]string{"ID", "DEFINITION", "STATUS", "DEPENDS ON", "FAN-OUT", "COMMANDS", "REASSIGNED TO", "CLAIMED BY", "ERROR"}, rows)
+}
+
+func (p *printer) inbox(inbox []api.InboxTaskUnit) error {
+	if p.format == outputJSON {
+		return p.json(inbox)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(inbox); i++ {
+		for j := 0; j < len(inbox[i].TaskUnits); j++ {
+			rows = append(rows, []string{
+				string(inbox[i].TopicID),
+				string(inbox[i].JobID),
+				string(inbox[i].TaskID),
+				string(inbox[i].TaskUnits[j].Key),
+				string(inbox[i].TaskUnits[j].TaskDefinitionID),
+				string(inbox[i].TaskUnits[j].Status),
+				fmt.Sprint(inbox[i].Priority),
+			})
+		}
+	}
+	return p.table([]string{"TOPIC", "JOB", "TASK", "UNIT", "DEFINITION", "STATUS", "PRIORITY"}, rows)
+}
+
+func (p *printer) templates(templates []types.Template) error {
+	if p.format == outputJSON {
+		return p.json(templates)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(templates); i++ {
+		rows = append(rows, []string{
+			string(templates[i].Key),
+			templates[i].Name,
+			fmt.Sprint(templates[i].Version),
+			templates[i].CreatedAt.Format(time.RFC3339),
+			templates[i].Description,
+		})
+	}
+	return p.table([]string{"ID", "NAME", "VERSION", "CREATED", "DESCRIPTION"}, rows)
+}
+
+func (p *printer) batch(progress *types.BatchProgress) error {
+	if p.format == outputJSON {
+		return p.json(progress)
+	}
+	statuses := []string{}
+	for _, status := range []types.StatusType{types.NoneStatus, types.QueuedStatus, types.ProgressStatus, types.SuccessStatus, types.ErrorStatus, types.PauseStatus} {
+		if count := progress.Statuses[status]; count > 0 {
+			statuses = append(statuses, fmt.Sprintf("%v=%v", status, count))
+		}
+	}
+	return p.table([]string{"ID", "TOPIC", "STATUS", "JOBS", "BY STATUS"}, [][]string{{
+		string(progress.Key),
+		string(progress.TopicID),
+		string(progress.Status),
+		fmt.Sprint(progress.Total),
+		strings.Join(statuses, " "),
+	}})
+}
+
+func (p *printer) limits(limits []types.ConcurrencyLimit) error {
+	if p.format == outputJSON {
+		return p.json(limits)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(limits); i++ {
+		topic := string(limits[i].TopicID)
+		if topic == "" {
+			topic = "*"
+		}
+		rows = append(rows, []string{
+			string(limits[i].OwnerID),
+			string(limits[i].TaskDefinitionID),
+			topic,
+			fmt.Sprint(limits[i].Max),
+		})
+	}
+	return p.table([]string{"OWNER", "DEFINITION", "TOPIC", "MAX"}, rows)
+}
+
+func (p *printer) audit(records []types.AuditRecord) error {
+	if p.format == outputJSON {
+		return p.json(records)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(records); i++ {
+		decision := "allowed"
+		if !records[i].Allowed {
+			decision = "denied"
+		}
+		rows = append(rows, []string{
+			records[i].CreatedAt.Format(time.RFC3339),
+			records[i].Principal,
+			string(records[i].Action),
+			string(records[i].TopicID),
+			records[i].Target,
+			decision,
+		})
+	}
+	return p.table([]string{"TIME", "PRINCIPAL", "ACTION", "TOPIC", "TARGET", "DECISION"}, rows)
+}
+
+func (p *printer) state(state *types.TaskUnitState) error {
+	if p.format == outputJSON {
+		return p.json(state)
+	}
+	keys := make([]string, 0, len(state.Data))
+	for k := range state.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := [][]string{}
+	for i := 0; i < len(keys); i++ {
+		rows = append(rows, []string{keys[i], state.Data[keys[i]]})
+	}
+	return p.table([]string{"KEY", "VALUE"}, rows)
+}
+
+func (p *printer) schedules(schedules []types.Schedule) error {
+	if p.format == outputJSON {
+		return p.json(schedules)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(schedules); i++ {
+		source := "dag"
+		if schedules[i].TemplateID != "" {
+			source = "template " + string(schedules[i].TemplateID)
+		}
+		rows = append(rows, []string{
+			string(schedules[i].Key),
+			schedules[i].Name,
+			string(schedules[i].TopicID),
+			schedules[i].Cron,
+			source,
+			string(schedules[i].Overlap),
+			schedules[i].LastTick.Format(time.RFC3339),
+			fmt.Sprint(schedules[i].Pending),
+			fmt.Sprint(schedules[i].Paused),
+		})
+	}
+	return p.table([]string{"ID", "NAME", "TOPIC", "CRON", "SOURCE", "OVERLAP", "LAST TICK", "PENDING", "PAUSED"}, rows)
+}
+
+func (p *printer) webhooks(webhooks []types.Webhook) error {
+	if p.format == outputJSON {
+		return p.json(webhooks)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(webhooks); i++ {
+		events := "all"
+		if len(webhooks[i].Events) > 0 {
+			names := []string{}
+			for k := 0; k < len(webhooks[i].Events); k++ {
+				names = append(names, string(webhooks[i].Events[k]))
+			}
+			events = strings.Join(names, ",")
+		}
+		rows = append(rows, []string{
+			string(webhooks[i].Key),
+			webhooks[i].URL,
+			string(webhooks[i].TopicID),
+			string(webhooks[i].OwnerID),
+			events,
+		})
+	}
+	return p.table([]string{"ID", "URL", "TOPIC", "OWNER", "EVENTS"}, rows)
+}
+
+func (p *printer) deliveries(deliveries []types.Delivery) error {
+	if p.format == outputJSON {
+		return p.json(deliveries)
+	}
+	rows := [][]string{}
+	for i := 0; i < len(deliveries); i++ {
+		code := ""
+		if deliveries[i].ResponseCode != 0 {
+			code = fmt.Sprint(deliveries[i].ResponseCode)
+		}
+		rows = append(rows, []string{
+			string(deliveries[i].Key),
+			string(deliveries[i].Event.Type),
+			string(deliveries[i].Event.JobID),
+			string(deliveries[i].Event.TaskUnitID),
+			string(deliveries[i].Status),
+			fmt.Sprint(deliveries[i].Attempts),
+			code,
+			deliveries[i].LastError,
+		})
+	}
+	return p.table([]string{"ID", "EVENT", "JOB", "UNIT", "STATUS", "ATTEMPTS", "CODE", "ERROR"}, rows)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/api"
+	"github.com/davidroman0O/junjo/rpc"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// Serve the local store until SIGINT or SIGTERM, the store stays locked while served and is written back on shutdown
+// With `-tokens` every namespace of the tokens is served from the same store, each request only sees its own namespace
+func serve(store string, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "")
+	grpcAddr := fs.String("grpc-addr", "", "")
+	tokensPath := fs.String("tokens", "", "")
+	reapTTL := fs.Duration("reap-ttl", 0, "")
+	reapInterval := fs.Duration("reap-interval", time.Hour, "")
+	reapEnforce := fs.Bool("reap-enforce", false, "")
+	scheduleInterval := fs.Duration("schedule-interval", 15*time.Second, "")
+	webhookInterval := fs.Duration("webhook-interval", 5*time.Second, "")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	served := []types.Namespace{types.DefaultNamespace}
+	cfgs := []junjo.NamespacesConfig{}
+	if *tokensPath != "" {
+		tokens, err := loadTokens(*tokensPath)
+		if err != nil {
+			return err
+		}
+		served = tokenNamespaces(tokens)
+		cfgs = append(cfgs, junjo.WithNamespacesAuth(tokens))
+	}
+
+	local, err := openLocal(store, types.DefaultNamespace)
+	if err != nil {
+		return err
+	}
+	defer local.close()
+	isServed := map[types.Namespace]bool{}
+	for i := 0; i < len(served); i++ {
+		isServed[served[i]] = true
+	}
+	namespaces := junjo.NewNamespaces(func(namespace types.Namespace) (types.StorageInterface, error) {
+		if !isServed[namespace] {
+			return nil, fmt.Errorf("%w: %v is not served", types.ErrNamespaceDenied, namespace)
+		}
+		return local.store.Storage().Namespace(namespace), nil
+	}, cfgs...)
+
+	server := &http.Server{
+		Addr:    *addr,
+		Handler: api.NewGateway(namespaces),
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	// the background loops run on the engines serving the requests so they share their inboxes
+	for i := 0; i < len(served); i++ {
+		jj, err := namespaces.Get(served[i])
+		if err != nil {
+			return err
+		}
+
+		// drafts are only reported until `-reap-enforce`
+		if *reapTTL > 0 {
+			mode := junjo.ReaperDryRun
+			if *reapEnforce {
+				mode = junjo.ReaperEnforce
+			}
+			reaper := jj.NewReaper(
+				junjo.WithReaperTTL(*reapTTL),
+				junjo.WithReaperInterval(*reapInterval),
+				junjo.WithReaperMode(mode))
+			go reaper.Run(ctx)
+		}
+
+		if *scheduleInterval > 0 {
+			go jj.NewScheduler(junjo.WithSchedulerInterval(*scheduleInterval)).Run(ctx)
+		}
+
+		if *webhookInterval > 0 {
+			go jj.NewDispatcher(junjo.WithDispatcherInterval(*webhookInterval)).Run(ctx)
+		}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	errCh := make(chan error, 2)
+	go func() {
+		log.Printf("serving %v (namespaces %v) on %v", store, served, *addr)
+		errCh <- server.ListenAndServe()
+	}()
+
+	// gRPC next to HTTP on its own port, both on the same stores
+	stopGRPC := func() {}
+	if *grpcAddr != "" {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			server.Close()
+			return err
+		}
+		grpcServer := rpc.RegisterNamespaces(namespaces)
+		// the inbox watchers never end on their own, a graceful stop would wait for them
+		stopGRPC = grpcServer.Stop
+		go func() {
+			log.Printf("serving %v over gRPC on %v", store, *grpcAddr)
+			errCh <- grpcServer.Serve(listener)
+		}()
+	}
+
+	select {
+	case err = <-errCh:
+	case <-sigCh:
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err = server.Shutdown(ctx)
+	}
+	stopGRPC()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// The tokens file maps each token to its principal
+//
+//	{"b1lling": {"name": "billing-ci", "namespaces": ["billing"], "roles": [{"role": "topic-manager"}]},
+//	 "4dmin": {"name": "ops", "namespaces": ["billing", "payroll", "support"], "roles": [{"role": "admin"}]}}
+func loadTokens(path string) (types.StaticTokens, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tokens := types.StaticTokens{}
+	if err = json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("%w: tokens %v: %v", ErrUsage, path, err)
+	}
+	for token, principal := range tokens {
+		if token == "" {
+			return nil, fmt.Errorf("%w: tokens %v: empty token", ErrUsage, path)
+		}
+		for i := 0; i < len(principal.Namespaces); i++ {
+			if err = types.ValidateNamespace(principal.Namespaces[i]); err != nil {
+				return nil, err
+			}
+		}
+		if err = types.ValidateRoles(principal.Roles); err != nil {
+			return nil, fmt.Errorf("%w: tokens %v: %v", ErrUsage, path, err)
+		}
+	}
+	return tokens, nil
+}
+
+// Every namespace given to a token, sorted
+func tokenNamespaces(tokens types.StaticTokens) []types.Namespace {
+	seen := map[types.Namespace]bool{}
+	namespaces := []types.Namespace{}
+	for _, principal := range tokens {
+		granted := principal.Namespaces
+		for i := 0; i < len(granted); i++ {
+			if !seen[granted[i]] {
+				seen[granted[i]] = true
+				namespaces = append(namespaces, granted[i])
+			}
+		}
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i] < namespaces[j] })
+	return namespaces
+}
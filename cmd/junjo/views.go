@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/davidroman0O/junjo/api"
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Same views than `MemoryStorage.PrintTree` and `MemoryStorage.PrintDAG` but built from a `backend` so they work on a remote server too
+
+type treeUnit struct {
+	api.TaskUnit
+	Definition string `json:"definition"`
+	Owner      string `json:"owner"`
+	Depth      int    `json:"depth"`
+}
+
+type treeTask struct {
+	ID     types.TaskID     `json:"id"`
+	Status types.StatusType `json:"status"`
+	Units  []treeUnit       `json:"units"`
+}
+
+type treeJob struct {
+	ID     types.JobID      `json:"id"`
+	Status types.StatusType `json:"status"`
+	Tasks  []treeTask       `json:"tasks"`
+}
+
+type treeTopic struct {
+	ID    types.TopicID `json:"id"`
+	Name  string        `json:"name"`
+	Jobs  []treeJob     `json:"jobs"`
+	Error string        `json:"error,omitempty"`
+}
+
+func treeCmd(b backend, out *printer, args []string, depth bool) error {
+	name := "tree"
+	if depth {
+		name = "dag"
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("%w: %v expects at most one topic", ErrUsage, name)
+	}
+	values := args
+
+	topics, err := b.GetTopics()
+	if err != nil {
+		return err
+	}
+	if len(values) == 1 {
+		topic, err := b.GetTopic(types.TopicID(values[0]))
+		if err != nil {
+			return err
+		}
+		topics = []types.Topic{*topic}
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Name < topics[j].Name })
+
+	definitions, err := b.GetTaskDefinitions()
+	if err != nil {
+		return err
+	}
+	definitionsByID := map[types.TaskDefinitionID]types.TaskDefinition{}
+	for i := 0; i < len(definitions); i++ {
+		definitionsByID[definitions[i].Key] = definitions[i]
+	}
+	owners, err := b.GetOwners()
+	if err != nil {
+		return err
+	}
+	ownersByID := map[types.OwnerID]types.Owner{}
+	for i := 0; i < len(owners); i++ {
+		ownersByID[owners[i].Key] = owners[i]
+	}
+
+	tree := []treeTopic{}
+	for i := 0; i < len(topics); i++ {
+		topic := treeTopic{ID: topics[i].Key, Name: topics[i].Name, Jobs: []treeJob{}}
+		jobs, err := b.GetJobs(topics[i].Key)
+		if err != nil {
+			return err
+		}
+		sort.Slice(jobs, func(i, j int) bool { return jobs[i].Key < jobs[j].Key })
+		for j := 0; j < len(jobs); j++ {
+			job := treeJob{ID: jobs[j].Key, Status: jobs[j].Status, Tasks: []treeTask{}}
+			tasks, err := b.GetTasks(jobs[j].Key)
+			if err != nil {
+				return err
+			}
+			sort.Slice(tasks, func(i, j int) bool { return tasks[i].Key < tasks[j].Key })
+			for k := 0; k < len(tasks); k++ {
+				units, err := b.GetTaskUnits(tasks[k].Key)
+				if err != nil {
+					return err
+				}
+				task := treeTask{ID: tasks[k].Key, Status: tasks[k].Status}
+				if task.Units, err = sortUnits(units, definitionsByID, ownersByID); err != nil {
+					topic.Error = err.Error()
+				}
+				job.Tasks = append(job.Tasks, task)
+			}
+			topic.Jobs = append(topic.Jobs, job)
+		}
+		tree = append(tree, topic)
+	}
+
+	if out.format == outputJSON {
+		return out.json(tree)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Topics:\n")
+	for i := 0; i < len(tree); i++ {
+		builder.WriteString(fmt.Sprintf("- %s (%s)\n  Jobs:\n", tree[i].Name, tree[i].ID))
+		if tree[i].Error != "" {
+			builder.WriteString(fmt.Sprintf("  Error: %s\n", tree[i].Error))
+		}
+		for j := 0; j < len(tree[i].Jobs); j++ {
+			job := tree[i].Jobs[j]
+			builder.WriteString(fmt.Sprintf("  - %s (%s)\n    Tasks:\n", job.ID, job.Status))
+			for k := 0; k < len(job.Tasks); k++ {
+				task := job.Tasks[k]
+				builder.WriteString(fmt.Sprintf("    - %s (%s)\n      Task Units:\n", task.ID, task.Status))
+				for l := 0; l < len(task.Units); l++ {
+					unit := task.Units[l]
+					indent := "      "
+					if depth {
+						indent = strings.Repeat("    ", unit.Depth+1) + "  "
+					}
+					builder.WriteString(fmt.Sprintf("%s- %s (%s)\n", indent, unit.Key, unit.Status))
+					builder.WriteString(fmt.Sprintf("%s  - %s (%s)\n", indent, unit.Definition, unit.Owner))
+				}
+			}
+		}
+	}
+	_, err = fmt.Fprint(out.w, builder.String())
+	return err
+}
+
+// Units in topological order with the depth of each unit in its task
+func sortUnits(units []types.TaskUnit, definitions map[types.TaskDefinitionID]types.TaskDefinition, owners map[types.OwnerID]types.Owner) ([]treeUnit, error) {
+	byID := map[types.TaskUnitID]*types.TaskUnit{}
+	for i := 0; i < len(units); i++ {
+		byID[units[i].Key] = &units[i]
+	}
+	sorted, err := types.TopologicalSort(byID)
+	if err != nil {
+		return []treeUnit{}, err
+	}
+
+	values := make([]treeUnit, 0, len(sorted))
+	depths := map[types.TaskUnitID]int{}
+	for i := 0; i < len(sorted); i++ {
+		unit := byID[sorted[i]]
+		depth := 0
+		for j := 0; j < len(unit.DependsOnIDs); j++ {
+			if d, found := depths[unit.DependsOnIDs[j]]; found && d+1 > depth {
+				depth = d + 1
+			}
+		}
+		depths[unit.Key] = depth
+
+		value := treeUnit{TaskUnit: api.ToTaskUnit(*unit), Depth: depth}
+		if definition, ok := definitions[unit.TaskDefinitionID]; ok {
+			value.Definition = definition.Name
+			if owner, ok := owners[definition.OwnerID]; ok {
+				value.Owner = owner.Name
+			}
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
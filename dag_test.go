@@ -1,6 +1,7 @@
 package junjo
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -13,7 +14,7 @@ import (
 func TestEdges(t *testing.T) {
 	storage := memory.NewMemoryStorage()
 	var err error
-	jj := New(storage)
+	jj := NewJ(storage)
 
 	var vertexA *types.Owner
 	if vertexA, err = jj.CreateOwner("vertexA"); err != nil {
@@ -81,6 +82,104 @@ func TestEdges(t *testing.T) {
 	}
 }
 
+// go test -timeout 30s -v -count=1 -run ^TestWorkUnitDagJSON$ .
+func TestWorkUnitDagJSON(t *testing.T) {
+	storage := memory.NewMemoryStorage()
+	var err error
+	jj := NewJ(storage)
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var logging *types.TaskDefinition
+	if logging, err = jj.CreateTaskDefinition("log", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	defProvisioning := unitDag.AddTaskDefinition(provisioning)
+	defLog := unitDag.AddTaskDefinition(logging)
+
+	first, second := unitDag.ConnectDef(defProvisioning, defProvisioning)
+	unitDag.MConnectDef(defLog, first, second)
+
+	first.(*types.NodeTaskUnit).Unit.Status = types.SuccessStatus
+	second.(*types.NodeTaskUnit).SetMetadata(map[string]string{"machine": "uuid"})
+
+	var data []byte
+	if data, err = json.Marshal(unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	restored := jj.CreateDagTaskUnits()
+	if err = json.Unmarshal(data, restored); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(restored.Graph().Vertices()) != 3 || len(restored.Graph().Edges()) != 3 {
+		t.Error(fmt.Errorf("should have 3 vertices and 3 edges"))
+		return
+	}
+
+	available := restored.AvailableNodeUnit()
+	if len(available) != 1 || available[0].Unit.Key != second.(*types.NodeTaskUnit).Unit.Key {
+		t.Error(fmt.Errorf("only the second provisioning should be available"))
+		return
+	}
+	if available[0].Unit.Data["machine"] != "uuid" || available[0].Definition.Name != "provisioning" {
+		t.Error(fmt.Errorf("data and definition should be restored"))
+		return
+	}
+
+	// vertices sharing a definition still share it
+	var provisioningNodes []*types.NodeTaskUnit
+	for _, vertex := range restored.Graph().Vertices() {
+		node := vertex.(*types.NodeTaskUnit)
+		if node.Definition.Key == provisioning.Key {
+			provisioningNodes = append(provisioningNodes, node)
+		}
+	}
+	if len(provisioningNodes) != 2 || provisioningNodes[0].Definition != provisioningNodes[1].Definition {
+		t.Error(fmt.Errorf("definitions should be shared"))
+		return
+	}
+
+	var again []byte
+	if again, err = json.Marshal(restored); err != nil {
+		t.Error(err)
+		return
+	}
+	if string(data) != string(again) {
+		t.Error(fmt.Errorf("round-trip should be identical"))
+		return
+	}
+
+	// still usable with the storage after decoding
+	restored.AssociateDef(provisioningNodes[0], restored.AddTaskDefinition(logging))
+	if len(restored.Graph().Vertices()) != 4 {
+		t.Error(fmt.Errorf("should have 4 vertices"))
+		return
+	}
+
+	cyclic := `{"version":1,"definitions":[],"vertices":[{"id":"a","status":"none"},{"id":"b","status":"none"}],"edges":[{"source":"a","target":"b"},{"source":"b","target":"a"}]}`
+	if err = json.Unmarshal([]byte(cyclic), jj.CreateDagTaskUnits()); err == nil {
+		t.Error(fmt.Errorf("cycles should be rejected"))
+		return
+	}
+}
+
 // // go test -timeout 30s -v -count=1 -run ^TestGeneral$ ./dag
 // func TestGeneral(t *testing.T) {
 
@@ -178,3 +277,62 @@ func TestEdges(t *testing.T) {
 // 		fmt.Println(idx, node.GetDescription().Identifier, node)
 // 	}
 // }
+
+// The dependency is the source of its edge, a unit is offered once its dependencies succeeded and only with its own definition
+// go test -timeout 30s -v -count=1 -run ^TestDagAvailability$ .
+func TestDagAvailability(t *testing.T) {
+	storage := memory.NewMemoryStorage()
+	var err error
+	jj := NewJ(storage)
+
+	var bench *types.Owner
+	if bench, err = jj.CreateOwner("Bench"); err != nil {
+		t.Error(err)
+		return
+	}
+	var flash *types.TaskDefinition
+	if flash, err = jj.CreateTaskDefinition("flash", bench.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	available := func(units []types.TaskUnit) []types.TaskUnitID {
+		workUnitDag, err := types.CreateDagFromTaskUnits(storage, units, []types.TaskDefinition{*flash})
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+		ids := []types.TaskUnitID{}
+		for _, node := range workUnitDag.AvailableNodeUnitWithOwner(bench.Key) {
+			ids = append(ids, node.Unit.Key)
+		}
+		return ids
+	}
+
+	// "without" has no definition, it used to take the one of the unit before it
+	units := []types.TaskUnit{
+		{Key: "first", TaskDefinitionID: flash.Key, Status: types.NoneStatus},
+		{Key: "second", TaskDefinitionID: flash.Key, Status: types.NoneStatus, DependsOnIDs: []types.TaskUnitID{"first"}},
+		{Key: "without", Status: types.NoneStatus},
+	}
+	if ids := available(units); len(ids) != 1 || ids[0] != "first" {
+		t.Errorf("expected only the unit without dependency offered, got %v", ids)
+		return
+	}
+	units[0].Status = types.ProgressStatus
+	if ids := available(units); len(ids) != 0 {
+		t.Errorf("expected nothing offered while the dependency runs, got %v", ids)
+		return
+	}
+	units[0].Status = types.SuccessStatus
+	if ids := available(units); len(ids) != 1 || ids[0] != "second" {
+		t.Errorf("expected the dependent unit offered once its dependency succeeded, got %v", ids)
+		return
+	}
+
+	// the given status wins over the default one
+	node := types.NewWorkUnitDag(storage).AddTaskUnit(types.WithNodeWithTaskStatus(types.SuccessStatus)).(*types.NodeTaskUnit)
+	if node.Unit.Status != types.SuccessStatus {
+		t.Errorf("expected the status given to AddTaskUnit kept, got %v", node.Unit.Status)
+		return
+	}
+}
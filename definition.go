@@ -0,0 +1,49 @@
+package junjo
+
+import (
+	"github.com/davidroman0O/junjo/types"
+)
+
+func (j *Junjoold) GetTaskDefinitionVersion(id types.TaskDefinitionID, version int) (*types.TaskDefinition, error) {
+	return j.storageImplementation.GetTaskDefinitionVersion(id, version)
+}
+
+// Every version of the definition, oldest first, deprecated ones included
+func (j *Junjoold) GetTaskDefinitionVersions(id types.TaskDefinitionID) ([]types.TaskDefinition, error) {
+	return j.storageImplementation.GetTaskDefinitionVersions(id)
+}
+
+// The version of its definition the unit was created with
+func (j *Junjoold) unitDefinition(unit *types.TaskUnit) (*types.TaskDefinition, error) {
+	return j.storageImplementation.GetTaskDefinitionVersion(unit.TaskDefinitionID, unit.DefinitionVersion)
+}
+
+// Owner of the unit with the version of the definition it pinned, its own owner when the definition can't be read
+func (j *Junjoold) unitOwner(unit *types.TaskUnit) types.OwnerID {
+	definition, err := j.unitDefinition(unit)
+	if err != nil {
+		return unit.OwnerID
+	}
+	return unit.Owner(definition)
+}
+
+// The versions pinned by the units, once each
+func (j *Junjoold) unitDefinitions(units []types.TaskUnit) ([]types.TaskDefinition, error) {
+	definitions := []types.TaskDefinition{}
+	seen := map[types.TaskDefinitionID]map[int]bool{}
+	for i := 0; i < len(units); i++ {
+		if units[i].TaskDefinitionID == "" || seen[units[i].TaskDefinitionID][units[i].DefinitionVersion] {
+			continue
+		}
+		definition, err := j.unitDefinition(&units[i])
+		if err != nil {
+			return nil, err
+		}
+		if seen[units[i].TaskDefinitionID] == nil {
+			seen[units[i].TaskDefinitionID] = map[int]bool{}
+		}
+		seen[units[i].TaskDefinitionID][units[i].DefinitionVersion] = true
+		definitions = append(definitions, *definition)
+	}
+	return definitions, nil
+}
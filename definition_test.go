@@ -0,0 +1,95 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestDefinitionVersions$ .
+func TestDefinitionVersions(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Factory"); err != nil {
+		t.Error(err)
+		return
+	}
+	var bench *types.Owner
+	if bench, err = jj.CreateOwner("Bench"); err != nil {
+		t.Error(err)
+		return
+	}
+	var flash *types.TaskDefinition
+	if flash, err = jj.CreateTaskDefinition("flash", bench.Key, types.WithTaskDefIdentifier("firmware-1.0")); err != nil {
+		t.Error(err)
+		return
+	}
+	launch := func() (types.TaskUnitID, error) {
+		unitDag := jj.CreateDagTaskUnits()
+		node := unitDag.AddTaskDefinition(flash)()
+		if _, err := jj.LaunchJob(topic.Key, unitDag); err != nil {
+			return "", err
+		}
+		return node.(*types.NodeTaskUnit).Unit.Key, nil
+	}
+	// the firmware the unit runs with
+	identifier := func(unitID types.TaskUnitID) string {
+		unit, err := jj.GetTaskUnit(unitID)
+		if err != nil {
+			t.Error(err)
+			return ""
+		}
+		definition, err := jj.GetTaskDefinitionVersion(unit.TaskDefinitionID, unit.DefinitionVersion)
+		if err != nil {
+			t.Error(err)
+			return ""
+		}
+		return definition.Identifier
+	}
+
+	var before, after types.TaskUnitID
+	if before, err = launch(); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = jj.UpdateTaskDefinition(flash.Key, bench.Key, "flash", "", "firmware-2.0"); err != nil {
+		t.Error(err)
+		return
+	}
+	if after, err = launch(); err != nil {
+		t.Error(err)
+		return
+	}
+	if identifier(before) != "firmware-1.0" || identifier(after) != "firmware-2.0" {
+		t.Errorf("expected each unit to keep the version of its launch, got %v and %v", identifier(before), identifier(after))
+		return
+	}
+
+	if err = jj.DeprecateTaskDefinition(flash.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = launch(); !errors.Is(err, types.ErrTaskDefinitionDeprecated) {
+		t.Errorf("expected a new job refused, got %v", err)
+		return
+	}
+	// the work already launched goes on
+	if err = jj.SubmitCommand(before, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var versions []types.TaskDefinition
+	if versions, err = jj.GetTaskDefinitionVersions(flash.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(versions) != 2 || versions[0].Version != 1 || versions[1].Version != 2 || !versions[0].Deprecated || versions[0].Identifier != "firmware-1.0" {
+		t.Errorf("expected both versions readable and deprecated, got %v", versions)
+		return
+	}
+}
@@ -0,0 +1,218 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestDeprecation$ .
+func TestDeprecation(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Rollout"); err != nil {
+		t.Error(err)
+		return
+	}
+	var contractor, backup *types.Owner
+	if contractor, err = jj.CreateOwner("Contractor"); err != nil {
+		t.Error(err)
+		return
+	}
+	if backup, err = jj.CreateOwner("Backup"); err != nil {
+		t.Error(err)
+		return
+	}
+	var install, reinstall *types.TaskDefinition
+	if install, err = jj.CreateTaskDefinition("install", contractor.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if reinstall, err = jj.CreateTaskDefinition("reinstall", backup.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	unitDag := jj.CreateDagTaskUnits()
+	started := unitDag.AddTaskDefinition(install)().(*types.NodeTaskUnit).Unit.Key
+	pending := unitDag.AddTaskDefinition(install)().(*types.NodeTaskUnit).Unit.Key
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(started, types.Command{Type: types.ProgressCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the contractor leaves, no new work goes to it
+	if _, err = jj.DeprecateOwner(contractor.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = jj.CreateTaskDefinition("audit", contractor.Key); !errors.Is(err, types.ErrOwnerDeprecated) {
+		t.Errorf("expected a new definition refused, got %v", err)
+		return
+	}
+	refused := jj.CreateDagTaskUnits()
+	refused.AddTaskDefinition(install)()
+	if _, err = jj.LaunchJob(topic.Key, refused); !errors.Is(err, types.ErrOwnerDeprecated) {
+		t.Errorf("expected a new job refused, got %v", err)
+		return
+	}
+
+	var migrated []types.TaskUnitID
+	if migrated, err = jj.MigrateOwner(contractor.Key, backup.Key, "contract ended"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(migrated) != 1 || migrated[0] != pending {
+		t.Errorf("expected only the pending unit migrated, got %v", migrated)
+		return
+	}
+	// the started one stays with the contractor until it's done
+	if err = jj.SubmitCommand(started, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err = jj.DeprecateTaskDefinition(install.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	// pinning the version of before the deprecation doesn't help
+	pinned := jj.CreateDagTaskUnits()
+	pinned.AddTaskDefinition(install)().(*types.NodeTaskUnit).Unit.DefinitionVersion = install.Version
+	if _, err = jj.LaunchJob(topic.Key, pinned); !errors.Is(err, types.ErrTaskDefinitionDeprecated) {
+		t.Errorf("expected a pinned unit of a deprecated definition refused, got %v", err)
+		return
+	}
+	if migrated, err = jj.MigrateTaskDefinition(install.Key, reinstall.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	var unit *types.TaskUnit
+	if unit, err = jj.GetTaskUnit(pending); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(migrated) != 1 || unit.TaskDefinitionID != reinstall.Key || unit.DefinitionVersion != reinstall.Version {
+		t.Errorf("expected the pending unit to use the replacement, got %v %v", migrated, unit.TaskDefinitionID)
+		return
+	}
+
+	if err = jj.DeprecateTopic(topic.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	unitDag = jj.CreateDagTaskUnits()
+	unitDag.AddTaskDefinition(reinstall)()
+	if _, err = jj.LaunchJob(topic.Key, unitDag); !errors.Is(err, types.ErrTopicDeprecated) {
+		t.Errorf("expected a job of a deprecated topic refused, got %v", err)
+		return
+	}
+	// its jobs go on
+	if err = jj.SubmitCommand(pending, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestDeprecationFanOut$ .
+func TestDeprecationFanOut(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var lab *types.Topic
+	if lab, err = jj.CreateTopic("Lab"); err != nil {
+		t.Error(err)
+		return
+	}
+	var inventorist, contractor, backup *types.Owner
+	if inventorist, err = jj.CreateOwner("Inventory"); err != nil {
+		t.Error(err)
+		return
+	}
+	if contractor, err = jj.CreateOwner("Contractor"); err != nil {
+		t.Error(err)
+		return
+	}
+	if backup, err = jj.CreateOwner("Backup"); err != nil {
+		t.Error(err)
+		return
+	}
+	var inventory, flashing *types.TaskDefinition
+	if inventory, err = jj.CreateTaskDefinition("inventory", inventorist.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if flashing, err = jj.CreateTaskDefinition("flashing", contractor.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	listing := unitDag.AddTaskDefinition(inventory)()
+	machines := unitDag.AddFanOut(flashing, "machines", "machine")
+	unitDag.Connect(listing, machines)
+	var job *types.Job
+	if job, err = jj.LaunchJob(lab.Key, unitDag, types.WithJobData(map[string]string{"machines": "m1,m2"})); err != nil {
+		t.Error(err)
+		return
+	}
+	fanOutID := machines.(*types.NodeTaskUnit).Unit.Key
+
+	// the contractor leaves before the fan-out is ready, the fan-out was launched before so its children are still created
+	if _, err = jj.DeprecateOwner(contractor.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(listing.(*types.NodeTaskUnit).Unit.Key, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	var fanOut *types.TaskUnit
+	if fanOut, err = jj.GetTaskUnit(fanOutID); err != nil {
+		t.Error(err)
+		return
+	}
+	if fanOut.Status != types.ProgressStatus {
+		t.Errorf("expected the fan-out expanded, got %v", fanOut.Status)
+		return
+	}
+
+	// then its children move like any pending unit
+	var migrated []types.TaskUnitID
+	if migrated, err = jj.MigrateOwner(contractor.Key, backup.Key, "contract ended"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(migrated) != 2 {
+		t.Errorf("expected the children migrated, got %v", migrated)
+		return
+	}
+
+	var units []types.TaskUnit
+	if units, err = jj.GetTaskUnits(job.TaskIDs[0]); err != nil {
+		t.Error(err)
+		return
+	}
+	children := 0
+	for i := 0; i < len(units); i++ {
+		if units[i].ParentID != fanOutID {
+			continue
+		}
+		children++
+		if units[i].OwnerID != backup.Key {
+			t.Errorf("expected the child %v to go to the replacement, got %v", units[i].Key, units[i].OwnerID)
+			return
+		}
+	}
+	if children != 2 {
+		t.Errorf("expected 2 children once migrated, got %v", children)
+		return
+	}
+}
@@ -0,0 +1,240 @@
+package junjo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// The `Dispatcher` sends the pending deliveries of the webhooks every interval
+///
+///	dispatcher := jj.NewDispatcher()
+///	go dispatcher.Run(ctx)
+///
+/// A delivery is done when the webhook answers a 2xx, otherwise it's tried again after 1s, 2s, 4s... until it runs out of attempts
+
+// What happened to one attempt of a `Delivery`
+type DeliveryAttempt struct {
+	DeliveryID   types.DeliveryID     `json:"deliveryID"`
+	WebhookID    types.WebhookID      `json:"webhookID"`
+	Event        types.EventType      `json:"event"`
+	Status       types.DeliveryStatus `json:"status"`
+	Attempt      int                  `json:"attempt"`
+	ResponseCode int                  `json:"responseCode,omitempty"`
+	Err          error                `json:"-"`
+}
+
+func (a DeliveryAttempt) String() string {
+	message := fmt.Sprintf("webhook %v delivery %v %v attempt %v: %v", a.WebhookID, a.DeliveryID, a.Event, a.Attempt, a.Status)
+	if a.ResponseCode != 0 {
+		message += fmt.Sprintf(" (%v)", a.ResponseCode)
+	}
+	if a.Err != nil {
+		message += fmt.Sprintf(", error: %v", a.Err)
+	}
+	return message
+}
+
+type Dispatcher struct {
+	jj          *Junjoold
+	interval    time.Duration
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	report      func(DeliveryAttempt)
+	now         func() time.Time
+}
+
+type DispatcherConfig func(d *Dispatcher)
+
+// Delay between two checks of the pending deliveries, 5 seconds by default
+func WithDispatcherInterval(interval time.Duration) DispatcherConfig {
+	return func(d *Dispatcher) {
+		d.interval = interval
+	}
+}
+
+// Client sending the deliveries, with a 10 seconds timeout by default
+func WithDispatcherClient(client *http.Client) DispatcherConfig {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+// Attempts before a delivery is failed, 8 by default
+func WithDispatcherMaxAttempts(maximum int) DispatcherConfig {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maximum
+	}
+}
+
+// Delay before the second attempt, doubled at each attempt after, 1 second by default
+func WithDispatcherBackoff(backoff time.Duration) DispatcherConfig {
+	return func(d *Dispatcher) {
+		d.backoff = backoff
+	}
+}
+
+// Receive each attempt made by `Run`, they are logged by default
+func WithDispatcherReport(report func(DeliveryAttempt)) DispatcherConfig {
+	return func(d *Dispatcher) {
+		d.report = report
+	}
+}
+
+// Replace the clock, useful for your tests
+func WithDispatcherClock(now func() time.Time) DispatcherConfig {
+	return func(d *Dispatcher) {
+		d.now = now
+	}
+}
+
+// Create a `Dispatcher` for the deliveries of the storage, call `Run` to start it in the background
+func (j *Junjoold) NewDispatcher(cfgs ...DispatcherConfig) *Dispatcher {
+	dispatcher := &Dispatcher{
+		jj:          j,
+		interval:    5 * time.Second,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: 8,
+		backoff:     time.Second,
+		report: func(attempt DeliveryAttempt) {
+			log.Println(attempt)
+		},
+		now: time.Now,
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](dispatcher)
+	}
+	return dispatcher
+}
+
+// Send the deliveries once every interval until the context is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		attempts, err := d.Tick(ctx)
+		if err != nil {
+			log.Printf("dispatcher: %v", err)
+		}
+		for i := 0; i < len(attempts); i++ {
+			d.report(attempts[i])
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Send every pending delivery whose next attempt has come
+func (d *Dispatcher) Tick(ctx context.Context) ([]DeliveryAttempt, error) {
+	deliveries, err := d.jj.storageImplementation.GetPendingDeliveries()
+	if err != nil {
+		return nil, err
+	}
+
+	now := d.now()
+	attempts := []DeliveryAttempt{}
+	webhooks := map[types.WebhookID]*types.Webhook{}
+	for i := 0; i < len(deliveries); i++ {
+		if deliveries[i].NextAttempt.After(now) {
+			break
+		}
+		if ctx.Err() != nil {
+			return attempts, ctx.Err()
+		}
+		webhook, ok := webhooks[deliveries[i].WebhookID]
+		if !ok {
+			if webhook, err = d.jj.storageImplementation.GetWebhook(deliveries[i].WebhookID); err != nil {
+				// deleted while we were sending
+				if errors.Is(err, types.ErrWebhookNotFound) {
+					continue
+				}
+				return attempts, err
+			}
+			webhooks[webhook.Key] = webhook
+		}
+		attempt := d.send(ctx, webhook, &deliveries[i])
+		attempts = append(attempts, attempt)
+		if err = d.jj.storageImplementation.UpdateDelivery(deliveries[i]); err != nil && !errors.Is(err, types.ErrWebhookNotFound) {
+			return attempts, err
+		}
+	}
+	return attempts, nil
+}
+
+// Post the event and record the outcome on the delivery
+func (d *Dispatcher) send(ctx context.Context, webhook *types.Webhook, delivery *types.Delivery) DeliveryAttempt {
+	delivery.Attempts++
+	attempt := DeliveryAttempt{
+		DeliveryID: delivery.Key,
+		WebhookID:  webhook.Key,
+		Event:      delivery.Event.Type,
+		Attempt:    delivery.Attempts,
+	}
+
+	code, err := d.post(ctx, webhook, delivery)
+	delivery.ResponseCode = code
+	now := d.now()
+	switch {
+	case err == nil:
+		delivery.Status = types.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = now
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = types.DeliveryFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+		// past 20 attempts the delay stops doubling
+		shift := delivery.Attempts - 1
+		if shift > 20 {
+			shift = 20
+		}
+		delivery.NextAttempt = now.Add(d.backoff << shift)
+	}
+	attempt.Status = delivery.Status
+	attempt.ResponseCode = code
+	attempt.Err = err
+	return attempt
+}
+
+func (d *Dispatcher) post(ctx context.Context, webhook *types.Webhook, delivery *types.Delivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(types.HeaderEvent, string(delivery.Event.Type))
+	request.Header.Set(types.HeaderDelivery, string(delivery.Key))
+	if webhook.Secret != "" {
+		request.Header.Set(types.HeaderSignature, types.Sign(webhook.Secret, body))
+	}
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("webhook answered %v", response.Status)
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,137 @@
+package junjo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestRenderDOT$ .
+func TestRenderDOT(t *testing.T) {
+	storage := memory.NewMemoryStorage()
+	var err error
+	jj := NewJ(storage)
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var network *types.Owner
+	if network, err = jj.CreateOwner("Network"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var ipxe *types.TaskDefinition
+	if ipxe, err = jj.CreateTaskDefinition("ipxe", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var portConfig *types.TaskDefinition
+	if portConfig, err = jj.CreateTaskDefinition("port config", network.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	source, _ := unitDag.ConnectDef(unitDag.AddTaskDefinition(ipxe), unitDag.AddTaskDefinition(portConfig))
+
+	dot := string(unitDag.Dot())
+	if !strings.Contains(dot, string(btl.Key)) || !strings.Contains(dot, "->") {
+		t.Error(fmt.Errorf("dot should contain the owner id and the edge"))
+		return
+	}
+
+	var units []*types.TaskUnit
+	if units, err = unitDag.ToTaskUnits(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var ids []types.TaskUnitID
+	if ids, err = jj.CreateTaskUnits(units); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var task *types.Task
+	if task, err = jj.CreateTask(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var job *types.Job
+	if job, err = jj.CreateJob(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err = jj.AssignTaskUnits(task.Key, ids); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.AssignTask(job.Key, task.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.AssignJob(topic.Key, job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err = storage.UpdateTaskUnitStatus(types.TaskUnitID(source.(*types.NodeTaskUnit).ID()), types.SuccessStatus, nil); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var raw []byte
+	if raw, err = jj.RenderTaskDOT(task.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	dot = string(raw)
+
+	if !strings.Contains(dot, "ipxe\\nBTL") || !strings.Contains(dot, "port config\\nNetwork") {
+		fmt.Println(dot)
+		t.Error(fmt.Errorf("nodes should be labeled with definition and owner names"))
+		return
+	}
+	if !strings.Contains(dot, "palegreen") || !strings.Contains(dot, "white") {
+		fmt.Println(dot)
+		t.Error(fmt.Errorf("nodes should be colored by status"))
+		return
+	}
+	edge := fmt.Sprintf(`"[root] %s" -> "[root] %s"`, units[0].Key, units[1].Key)
+	if units[0].Key != source.(*types.NodeTaskUnit).Unit.Key {
+		edge = fmt.Sprintf(`"[root] %s" -> "[root] %s"`, units[1].Key, units[0].Key)
+	}
+	if !strings.Contains(dot, edge) {
+		fmt.Println(dot)
+		t.Error(fmt.Errorf("edge should go from the dependency to the unit"))
+		return
+	}
+
+	if raw, err = jj.RenderJobDOT(job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	dot = string(raw)
+
+	if !strings.Contains(dot, fmt.Sprintf(`subgraph "cluster_task %s"`, task.Key)) {
+		fmt.Println(dot)
+		t.Error(fmt.Errorf("job should have one cluster per task"))
+		return
+	}
+}
@@ -0,0 +1,159 @@
+package junjo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Fan-outs are expanded by the rollup as soon as their dependencies succeed, see `types.FanOut`
+///
+///	unitDag := jj.CreateDagTaskUnits()
+///	machines := unitDag.AddFanOut(flashing, "machines", "machine")
+///	unitDag.AddFanIn(report, machines, 0)
+///	jj.LaunchJob(topic.Key, unitDag, types.WithJobData(map[string]string{"machines": `["m1","m2","m3"]`}))
+
+// Expand the fan-outs ready to start and update the status of the expanded ones until nothing changes, returns the units of the task
+func (j *Junjoold) settleFanOuts(taskID types.TaskID) ([]types.TaskUnit, error) {
+	for {
+		units, err := j.storageImplementation.GetTaskUnits(taskID)
+		if err != nil {
+			return nil, err
+		}
+		changed := false
+		for i := 0; i < len(units); i++ {
+			if units[i].FanOut == nil {
+				continue
+			}
+			var done bool
+			switch units[i].Status {
+			case types.NoneStatus:
+				done, err = j.expandFanOut(units[i], units)
+			case types.ProgressStatus:
+				done, err = j.updateFanOut(units[i], units)
+			}
+			if err != nil {
+				return nil, err
+			}
+			changed = changed || done
+		}
+		if !changed {
+			return units, nil
+		}
+	}
+}
+
+func (j *Junjoold) expandFanOut(fanOut types.TaskUnit, units []types.TaskUnit) (bool, error) {
+	statuses := map[types.TaskUnitID]types.StatusType{}
+	for i := 0; i < len(units); i++ {
+		statuses[units[i].Key] = units[i].Status
+	}
+	ancestors := []types.StatusType{}
+	for i := 0; i < len(fanOut.DependsOnIDs); i++ {
+		ancestors = append(ancestors, statuses[fanOut.DependsOnIDs[i]])
+	}
+	if !types.JoinSatisfied(fanOut.Join, ancestors) {
+		return false, nil
+	}
+
+	items, err := j.fanOutList(fanOut, units)
+	if err != nil {
+		return true, j.storageImplementation.UpdateTaskUnitStatus(fanOut.Key, types.ErrorStatus, err)
+	}
+
+	children := []*types.TaskUnit{}
+	for i := 0; i < len(items); i++ {
+		uuid, err := j.storageImplementation.NewUUID()
+		if err != nil {
+			return false, err
+		}
+		data := map[string]string{}
+		for k, v := range fanOut.Data {
+			data[k] = v
+		}
+		data[fanOut.FanOut.Item()] = items[i]
+		child := types.NewTaskUnit(
+			types.TaskUnitID(uuid),
+			types.WithTaskUnitDefinitionKey(fanOut.TaskDefinitionID),
+			types.WithTaskUnitDefinitionVersion(fanOut.DefinitionVersion),
+			types.WithTaskUnitOwner(fanOut.OwnerID),
+			types.WithTaskUnitData(data))
+		child.DependsOnIDs = append(child.DependsOnIDs, fanOut.DependsOnIDs...)
+		child.Priority = fanOut.Priority
+		child.Join = fanOut.Join
+		children = append(children, child)
+	}
+
+	err = j.storageImplementation.ExpandFanOut(fanOut.Key, children)
+	if errors.Is(err, types.ErrFanOutInvalid) {
+		// expanded by someone else in the meantime
+		if current, getErr := j.storageImplementation.GetTaskUnit(fanOut.Key); getErr == nil && current.Status != types.NoneStatus {
+			return true, nil
+		}
+	}
+	return err == nil, err
+}
+
+// The output of the dependencies overrides the job data
+func (j *Junjoold) fanOutList(fanOut types.TaskUnit, units []types.TaskUnit) ([]string, error) {
+	value, found := "", false
+
+	task, err := j.storageImplementation.GetTask(fanOut.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.JobID != "" {
+		job, err := j.storageImplementation.GetJob(task.JobID)
+		if err != nil {
+			return nil, err
+		}
+		value, found = job.Data[fanOut.FanOut.ListKey]
+	}
+
+	for i := 0; i < len(units); i++ {
+		for k := 0; k < len(fanOut.DependsOnIDs); k++ {
+			if units[i].Key != fanOut.DependsOnIDs[k] {
+				continue
+			}
+			if output, ok := units[i].State().Data[fanOut.FanOut.ListKey]; ok {
+				value, found = output, true
+			}
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: list %q not found for unit %v", types.ErrFanOutInvalid, fanOut.FanOut.ListKey, fanOut.Key)
+	}
+	return types.ParseFanOutList(value)
+}
+
+// The strictest fan-in decides of the quorum
+func (j *Junjoold) updateFanOut(fanOut types.TaskUnit, units []types.TaskUnit) (bool, error) {
+	children := []types.TaskUnit{}
+	quorum, all := 0, false
+	for i := 0; i < len(units); i++ {
+		if units[i].ParentID == fanOut.Key {
+			children = append(children, units[i])
+		}
+		if units[i].FanIn != nil && units[i].FanIn.FanOutID == fanOut.Key {
+			all = all || units[i].FanIn.Quorum == 0
+			if units[i].FanIn.Quorum > quorum {
+				quorum = units[i].FanIn.Quorum
+			}
+		}
+	}
+	if all {
+		quorum = 0
+	}
+
+	status := types.FanOutStatus(children, quorum)
+	if status == fanOut.Status {
+		return false, nil
+	}
+	var err error
+	if status == types.ErrorStatus {
+		err = fmt.Errorf("%w: not enough children succeeded", types.ErrFanOutInvalid)
+	}
+	return true, j.storageImplementation.UpdateTaskUnitStatus(fanOut.Key, status, err)
+}
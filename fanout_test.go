@@ -0,0 +1,134 @@
+package junjo
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestFanOut$ .
+func TestFanOut(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var lab *types.Topic
+	if lab, err = jj.CreateTopic("Lab"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var firmware *types.Owner
+	if firmware, err = jj.CreateOwner("Firmware"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var inventory, flashing, report *types.TaskDefinition
+	if inventory, err = jj.CreateTaskDefinition("inventory", firmware.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if flashing, err = jj.CreateTaskDefinition("flashing", firmware.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if report, err = jj.CreateTaskDefinition("report", firmware.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	listing := unitDag.AddTaskDefinition(inventory)()
+	machines := unitDag.AddFanOut(flashing, "machines", "machine")
+	unitDag.Connect(listing, machines)
+	summary := unitDag.AddFanIn(report, machines, 2)
+
+	var job *types.Job
+	if job, err = jj.LaunchJob(lab.Key, unitDag, types.WithJobData(map[string]string{"machines": "m1,m2"})); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = offered(jj, firmware.Key, 1); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the output of the inventory replaces the job data
+	if err = jj.SubmitCommand(listing.(*types.NodeTaskUnit).Unit.Key, types.Command{Type: types.SuccessCmd, Data: map[string]string{"machines": `["a","b","c"]`}}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = offered(jj, firmware.Key, 3); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(machines.(*types.NodeTaskUnit).Unit.Key, types.Command{Type: types.ProgressCmd}); !errors.Is(err, types.ErrTaskUnitNotAvailable) {
+		t.Errorf("expected %v, got %v", types.ErrTaskUnitNotAvailable, err)
+		return
+	}
+
+	var units []types.TaskUnit
+	if units, err = jj.GetTaskUnits(job.TaskIDs[0]); err != nil {
+		t.Error(err)
+		return
+	}
+	children := map[string]types.TaskUnitID{}
+	items := []string{}
+	for i := 0; i < len(units); i++ {
+		if units[i].ParentID == machines.(*types.NodeTaskUnit).Unit.Key {
+			children[units[i].Data["machine"]] = units[i].Key
+			items = append(items, units[i].Data["machine"])
+		}
+	}
+	sort.Strings(items)
+	if strings.Join(items, ",") != "a,b,c" {
+		t.Errorf("expected children a,b,c, got %v", items)
+		return
+	}
+
+	// one failure still leaves the quorum of 2 reachable
+	if err = jj.SubmitCommand(children["a"], types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(children["b"], types.Command{Type: types.ErrorCmd, Details: "unreachable"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = offered(jj, firmware.Key, 1); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(children["c"], types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(summary.(*types.NodeTaskUnit).Unit.Key, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if job, err = jj.GetJob(job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.Status != types.SuccessStatus {
+		t.Errorf("expected the job to succeed, got %v", job.Status)
+		return
+	}
+
+	// no list, no children
+	unitDag = jj.CreateDagTaskUnits()
+	unitDag.AddFanOut(flashing, "machines", "")
+	if job, err = jj.LaunchJob(lab.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.Status != types.ErrorStatus {
+		t.Errorf("expected the job without list to fail, got %v", job.Status)
+		return
+	}
+}
@@ -0,0 +1,140 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestCreateJobGraph$ .
+func TestCreateJobGraph(t *testing.T) {
+	storage := memory.NewMemoryStorage()
+	var err error
+	jj := NewJ(storage)
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	unitDag.ConnectDef(unitDag.AddTaskDefinition(provisioning), unitDag.AddTaskDefinition(provisioning))
+
+	// unknown topic, nothing should be left behind
+	if _, err = jj.LaunchJob("nope", unitDag); !errors.Is(err, types.ErrJobGraphInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrJobGraphInvalid, err)
+		return
+	}
+	if err = noDrafts(storage); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var job *types.Job
+	if job, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// same units a second time, the second job must fail entirely
+	if _, err = jj.LaunchJob(topic.Key, unitDag); !errors.Is(err, types.ErrJobGraphInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrJobGraphInvalid, err)
+		return
+	}
+	if err = noDrafts(storage); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var jobs []types.Job
+	if jobs, err = jj.GetJobs(topic.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(jobs) != 1 || jobs[0].Key != job.Key {
+		t.Errorf("expected only job %v, got %v", job.Key, jobs)
+		return
+	}
+
+	var inbox []types.InboxTopicTaskUnit
+	if inbox, err = jj.GetInboxTopic(btl.Key, topic.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox) != 1 || len(inbox[0].TaskUnits) != 1 {
+		t.Errorf("expected the first unit only, got %v", inbox)
+		return
+	}
+
+	// a draft task, without job, isn't offered and doesn't break the inbox
+	draftDag := jj.CreateDagTaskUnits()
+	draftDag.AddTaskDefinition(provisioning)()
+	var units []*types.TaskUnit
+	if units, err = draftDag.ToTaskUnits(); err != nil {
+		t.Error(err)
+		return
+	}
+	var ids []types.TaskUnitID
+	if ids, err = jj.CreateTaskUnits(units); err != nil {
+		t.Error(err)
+		return
+	}
+	var task *types.Task
+	if task, err = jj.CreateTask(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.AssignTaskUnits(task.Key, ids); err != nil {
+		t.Error(err)
+		return
+	}
+	var all []types.InboxAllTaskUnit
+	if all, err = jj.GetInbox(btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(all) != 1 || all[0].TaskID == task.Key {
+		t.Errorf("expected the unit of the job only, got %v", all)
+		return
+	}
+
+	// units depending on each other
+	cyclicDag := jj.CreateDagTaskUnits()
+	cyclicDag.ConnectDef(cyclicDag.AddTaskDefinition(provisioning), cyclicDag.AddTaskDefinition(provisioning))
+	if units, err = cyclicDag.ToTaskUnits(); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < len(units); i++ {
+		units[i].DependsOnIDs = []types.TaskUnitID{units[(i+1)%len(units)].Key}
+	}
+	if _, err = storage.CreateJobGraph(topic.Key, [][]*types.TaskUnit{units}); !errors.Is(err, types.ErrJobGraphInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrJobGraphInvalid, err)
+		return
+	}
+}
+
+func noDrafts(storage *memory.MemoryStorage) error {
+	jobs, _ := storage.GetDraftJobs()
+	tasks, _ := storage.GetDraftTasks()
+	units, _ := storage.GetDraftTaskUnits()
+	if len(jobs)+len(tasks)+len(units) != 0 {
+		return errors.New("drafts left behind")
+	}
+	return nil
+}
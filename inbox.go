@@ -0,0 +1,170 @@
+package junjo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Workers don't have to poll their inbox, `WaitInbox` blocks until there is work for them
+///
+///	inbox, err := jj.WaitInbox(ctx, owner.Key, types.WithQueryTopic(topic.Key))
+///
+/// A change of a `Task` only wakes the owners of its units and the members of their pools, `InboxChanged` gives the channel to wait on
+/// The changes made by another process on the same storage are not seen, the waiters check again every `InboxRecheck`
+
+// Longest wait before checking an inbox again, it catches the aging and the changes of the other processes
+const InboxRecheck = 30 * time.Second
+
+// One channel per waited owner, closed on the next change of its inbox
+type inboxNotifier struct {
+	mu      sync.Mutex
+	waiters map[types.OwnerID]chan struct{}
+}
+
+func newInboxNotifier() *inboxNotifier {
+	return &inboxNotifier{
+		waiters: map[types.OwnerID]chan struct{}{},
+	}
+}
+
+func (n *inboxNotifier) changed(ownerID types.OwnerID) <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ch, ok := n.waiters[ownerID]
+	if !ok {
+		ch = make(chan struct{})
+		n.waiters[ownerID] = ch
+	}
+	return ch
+}
+
+func (n *inboxNotifier) notify(ownerIDs ...types.OwnerID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := 0; i < len(ownerIDs); i++ {
+		if ch, ok := n.waiters[ownerIDs[i]]; ok {
+			close(ch)
+			delete(n.waiters, ownerIDs[i])
+		}
+	}
+}
+
+func (n *inboxNotifier) notifyAll() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ownerID, ch := range n.waiters {
+		close(ch)
+		delete(n.waiters, ownerID)
+	}
+}
+
+// Closed on the next change of the inbox of the owner, ask again for the change after
+// Get the channel before reading the inbox so a change in between is not missed
+func (j *Junjoold) InboxChanged(ownerID types.OwnerID) <-chan struct{} {
+	return j.inbox.changed(ownerID)
+}
+
+// Return the inbox of the owner as soon as it's not empty, or the error of the context when it's done first
+// The configs are the same as `GetInbox`, like `types.WithQueryTopic`
+func (j *Junjoold) WaitInbox(ctx context.Context, ownerID types.OwnerID, cfgs ...types.QueryConfig) ([]types.InboxAllTaskUnit, error) {
+	params := types.NewQuery(cfgs...)
+	var topicID types.TopicID
+	if params.Topic != nil {
+		topicID = *params.Topic
+	}
+	// checked once, not on each wake up
+	if err := j.authorizeInbox(ownerID, topicID); err != nil {
+		return nil, err
+	}
+	for {
+		changed := j.InboxChanged(ownerID)
+		inbox, err := j.getInbox(ownerID, params)
+		if err != nil || len(inbox) > 0 {
+			return inbox, err
+		}
+		recheck := time.NewTimer(InboxRecheck)
+		select {
+		case <-ctx.Done():
+			recheck.Stop()
+			return nil, ctx.Err()
+		case <-changed:
+		case <-recheck.C:
+		}
+		recheck.Stop()
+	}
+}
+
+// Wake the owners and the approvers of the units of the task, a failure only delays them until their next check
+// The webhooks hear of the same changes
+func (j *Junjoold) notifyTask(taskID types.TaskID) {
+	j.announceTask(taskID)
+	units, err := j.storageImplementation.GetTaskUnits(taskID)
+	if err != nil {
+		return
+	}
+	owners := []types.OwnerID{}
+	seen := map[types.OwnerID]bool{}
+	for i := 0; i < len(units); i++ {
+		definition, err := j.unitDefinition(&units[i])
+		if err != nil {
+			continue
+		}
+		// the approvers review the gates
+		ownerIDs := []types.OwnerID{units[i].Owner(definition)}
+		if definition.Approval != nil {
+			ownerIDs = append(ownerIDs, definition.Approval.Approvers...)
+		}
+		for _, ownerID := range ownerIDs {
+			if seen[ownerID] {
+				continue
+			}
+			seen[ownerID] = true
+			members, err := j.poolMembers(ownerID)
+			if err != nil {
+				continue
+			}
+			owners = append(owners, members...)
+		}
+	}
+	j.inbox.notify(owners...)
+}
+
+func (j *Junjoold) notifyJob(jobID types.JobID) {
+	tasks, err := j.storageImplementation.GetTasks(jobID)
+	if err != nil {
+		return
+	}
+	for i := 0; i < len(tasks); i++ {
+		j.notifyTask(tasks[i].Key)
+	}
+}
+
+func (j *Junjoold) notifyDependents(jobID types.JobID) {
+	dependents, err := j.storageImplementation.GetDependentJobs(jobID)
+	if err != nil {
+		return
+	}
+	for i := 0; i < len(dependents); i++ {
+		j.notifyJob(dependents[i].Key)
+	}
+}
+
+// A new limit can free slots of the owner
+func (j *Junjoold) notifyLimit(limit types.ConcurrencyLimit) {
+	ownerID := limit.OwnerID
+	if ownerID == "" {
+		definition, err := j.storageImplementation.GetTaskDefinition(limit.TaskDefinitionID)
+		if err != nil {
+			return
+		}
+		ownerID = definition.OwnerID
+	}
+	members, err := j.poolMembers(ownerID)
+	if err != nil {
+		return
+	}
+	j.inbox.notify(members...)
+}
@@ -0,0 +1,117 @@
+package junjo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestWaitInbox$ .
+func TestWaitInbox(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var lab, datacenter *types.Topic
+	if lab, err = jj.CreateTopic("Lab"); err != nil {
+		t.Error(err)
+		return
+	}
+	if datacenter, err = jj.CreateTopic("Datacenter"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var firmware, network *types.Owner
+	if firmware, err = jj.CreateOwner("Firmware"); err != nil {
+		t.Error(err)
+		return
+	}
+	if network, err = jj.CreateOwner("Network"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var flashing, configure *types.TaskDefinition
+	if flashing, err = jj.CreateTaskDefinition("flashing", firmware.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if configure, err = jj.CreateTaskDefinition("configure", network.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	first, second := unitDag.ConnectDef(unitDag.AddTaskDefinition(flashing), unitDag.AddTaskDefinition(configure))
+	if _, err = jj.LaunchJob(lab.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	type result struct {
+		inbox []types.InboxAllTaskUnit
+		err   error
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	waited := make(chan result, 1)
+	go func() {
+		inbox, err := jj.WaitInbox(ctx, network.Key)
+		waited <- result{inbox, err}
+	}()
+
+	select {
+	case value := <-waited:
+		t.Errorf("expected the network to wait for the flashing, got %v %v", value.inbox, value.err)
+		return
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err = jj.SubmitCommand(first.(*types.NodeTaskUnit).Unit.Key, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	value := <-waited
+	if value.err != nil {
+		t.Error(value.err)
+		return
+	}
+	if len(value.inbox) != 1 || len(value.inbox[0].TaskUnits) != 1 || value.inbox[0].TaskUnits[0].Key != second.(*types.NodeTaskUnit).Unit.Key {
+		t.Errorf("expected the configure unit, got %v", value.inbox)
+		return
+	}
+
+	// only the owners of the task are woken
+	firmwareChanged := jj.InboxChanged(firmware.Key)
+	networkChanged := jj.InboxChanged(network.Key)
+	unitDag = jj.CreateDagTaskUnits()
+	unitDag.AddTaskDefinition(configure)()
+	if _, err = jj.LaunchJob(lab.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	select {
+	case <-networkChanged:
+	default:
+		t.Error("expected the network inbox to change")
+		return
+	}
+	select {
+	case <-firmwareChanged:
+		t.Error("expected the firmware inbox to be left alone")
+		return
+	default:
+	}
+
+	// nothing for the network in the datacenter
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+	if _, err = jj.WaitInbox(short, network.Key, types.WithQueryTopic(datacenter.Key)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+		return
+	}
+}
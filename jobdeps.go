@@ -0,0 +1,116 @@
+package junjo
+
+import (
+	"errors"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Jobs can wait for other jobs, even from other topics
+///
+///	deploy, _ := jj.LaunchJob(topic.Key, dag, types.WithJobDependsOn(build.Key), types.WithJobUpstreamPolicy(types.UpstreamCancel))
+///
+/// The units of `deploy` are not offered by any inbox until `build` succeeds
+/// `build` can't be deleted while `deploy` depends on it, an upstream job missing anyway counts as failed
+
+// Make an existing job wait for more upstream jobs, refused with `types.ErrJobDependencyInvalid` when they would create a cycle
+func (j *Junjoold) AddJobDependencies(jobID types.JobID, upstreamIDs ...types.JobID) error {
+	if err := j.authorizeJob(types.ActionJobCancel, jobID); err != nil {
+		return err
+	}
+	if err := j.storageImplementation.AddJobDependencies(jobID, upstreamIDs); err != nil {
+		return err
+	}
+	return j.applyUpstreamPolicy(jobID)
+}
+
+func (j *Junjoold) GetDependentJobs(jobID types.JobID) ([]types.Job, error) {
+	if err := j.authorizeJob(types.ActionJobRead, jobID); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetDependentJobs(jobID)
+}
+
+// Whether one of the upstream jobs is not done yet
+func (j *Junjoold) jobWaiting(jobID types.JobID) (bool, error) {
+	job, err := j.storageImplementation.GetJob(jobID)
+	if err != nil {
+		return false, err
+	}
+	for i := 0; i < len(job.DependsOn); i++ {
+		status, err := j.upstreamStatus(job.DependsOn[i])
+		if err != nil {
+			return false, err
+		}
+		if !types.UpstreamDone(job.Upstream, status) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Status of an upstream job, a missing one counts as failed so the upstream policy of the job decides
+func (j *Junjoold) upstreamStatus(jobID types.JobID) (types.StatusType, error) {
+	upstream, err := j.storageImplementation.GetJob(jobID)
+	if errors.Is(err, types.ErrJobNotFound) {
+		return types.ErrorStatus, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return upstream.Status, nil
+}
+
+// A job with `UpstreamCancel` is cancelled right away when one of its upstream jobs already failed
+func (j *Junjoold) applyUpstreamPolicy(jobID types.JobID) error {
+	job, err := j.storageImplementation.GetJob(jobID)
+	if err != nil {
+		return err
+	}
+	if job.Upstream != types.UpstreamCancel || job.Status == types.ErrorStatus {
+		return nil
+	}
+	for i := 0; i < len(job.DependsOn); i++ {
+		status, err := j.upstreamStatus(job.DependsOn[i])
+		if err != nil {
+			return err
+		}
+		if status == types.ErrorStatus {
+			return j.cancelJob(jobID)
+		}
+	}
+	return nil
+}
+
+// Cancel the jobs waiting with `UpstreamCancel` on a failed job, and the jobs waiting on them
+// The jobs released by a finished job have new available units
+func (j *Junjoold) markDependentsAvailable(jobID types.JobID) error {
+	dependents, err := j.storageImplementation.GetDependentJobs(jobID)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(dependents); i++ {
+		for k := 0; k < len(dependents[i].TaskIDs); k++ {
+			if err = j.markAvailable(dependents[i].TaskIDs[k]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (j *Junjoold) cancelDependents(jobID types.JobID) error {
+	dependents, err := j.storageImplementation.GetDependentJobs(jobID)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(dependents); i++ {
+		if dependents[i].Upstream != types.UpstreamCancel || dependents[i].Status == types.ErrorStatus || dependents[i].Status == types.SuccessStatus {
+			continue
+		}
+		if err = j.cancelJob(dependents[i].Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
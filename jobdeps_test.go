@@ -0,0 +1,136 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestJobDependencies$ .
+func TestJobDependencies(t *testing.T) {
+	var err error
+	storage := memory.NewMemoryStorage()
+	jj := NewJ(storage)
+
+	var lab, datacenter *types.Topic
+	if lab, err = jj.CreateTopic("Lab"); err != nil {
+		t.Error(err)
+		return
+	}
+	if datacenter, err = jj.CreateTopic("Datacenter"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var firmware *types.Owner
+	if firmware, err = jj.CreateOwner("Firmware"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var flashing *types.TaskDefinition
+	if flashing, err = jj.CreateTaskDefinition("flashing", firmware.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	units := map[types.JobID]types.TaskUnitID{}
+	launch := func(topicID types.TopicID, cfgs ...types.JobConfig) (*types.Job, error) {
+		unitDag := jj.CreateDagTaskUnits()
+		vertex := unitDag.AddTaskDefinition(flashing)()
+		job, err := jj.LaunchJob(topicID, unitDag, cfgs...)
+		if err != nil {
+			return nil, err
+		}
+		units[job.Key] = vertex.(*types.NodeTaskUnit).Unit.Key
+		return job, nil
+	}
+
+	var build, deploy *types.Job
+	if build, err = launch(lab.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if deploy, err = launch(datacenter.Key, types.WithJobDependsOn(build.Key)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err = offered(jj, firmware.Key, 1); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(units[deploy.Key], types.Command{Type: types.ProgressCmd}); !errors.Is(err, types.ErrTaskUnitNotAvailable) {
+		t.Errorf("expected %v, got %v", types.ErrTaskUnitNotAvailable, err)
+		return
+	}
+
+	if err = jj.AddJobDependencies(build.Key, deploy.Key); !errors.Is(err, types.ErrJobDependencyInvalid) {
+		t.Errorf("expected a cycle to be refused, got %v", err)
+		return
+	}
+	if _, err = launch(lab.Key, types.WithJobDependsOn("unknown")); !errors.Is(err, types.ErrJobDependencyInvalid) {
+		t.Errorf("expected an unknown upstream job to be refused, got %v", err)
+		return
+	}
+
+	if err = jj.SubmitCommand(units[build.Key], types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = offered(jj, firmware.Key, 1); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(units[deploy.Key], types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// a failure cancels the chain of `UpstreamCancel` and lets `UpstreamIgnore` go
+	var flash, verify, release, report *types.Job
+	if flash, err = launch(lab.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if verify, err = launch(lab.Key, types.WithJobDependsOn(flash.Key), types.WithJobUpstreamPolicy(types.UpstreamCancel)); err != nil {
+		t.Error(err)
+		return
+	}
+	if release, err = launch(datacenter.Key, types.WithJobDependsOn(verify.Key), types.WithJobUpstreamPolicy(types.UpstreamCancel)); err != nil {
+		t.Error(err)
+		return
+	}
+	if report, err = launch(lab.Key, types.WithJobDependsOn(flash.Key), types.WithJobUpstreamPolicy(types.UpstreamIgnore)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err = jj.SubmitCommand(units[flash.Key], types.Command{Type: types.ErrorCmd, Details: "bricked"}); err != nil {
+		t.Error(err)
+		return
+	}
+	for _, jobID := range []types.JobID{verify.Key, release.Key} {
+		var job *types.Job
+		if job, err = jj.GetJob(jobID); err != nil {
+			t.Error(err)
+			return
+		}
+		if job.Status != types.ErrorStatus {
+			t.Errorf("expected job %v cancelled, got %v", jobID, job.Status)
+			return
+		}
+	}
+	if err = jj.SubmitCommand(units[report.Key], types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the jobs depending on it keep it
+	if err = storage.DeleteJob(flash.Key); !errors.Is(err, types.ErrJobDependencyInvalid) {
+		t.Errorf("expected the deletion of an upstream job refused, got %v", err)
+		return
+	}
+}
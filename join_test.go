@@ -0,0 +1,127 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/dag"
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestJoin$ .
+func TestJoin(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var monitoring *types.Topic
+	if monitoring, err = jj.CreateTopic("Monitoring"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var sre *types.Owner
+	if sre, err = jj.CreateOwner("SRE"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var probe, report *types.TaskDefinition
+	if probe, err = jj.CreateTaskDefinition("probe", sre.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if report, err = jj.CreateTaskDefinition("report", sre.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// three datacenters probe the same service
+	build := func(join *types.Join) (*types.WorkUnitDag, []types.TaskUnitID, types.TaskUnitID) {
+		unitDag := jj.CreateDagTaskUnits()
+		probes := []dag.Vertex{}
+		keys := []types.TaskUnitID{}
+		for i := 0; i < 3; i++ {
+			vertex := unitDag.AddTaskDefinition(probe)()
+			probes = append(probes, vertex)
+			keys = append(keys, vertex.(*types.NodeTaskUnit).Unit.Key)
+		}
+		summary := unitDag.MConnectDef(unitDag.AddTaskDefinition(report), probes...)
+		summary.(*types.NodeTaskUnit).Unit.Join = join
+		return unitDag, keys, summary.(*types.NodeTaskUnit).Unit.Key
+	}
+
+	unitDag, probes, summary := build(types.AnyOf())
+	var job *types.Job
+	if job, err = jj.LaunchJob(monitoring.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		if err = jj.SubmitCommand(probes[i], types.Command{Type: types.ErrorCmd, Details: "timeout"}); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if err = jj.SubmitCommand(summary, types.Command{Type: types.ProgressCmd}); !errors.Is(err, types.ErrTaskUnitNotAvailable) {
+		t.Errorf("expected %v, got %v", types.ErrTaskUnitNotAvailable, err)
+		return
+	}
+	if err = jj.SubmitCommand(probes[2], types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = offered(jj, sre.Key, 1); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(summary, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if job, err = jj.GetJob(job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.Status != types.SuccessStatus {
+		t.Errorf("expected one probe to be enough, got %v", job.Status)
+		return
+	}
+
+	// two failures out of three can't make two successes
+	unitDag, probes, _ = build(types.AtLeast(2))
+	if job, err = jj.LaunchJob(monitoring.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(probes[0], types.Command{Type: types.ErrorCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if job, err = jj.GetJob(job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.Status != types.ProgressStatus {
+		t.Errorf("expected the job to go on after one failure, got %v", job.Status)
+		return
+	}
+	if err = jj.SubmitCommand(probes[1], types.Command{Type: types.ErrorCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if job, err = jj.GetJob(job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.Status != types.ErrorStatus {
+		t.Errorf("expected the job to fail, got %v", job.Status)
+		return
+	}
+
+	unitDag, _, _ = build(types.AtLeast(4))
+	if _, err = jj.LaunchJob(monitoring.Key, unitDag); !errors.Is(err, types.ErrJobGraphInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrJobGraphInvalid, err)
+		return
+	}
+}
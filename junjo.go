@@ -1,6 +1,10 @@
 package junjo
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/davidroman0O/junjo/types"
 )
 
@@ -21,24 +25,38 @@ import (
 // Most of the API leverage the storage implemenation, except few exeptions.
 type Junjoold struct {
 	storageImplementation types.StorageInterface
+	inbox                 *inboxNotifier
+	// nil for the system, see `As`
+	principal *types.Principal
 }
 
 // New `Junjo` Api
 func NewJ(implt types.StorageInterface) *Junjoold {
 	return &Junjoold{
 		storageImplementation: implt,
+		inbox:                 newInboxNotifier(),
 	}
 }
 
 // Create a new `Topic`
 func (j *Junjoold) CreateTopic(name string, cfgs ...types.TopicConfig) (*types.Topic, error) {
+	if err := j.authorize(types.ActionTopicCreate, types.Scope{}, name); err != nil {
+		return nil, err
+	}
 	return j.
 		storageImplementation.
 		CreateTopic(name, cfgs...)
 }
 
-// Create a new `Owner`
+// Create a new `Owner`, a pool with `types.WithOwnerMembers`
 func (j *Junjoold) CreateOwner(name string, cfgs ...types.OwnerConfig) (*types.Owner, error) {
+	if err := j.authorize(types.ActionOwnerManage, types.Scope{}, name); err != nil {
+		return nil, err
+	}
+	value := types.NewOwner("", name, cfgs...)
+	if err := j.validateMembers("", value.Members); err != nil {
+		return nil, err
+	}
 	return j.
 		storageImplementation.
 		CreateOwner(name, cfgs...)
@@ -46,6 +64,13 @@ func (j *Junjoold) CreateOwner(name string, cfgs ...types.OwnerConfig) (*types.O
 
 // Create a new `TaskDefinition` for a `Owner`
 func (j *Junjoold) CreateTaskDefinition(name string, ownerID types.OwnerID, cfgs ...types.TaskDefinitionConfig) (*types.TaskDefinition, error) {
+	if err := j.authorize(types.ActionDefinitionManage, types.Scope{}, name); err != nil {
+		return nil, err
+	}
+	value := types.NewUnitDescription("", name, ownerID, cfgs...)
+	if err := j.validateApproval(value.Approval); err != nil {
+		return nil, err
+	}
 	return j.
 		storageImplementation.
 		CreateTaskDefinition(name, ownerID, cfgs...)
@@ -60,6 +85,9 @@ func (j *Junjoold) CreateDagTaskUnits() *types.WorkUnitDag {
 // Create new `Task` with it's TaskUnit, probably from a WorkUnitDag
 // By default that task as `Status == none` with no JobID
 func (j *Junjoold) CreateTask(cfgs ...types.TaskConfig) (*types.Task, error) {
+	if err := j.authorize(types.ActionJobLaunch, types.Scope{}, "draft task"); err != nil {
+		return nil, err
+	}
 	return j.
 		storageImplementation.
 		CreateTask(cfgs...)
@@ -68,6 +96,9 @@ func (j *Junjoold) CreateTask(cfgs ...types.TaskConfig) (*types.Task, error) {
 // Create new `Job` with it's Tasks
 // By default that `Job` will have `Status == none` with no TopicID AND no initial `Data`
 func (j *Junjoold) CreateJob(cfgs ...types.JobConfig) (*types.Job, error) {
+	if err := j.authorize(types.ActionJobLaunch, types.Scope{}, "draft job"); err != nil {
+		return nil, err
+	}
 	return j.
 		storageImplementation.
 		CreateJob(cfgs...)
@@ -76,6 +107,9 @@ func (j *Junjoold) CreateJob(cfgs ...types.JobConfig) (*types.Job, error) {
 // Create new `TaskUnit` as an array
 // By default those `TaskUnit` has `Status == none` with not JobID
 func (j *Junjoold) CreateTaskUnits(units []*types.TaskUnit) ([]types.TaskUnitID, error) {
+	if err := j.authorize(types.ActionJobLaunch, types.Scope{}, "draft units"); err != nil {
+		return nil, err
+	}
 	return j.
 		storageImplementation.
 		CreateTaskUnits(units)
@@ -84,23 +118,520 @@ func (j *Junjoold) CreateTaskUnits(units []*types.TaskUnit) ([]types.TaskUnitID,
 // Assign a drafted `Job` to a `Topic` for processing
 // Consider every orphan `Job` as a draft (that you might take in charge for deletion)
 func (j *Junjoold) AssignJob(topicID types.TopicID, jobID types.JobID) error {
-	return j.storageImplementation.AssignJob(topicID, jobID)
+	if err := j.authorizeTopic(types.ActionJobLaunch, topicID); err != nil {
+		return err
+	}
+	if err := j.storageImplementation.AssignJob(topicID, jobID); err != nil {
+		return err
+	}
+	j.notifyJob(jobID)
+	return nil
 }
 
 // Assign a drafted `Task` to a `Job`
 // Consider every orphan `Task` as a draft (that you might take in charge for deletion)
 func (j *Junjoold) AssignTask(jobID types.JobID, taskID types.TaskID) error {
-	return j.storageImplementation.AssignTask(jobID, taskID)
+	if err := j.authorizeJob(types.ActionJobLaunch, jobID); err != nil {
+		return err
+	}
+	if err := j.storageImplementation.AssignTask(jobID, taskID); err != nil {
+		return err
+	}
+	j.notifyTask(taskID)
+	return nil
 }
 
 // Assign a drafted `TaskUnits` to a `Task`
 // Consider every orphan `TaskUnit` as a draft (that you might take in charge for deletion)
 func (j *Junjoold) AssignTaskUnits(taskID types.TaskID, ids []types.TaskUnitID) error {
-	return j.storageImplementation.AssignTaskUnits(taskID, ids)
+	if err := j.authorizeTask(types.ActionJobLaunch, taskID); err != nil {
+		return err
+	}
+	if err := j.storageImplementation.AssignTaskUnits(taskID, ids); err != nil {
+		return err
+	}
+	j.notifyTask(taskID)
+	return nil
 }
 
 // Workers/Owners will only see the tasks their need to accomplish
 func (j *Junjoold) GetInbox(ownerID types.OwnerID, cfgs ...types.QueryConfig) ([]types.InboxAllTaskUnit, error) {
 	params := types.NewQuery(cfgs...)
-	return j.storageImplementation.GetInbox(ownerID, params)
+	var topicID types.TopicID
+	if params.Topic != nil {
+		topicID = *params.Topic
+	}
+	if err := j.authorizeInbox(ownerID, topicID); err != nil {
+		return nil, err
+	}
+	return j.getInbox(ownerID, params)
+}
+
+func (j *Junjoold) getInbox(ownerID types.OwnerID, params *types.QueryParams) ([]types.InboxAllTaskUnit, error) {
+	if params.Topic == nil {
+		return j.storageImplementation.GetInbox(ownerID, params)
+	}
+	inbox, err := j.storageImplementation.GetInboxTopic(ownerID, *params.Topic, params)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]types.InboxAllTaskUnit, 0, len(inbox))
+	for i := 0; i < len(inbox); i++ {
+		values = append(values, types.InboxAllTaskUnit{
+			TopicID:   *params.Topic,
+			JobID:     inbox[i].JobID,
+			TaskID:    inbox[i].TaskID,
+			TaskUnits: inbox[i].TaskUnits,
+			Priority:  inbox[i].Priority,
+		})
+	}
+	return values, nil
+}
+
+// Rebuild the `WorkUnitDag` of a stored `Task` with its current statuses
+func (j *Junjoold) taskDag(taskID types.TaskID) (*types.WorkUnitDag, error) {
+	units, err := j.storageImplementation.GetTaskUnits(taskID)
+	if err != nil {
+		return nil, err
+	}
+	definitions, err := j.unitDefinitions(units)
+	if err != nil {
+		return nil, err
+	}
+	return types.CreateDagFromTaskUnits(j.storageImplementation, units, definitions)
+}
+
+// Render the DAG of a `Task` as Graphviz DOT, nodes are labeled with their `TaskDefinition` and `Owner` and colored by status
+func (j *Junjoold) RenderTaskDOT(taskID types.TaskID) ([]byte, error) {
+	if err := j.authorizeTask(types.ActionJobRead, taskID); err != nil {
+		return nil, err
+	}
+	owners, err := j.storageImplementation.GetOwners()
+	if err != nil {
+		return nil, err
+	}
+	workUnitDag, err := j.taskDag(taskID)
+	if err != nil {
+		return nil, err
+	}
+	return workUnitDag.Dot(owners...), nil
+}
+
+// Render all the DAGs of a `Job` as Graphviz DOT with one cluster per `Task`
+func (j *Junjoold) RenderJobDOT(jobID types.JobID) ([]byte, error) {
+	if err := j.authorizeJob(types.ActionJobRead, jobID); err != nil {
+		return nil, err
+	}
+	owners, err := j.storageImplementation.GetOwners()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := j.storageImplementation.GetTasks(jobID)
+	if err != nil {
+		return nil, err
+	}
+	dags := map[types.TaskID]*types.WorkUnitDag{}
+	for i := 0; i < len(tasks); i++ {
+		if dags[tasks[i].Key], err = j.taskDag(tasks[i].Key); err != nil {
+			return nil, err
+		}
+	}
+	return types.JobDot(dags, owners...), nil
+}
+
+// Workers/Owners will only see the tasks their need to accomplish on one particular `Topic`
+func (j *Junjoold) GetInboxTopic(ownerID types.OwnerID, topicID types.TopicID, cfgs ...types.QueryConfig) ([]types.InboxTopicTaskUnit, error) {
+	if err := j.authorizeInbox(ownerID, topicID); err != nil {
+		return nil, err
+	}
+	params := types.NewQuery(cfgs...)
+	return j.storageImplementation.GetInboxTopic(ownerID, topicID, params)
+}
+
+func (j *Junjoold) GetTopics() ([]types.Topic, error) {
+	return j.storageImplementation.GetTopics()
+}
+
+func (j *Junjoold) GetTopic(id types.TopicID) (*types.Topic, error) {
+	return j.storageImplementation.GetTopic(id)
+}
+
+func (j *Junjoold) UpdateTopic(id types.TopicID, name string) (*types.Topic, error) {
+	if err := j.authorizeTopic(types.ActionTopicManage, id); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.UpdateTopic(id, name)
+}
+
+func (j *Junjoold) DeprecateTopic(id types.TopicID) error {
+	if err := j.authorizeTopic(types.ActionTopicManage, id); err != nil {
+		return err
+	}
+	return j.storageImplementation.DeprecateTopic(id)
+}
+
+func (j *Junjoold) GetOwners() ([]types.Owner, error) {
+	return j.storageImplementation.GetOwners()
+}
+
+func (j *Junjoold) GetOwner(ownerID types.OwnerID) (*types.Owner, error) {
+	return j.storageImplementation.GetOwner(ownerID)
+}
+
+func (j *Junjoold) UpdateOwner(ownerID types.OwnerID, name string) (*types.Owner, error) {
+	if err := j.authorize(types.ActionOwnerManage, types.Scope{}, string(ownerID)); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.UpdateOwner(ownerID, name)
+}
+
+func (j *Junjoold) DeprecateOwner(ownerID types.OwnerID) (*types.Owner, error) {
+	if err := j.authorize(types.ActionOwnerManage, types.Scope{}, string(ownerID)); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.DeprecateOwner(ownerID)
+}
+
+func (j *Junjoold) GetTaskDefinitions() ([]types.TaskDefinition, error) {
+	return j.storageImplementation.GetTaskDefinitions()
+}
+
+func (j *Junjoold) GetTaskDefinition(id types.TaskDefinitionID) (*types.TaskDefinition, error) {
+	return j.storageImplementation.GetTaskDefinition(id)
+}
+
+// Saves a new version, the units already created keep theirs
+func (j *Junjoold) UpdateTaskDefinition(id types.TaskDefinitionID, ownerID types.OwnerID, name string, description string, identifier string) (*types.TaskDefinition, error) {
+	if err := j.authorize(types.ActionDefinitionManage, types.Scope{}, string(id)); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.UpdateTaskDefinition(id, ownerID, name, description, identifier)
+}
+
+func (j *Junjoold) DeprecateTaskDefinition(id types.TaskDefinitionID) error {
+	if err := j.authorize(types.ActionDefinitionManage, types.Scope{}, string(id)); err != nil {
+		return err
+	}
+	return j.storageImplementation.DeprecateTaskDefinition(id)
+}
+
+func (j *Junjoold) GetJobs(topicID types.TopicID) ([]types.Job, error) {
+	if err := j.authorizeTopic(types.ActionJobRead, topicID); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetJobs(topicID)
+}
+
+func (j *Junjoold) GetJob(jobID types.JobID) (*types.Job, error) {
+	if err := j.authorizeJob(types.ActionJobRead, jobID); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetJob(jobID)
+}
+
+// Cancel the job and the jobs depending on it with `UpstreamCancel`
+func (j *Junjoold) CancelJob(jobID types.JobID) error {
+	if err := j.authorizeJob(types.ActionJobCancel, jobID); err != nil {
+		return err
+	}
+	return j.cancelJob(jobID)
+}
+
+func (j *Junjoold) cancelJob(jobID types.JobID) error {
+	if err := j.storageImplementation.CancelJob(jobID); err != nil {
+		return err
+	}
+	j.notifyJob(jobID)
+	return j.cancelDependents(jobID)
+}
+
+func (j *Junjoold) GetTasks(jobID types.JobID) ([]types.Task, error) {
+	if err := j.authorizeJob(types.ActionJobRead, jobID); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetTasks(jobID)
+}
+
+func (j *Junjoold) GetTask(taskID types.TaskID) (*types.Task, error) {
+	if err := j.authorizeTask(types.ActionJobRead, taskID); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetTask(taskID)
+}
+
+func (j *Junjoold) CancelTask(taskID types.TaskID) error {
+	if err := j.authorizeTask(types.ActionJobCancel, taskID); err != nil {
+		return err
+	}
+	if err := j.storageImplementation.CancelTask(taskID); err != nil {
+		return err
+	}
+	j.notifyTask(taskID)
+	return nil
+}
+
+func (j *Junjoold) GetTaskUnits(taskID types.TaskID) ([]types.TaskUnit, error) {
+	if err := j.authorizeTask(types.ActionJobRead, taskID); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetTaskUnits(taskID)
+}
+
+func (j *Junjoold) GetTaskUnit(taskUnitID types.TaskUnitID) (*types.TaskUnit, error) {
+	if err := j.authorizeUnit(types.ActionJobRead, taskUnitID); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetTaskUnit(taskUnitID)
+}
+
+// Create and assign a whole `Job` with one `Task` built from your `WorkUnitDag`
+// It's the same as calling yourself `CreateTaskUnits`, `CreateTask`, `AssignTaskUnits`, `CreateJob`, `AssignTask` and `AssignJob` except that nothing is created when it fails
+func (j *Junjoold) LaunchJob(topicID types.TopicID, workUnitDag *types.WorkUnitDag, cfgs ...types.JobConfig) (*types.Job, error) {
+	if err := j.authorizeTopic(types.ActionJobLaunch, topicID); err != nil {
+		return nil, err
+	}
+	return j.launchJob(topicID, workUnitDag, cfgs...)
+}
+
+func (j *Junjoold) launchJob(topicID types.TopicID, workUnitDag *types.WorkUnitDag, cfgs ...types.JobConfig) (*types.Job, error) {
+	job, err := j.createJob(topicID, workUnitDag, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	if err = j.startJob(job); err != nil {
+		return nil, errors.Join(err, j.storageImplementation.DeleteJob(job.Key))
+	}
+	return job, nil
+}
+
+// The job and all its units, nothing is offered yet
+func (j *Junjoold) createJob(topicID types.TopicID, workUnitDag *types.WorkUnitDag, cfgs ...types.JobConfig) (*types.Job, error) {
+	units, err := workUnitDag.ToTaskUnits()
+	if err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.CreateJobGraph(topicID, [][]*types.TaskUnit{units}, cfgs...)
+}
+
+// Offer the units of a created job
+func (j *Junjoold) startJob(job *types.Job) error {
+	// fan-outs without dependencies expand right away
+	for i := 0; i < len(job.TaskIDs); i++ {
+		if err := j.rollup(job.TaskIDs[i]); err != nil {
+			return err
+		}
+	}
+	return j.applyUpstreamPolicy(job.Key)
+}
+
+// Owners report their progression on a `TaskUnit` with a `Command`
+// The command is recorded, then the status of the unit change (except for `LogCmd`) and is rolled up to its `Task` and `Job`
+// A unit can't change its status until its dependencies are successful, all of them unless it has a `Join`
+// A `DelegateCmd` hands the unit to another owner, see `ReassignTaskUnit`, `ApproveCmd` and `RejectCmd` review an approval gate
+func (j *Junjoold) SubmitCommand(taskUnitID types.TaskUnitID, cmd types.Command) error {
+	var err error
+
+	switch cmd.Type {
+	case types.DelegateCmd:
+		return j.ReassignTaskUnit(taskUnitID, types.OwnerID(cmd.Data[types.DelegateTo]), cmd.Details)
+	case types.ApproveCmd:
+		return j.Approve(taskUnitID, types.OwnerID(cmd.Data[types.Reviewer]), cmd.Details)
+	case types.RejectCmd:
+		return j.Reject(taskUnitID, types.OwnerID(cmd.Data[types.Reviewer]), cmd.Details)
+	}
+	// the member sending it acts for itself, not for its whole pool
+	var actingAs []types.OwnerID
+	if claimant := types.OwnerID(cmd.Data[types.Claimant]); claimant != "" {
+		actingAs = append(actingAs, claimant)
+	}
+	if err = j.authorizeUnit(types.ActionUnitCommand, taskUnitID, actingAs...); err != nil {
+		return err
+	}
+
+	var unit *types.TaskUnit
+	if unit, err = j.storageImplementation.GetTaskUnit(taskUnitID); err != nil {
+		return err
+	}
+
+	// a claimed unit only takes the commands of its claimer
+	if unit.ClaimedBy != "" && types.OwnerID(cmd.Data[types.Claimant]) != unit.ClaimedBy {
+		return fmt.Errorf("%w: %v", types.ErrTaskUnitClaimed, unit.ClaimedBy)
+	}
+
+	status := cmd.Status
+	if status == "" {
+		status, _ = types.CommandStatus(cmd.Type)
+	}
+	cmd.Status = status
+
+	// a fan-out only moves with its children
+	if unit.FanOut != nil && status != "" {
+		return types.ErrTaskUnitNotAvailable
+	}
+	// and a gate with its reviews
+	if status != "" {
+		var approval *types.Approval
+		if approval, err = j.approvalGate(unit); err != nil {
+			return err
+		}
+		if approval != nil {
+			return types.ErrTaskUnitNotAvailable
+		}
+	}
+
+	if status != "" && status != unit.Status {
+		var available bool
+		if available, err = j.canChangeStatus(unit); err != nil {
+			return err
+		}
+		if !available {
+			return types.ErrTaskUnitNotAvailable
+		}
+	}
+
+	if status == "" || status == unit.Status {
+		return j.storageImplementation.AddTaskUnitCommand(taskUnitID, cmd)
+	}
+
+	// the status first, a unit refused by the concurrency limits doesn't keep the command
+	var unitErr error
+	if status == types.ErrorStatus {
+		unitErr = errors.New(cmd.Details)
+	}
+	if err = j.storageImplementation.UpdateTaskUnitStatus(taskUnitID, status, unitErr); err != nil {
+		return err
+	}
+
+	if err = j.storageImplementation.AddTaskUnitCommand(taskUnitID, cmd); err != nil {
+		return err
+	}
+
+	return j.rollup(unit.TaskID)
+}
+
+// Cap the queued and in progress units of an owner or a definition, see `types.ConcurrencyLimit`
+func (j *Junjoold) SetConcurrencyLimit(limit types.ConcurrencyLimit) error {
+	if err := j.authorize(types.ActionLimitManage, types.Scope{}, ""); err != nil {
+		return err
+	}
+	if err := j.storageImplementation.SetConcurrencyLimit(limit); err != nil {
+		return err
+	}
+	j.notifyLimit(limit)
+	return nil
+}
+
+func (j *Junjoold) GetConcurrencyLimits() ([]types.ConcurrencyLimit, error) {
+	return j.storageImplementation.GetConcurrencyLimits()
+}
+
+// Check with the DAG of the task if the unit can change its status
+func (j *Junjoold) canChangeStatus(unit *types.TaskUnit) (bool, error) {
+	// a draft unit has no dag yet
+	if unit.TaskID == "" {
+		return true, nil
+	}
+	task, err := j.storageImplementation.GetTask(unit.TaskID)
+	if err != nil {
+		return false, err
+	}
+	if task.JobID != "" {
+		var waiting bool
+		if waiting, err = j.jobWaiting(task.JobID); err != nil || waiting {
+			return false, err
+		}
+	}
+	workUnitDag, err := j.taskDag(unit.TaskID)
+	if err != nil {
+		return false, err
+	}
+	for _, vertex := range workUnitDag.Graph().Vertices() {
+		node, ok := vertex.(*types.NodeTaskUnit)
+		if ok && node.Unit.Key == unit.Key {
+			return workUnitDag.CanChangeStatus(vertex)
+		}
+	}
+	return false, fmt.Errorf("task unit %v not found in its task", unit.Key)
+}
+
+// Record when the units of the task became available, the expiry of the approval gates counts from it
+// The drafts and the units of a waiting job are recorded by a later rollup or by `ExpireApprovals`
+func (j *Junjoold) markAvailable(taskID types.TaskID) error {
+	task, err := j.storageImplementation.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+	if task.JobID == "" {
+		return nil
+	}
+	waiting, err := j.jobWaiting(task.JobID)
+	if err != nil || waiting {
+		return err
+	}
+	workUnitDag, err := j.taskDag(taskID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, node := range workUnitDag.AvailableNodeUnit() {
+		if !node.Unit.AvailableAt.IsZero() {
+			continue
+		}
+		if err = j.storageImplementation.MarkTaskUnitAvailable(node.Unit.Key, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Propagate the statuses of the units to their `Task` then to the `Job`
+func (j *Junjoold) rollup(taskID types.TaskID) error {
+	if taskID == "" {
+		return nil
+	}
+
+	units, err := j.settleFanOuts(taskID)
+	if err != nil {
+		return err
+	}
+	if err = j.storageImplementation.UpdateTaskStatus(taskID, types.RollupStatus(types.TaskUnitStatuses(units))); err != nil {
+		return err
+	}
+	if err = j.markAvailable(taskID); err != nil {
+		return err
+	}
+
+	task, err := j.storageImplementation.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+	if task.JobID == "" {
+		j.notifyTask(taskID)
+		return nil
+	}
+
+	tasks, err := j.storageImplementation.GetTasks(task.JobID)
+	if err != nil {
+		return err
+	}
+	statuses := []types.StatusType{}
+	for i := 0; i < len(tasks); i++ {
+		statuses = append(statuses, tasks[i].Status)
+	}
+	status := types.RollupStatus(statuses)
+	if err = j.storageImplementation.UpdateJobStatus(task.JobID, status); err != nil {
+		return err
+	}
+	j.notifyTask(taskID)
+	// a finished job might release the jobs waiting on it
+	if status == types.SuccessStatus || status == types.ErrorStatus {
+		j.notifyDependents(task.JobID)
+		if err = j.markDependentsAvailable(task.JobID); err != nil {
+			return err
+		}
+	}
+	if status == types.ErrorStatus {
+		return j.cancelDependents(task.JobID)
+	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package junjo
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -19,7 +20,7 @@ func TestBrigadeDeCuisine(t *testing.T) {
 
 	storage := memory.NewMemoryStorage()
 
-	jj := New(storage)
+	jj := NewJ(storage)
 	var err error
 
 	var topic *types.Topic
@@ -354,3 +355,57 @@ func printProgression(workUnitDag *types.WorkUnitDag) {
 		fmt.Println(available[i].Definition.Name)
 	}
 }
+
+// go test -timeout 30s -v -count=1 -run ^TestCancelJob$ .
+func TestCancelJob(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var kitchen *types.Topic
+	if kitchen, err = jj.CreateTopic("Kitchen"); err != nil {
+		t.Error(err)
+		return
+	}
+	var chef *types.Owner
+	if chef, err = jj.CreateOwner("Chef"); err != nil {
+		t.Error(err)
+		return
+	}
+	var plating *types.TaskDefinition
+	if plating, err = jj.CreateTaskDefinition("plating", chef.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	node := unitDag.AddTaskDefinition(plating)()
+	unitID := node.(*types.NodeTaskUnit).Unit.Key
+	var job *types.Job
+	if job, err = jj.LaunchJob(kitchen.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.CancelJob(job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the units not started yet leave the inbox and can't move anymore
+	var inbox []types.InboxAllTaskUnit
+	if inbox, err = jj.GetInbox(chef.Key); err != nil || len(inbox) != 0 {
+		t.Errorf("expected an empty inbox, got %v %v", inbox, err)
+		return
+	}
+	if err = jj.SubmitCommand(unitID, types.Command{Type: types.ProgressCmd}); !errors.Is(err, types.ErrTaskUnitClosed) {
+		t.Errorf("expected %v, got %v", types.ErrTaskUnitClosed, err)
+		return
+	}
+	if err = jj.SubmitCommand(unitID, types.Command{Type: types.LogCmd, Details: "too late"}); !errors.Is(err, types.ErrTaskUnitClosed) {
+		t.Errorf("expected %v, got %v", types.ErrTaskUnitClosed, err)
+		return
+	}
+	if job, err = jj.GetJob(job.Key); err != nil || job.Status != types.ErrorStatus {
+		t.Errorf("expected the job to stay canceled, got %v %v", job, err)
+		return
+	}
+}
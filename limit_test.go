@@ -0,0 +1,146 @@
+package junjo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestConcurrencyLimit$ .
+func TestConcurrencyLimit(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var lab, datacenter *types.Topic
+	if lab, err = jj.CreateTopic("Lab"); err != nil {
+		t.Error(err)
+		return
+	}
+	if datacenter, err = jj.CreateTopic("Datacenter"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var firmware *types.Owner
+	if firmware, err = jj.CreateOwner("Firmware"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var flashing *types.TaskDefinition
+	if flashing, err = jj.CreateTaskDefinition("flashing", firmware.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	units := []types.TaskUnitID{}
+	launch := func(topicID types.TopicID) error {
+		unitDag := jj.CreateDagTaskUnits()
+		vertex := unitDag.AddTaskDefinition(flashing)()
+		units = append(units, vertex.(*types.NodeTaskUnit).Unit.Key)
+		_, err := jj.LaunchJob(topicID, unitDag)
+		return err
+	}
+	for i := 0; i < 3; i++ {
+		if err = launch(lab.Key); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	if err = jj.SetConcurrencyLimit(types.NewConcurrencyLimit(2, types.WithLimitOwner(firmware.Key))); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SetConcurrencyLimit(types.NewConcurrencyLimit(2)); !errors.Is(err, types.ErrConcurrencyLimitInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrConcurrencyLimitInvalid, err)
+		return
+	}
+
+	if err = offered(jj, firmware.Key, 2); err != nil {
+		t.Error(err)
+		return
+	}
+
+	for i := 0; i < 2; i++ {
+		if err = jj.SubmitCommand(units[i], types.Command{Type: types.ProgressCmd}); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if err = offered(jj, firmware.Key, 0); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err = jj.SubmitCommand(units[2], types.Command{Type: types.ProgressCmd}); !errors.Is(err, types.ErrConcurrencyLimitReached) {
+		t.Errorf("expected %v, got %v", types.ErrConcurrencyLimitReached, err)
+		return
+	}
+	var unit *types.TaskUnit
+	if unit, err = jj.GetTaskUnit(units[2]); err != nil {
+		t.Error(err)
+		return
+	}
+	if unit.Status != types.NoneStatus || len(unit.Commands) != 0 {
+		t.Errorf("expected the refused unit untouched, got %v with %v command(s)", unit.Status, len(unit.Commands))
+		return
+	}
+
+	// a finished unit frees its slot
+	if err = jj.SubmitCommand(units[0], types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = offered(jj, firmware.Key, 1); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// per topic, the lab units don't count against the datacenter
+	if err = jj.SetConcurrencyLimit(types.NewConcurrencyLimit(0, types.WithLimitOwner(firmware.Key))); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SetConcurrencyLimit(types.NewConcurrencyLimit(1, types.WithLimitTaskDefinition(flashing.Key), types.WithLimitTopic(datacenter.Key))); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		if err = launch(datacenter.Key); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	var inbox []types.InboxTopicTaskUnit
+	if inbox, err = jj.GetInboxTopic(firmware.Key, datacenter.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox) != 1 {
+		t.Errorf("expected 1 datacenter unit offered, got %v", len(inbox))
+		return
+	}
+	if err = offered(jj, firmware.Key, 2); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
+func offered(jj *Junjoold, ownerID types.OwnerID, expected int) error {
+	inbox, err := jj.GetInbox(ownerID)
+	if err != nil {
+		return err
+	}
+	count := 0
+	for i := 0; i < len(inbox); i++ {
+		count += len(inbox[i].TaskUnits)
+	}
+	if count != expected {
+		return fmt.Errorf("expected %v unit(s) offered, got %v", expected, count)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+func (ms *MemoryStorage) AddAuditRecord(record types.AuditRecord) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if record.Key == "" {
+		uuid, err := ms.NewUUID()
+		if err != nil {
+			return err
+		}
+		record.Key = types.AuditRecordID(uuid)
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	record.Namespace = ms.namespace
+	ms.audit = append(ms.audit, record)
+	return nil
+}
+
+func (ms *MemoryStorage) GetAuditRecords() ([]types.AuditRecord, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	records := []types.AuditRecord{}
+	for i := 0; i < len(ms.audit); i++ {
+		if ms.sees(ms.audit[i].Namespace) {
+			records = append(records, ms.audit[i])
+		}
+	}
+	return records, nil
+}
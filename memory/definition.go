@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+// A definition at the version a unit pinned
+type pinnedDefinition struct {
+	id      types.TaskDefinitionID
+	version int
+}
+
+func (ms *MemoryStorage) GetTaskDefinitionVersion(id types.TaskDefinitionID, version int) (*types.TaskDefinition, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	versions := ms.definitionVersions[id]
+	if _, exists := ms.definition(id); !exists {
+		return nil, fmt.Errorf("unit description with ID %s not found", id)
+	}
+	if version == 0 {
+		version = len(versions)
+	}
+	if version < 0 || version > len(versions) {
+		return nil, fmt.Errorf("%w: %v of %v", types.ErrTaskDefinitionVersionNotFound, version, id)
+	}
+	definition := versions[version-1]
+	return &definition, nil
+}
+
+func (ms *MemoryStorage) GetTaskDefinitionVersions(id types.TaskDefinitionID) ([]types.TaskDefinition, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	versions := ms.definitionVersions[id]
+	if _, exists := ms.definition(id); !exists {
+		return nil, fmt.Errorf("unit description with ID %s not found", id)
+	}
+	return append([]types.TaskDefinition{}, versions...), nil
+}
+
+// Version of its definition the unit was created with, must be called with the lock held
+func (ms *MemoryStorage) unitDefinition(unit *types.TaskUnit) *types.TaskDefinition {
+	versions := ms.definitionVersions[unit.TaskDefinitionID]
+	if unit.DefinitionVersion > 0 && unit.DefinitionVersion <= len(versions) {
+		return &versions[unit.DefinitionVersion-1]
+	}
+	return ms.definitions[unit.TaskDefinitionID]
+}
+
+// A new unit can use its definition and go to its owner, must be called with the lock held
+// Pinning an older version doesn't get around the deprecation of the definition
+func (ms *MemoryStorage) checkNewUnit(unit *types.TaskUnit) error {
+	if err := ms.checkUnitDefinition(unit); err != nil {
+		return err
+	}
+	if unit.TaskDefinitionID == "" {
+		return ms.checkOwner(unit.OwnerID)
+	}
+	if definition, _ := ms.definition(unit.TaskDefinitionID); definition.Deprecated {
+		return fmt.Errorf("%w: %v", types.ErrTaskDefinitionDeprecated, definition.Key)
+	}
+	// the owner of the version the unit pins, which may not be the current one
+	return ms.checkOwner(unit.Owner(ms.unitDefinition(unit)))
+}
+
+// The definition of the unit and the version it pins exist, must be called with the lock held
+func (ms *MemoryStorage) checkUnitDefinition(unit *types.TaskUnit) error {
+	if unit.TaskDefinitionID == "" {
+		return nil
+	}
+	if _, exists := ms.definition(unit.TaskDefinitionID); !exists {
+		return fmt.Errorf("%w: unit %v references unknown definition %v", types.ErrJobGraphInvalid, unit.Key, unit.TaskDefinitionID)
+	}
+	if unit.DefinitionVersion < 0 || unit.DefinitionVersion > len(ms.definitionVersions[unit.TaskDefinitionID]) {
+		return fmt.Errorf("%w: %v of %v", types.ErrTaskDefinitionVersionNotFound, unit.DefinitionVersion, unit.TaskDefinitionID)
+	}
+	return nil
+}
+
+// New work can go to the owner, must be called with the lock held
+func (ms *MemoryStorage) checkOwner(ownerID types.OwnerID) error {
+	if owner, exists := ms.owner(ownerID); exists && owner.Deprecated {
+		return fmt.Errorf("%w: %v", types.ErrOwnerDeprecated, ownerID)
+	}
+	return nil
+}
+
+func (ms *MemoryStorage) MigrateTaskUnit(taskUnitID types.TaskUnitID, definitionID types.TaskDefinitionID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	unit, exists := ms.unit(taskUnitID)
+	if !exists {
+		return fmt.Errorf("task unit with ID %s not found", taskUnitID)
+	}
+	definition, exists := ms.definition(definitionID)
+	if !exists {
+		return fmt.Errorf("%w: definition %v not found", types.ErrMigrationInvalid, definitionID)
+	}
+	if !unit.Pending() {
+		return types.ErrTaskUnitNotAvailable
+	}
+	if definition.Deprecated {
+		return fmt.Errorf("%w: %v", types.ErrTaskDefinitionDeprecated, definitionID)
+	}
+	if err := ms.checkOwner(unit.Owner(definition)); err != nil {
+		return err
+	}
+	unit.TaskDefinitionID = definition.Key
+	unit.DefinitionVersion = definition.Version
+	return nil
+}
+
+// Pin the unit to the current version of its definition, must be called with the lock held
+func (ms *MemoryStorage) pinDefinition(unit *types.TaskUnit) {
+	if definition, exists := ms.definition(unit.TaskDefinitionID); exists && unit.DefinitionVersion == 0 {
+		unit.DefinitionVersion = definition.Version
+	}
+}
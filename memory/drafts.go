@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+func (ms *MemoryStorage) GetDraftJobs() ([]types.Job, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	jobs := []types.Job{}
+	for _, job := range ms.jobs {
+		if job.TopicID == "" && ms.sees(job.Namespace) {
+			jobs = append(jobs, *job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Key < jobs[j].Key })
+	return jobs, nil
+}
+
+func (ms *MemoryStorage) GetDraftTasks() ([]types.Task, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	tasks := []types.Task{}
+	for _, task := range ms.tasks {
+		if task.JobID == "" && ms.sees(task.Namespace) {
+			tasks = append(tasks, *task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Key < tasks[j].Key })
+	return tasks, nil
+}
+
+func (ms *MemoryStorage) GetDraftTaskUnits() ([]types.TaskUnit, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	units := []types.TaskUnit{}
+	for _, unit := range ms.units {
+		if unit.TaskID == "" && ms.sees(unit.Namespace) {
+			units = append(units, *unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool { return units[i].Key < units[j].Key })
+	return units, nil
+}
+
+// DeleteJob removes the job from its topic then deletes it with its tasks and their units
+func (ms *MemoryStorage) DeleteJob(jobID types.JobID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return ms.deleteJob(jobID, false)
+}
+
+// DeleteDraftJob deletes the job like `DeleteJob` as long as it has no topic
+func (ms *MemoryStorage) DeleteDraftJob(jobID types.JobID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return ms.deleteJob(jobID, true)
+}
+
+// Delete a job, only a draft one when `draft` is set, the lock must be held
+func (ms *MemoryStorage) deleteJob(jobID types.JobID, draft bool) error {
+	job, exists := ms.job(jobID)
+	if !exists {
+		return fmt.Errorf("job %v doesn't exists", jobID)
+	}
+	if draft && job.TopicID != "" {
+		return fmt.Errorf("%w: job %v is in topic %v", types.ErrNotDraft, jobID, job.TopicID)
+	}
+	// the jobs waiting on it would never know how it ended
+	for _, dependent := range ms.jobs {
+		if hasJobID(dependent.DependsOn, jobID) && ms.sees(dependent.Namespace) {
+			return fmt.Errorf("%w: job %v is the upstream of job %v", types.ErrJobDependencyInvalid, jobID, dependent.Key)
+		}
+	}
+
+	if topic, exists := ms.topic(job.TopicID); exists {
+		delete(topic.Jobs, jobID)
+		jobIDs := []types.JobID{}
+		for i := 0; i < len(topic.JobIDs); i++ {
+			if topic.JobIDs[i] != jobID {
+				jobIDs = append(jobIDs, topic.JobIDs[i])
+			}
+		}
+		topic.JobIDs = jobIDs
+	}
+
+	for i := 0; i < len(job.TaskIDs); i++ {
+		ms.deleteTask(job.TaskIDs[i])
+	}
+	delete(ms.jobs, jobID)
+
+	return nil
+}
+
+// DeleteTask removes the task from its job then deletes it with its units
+func (ms *MemoryStorage) DeleteTask(taskID types.TaskID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return ms.deleteJobTask(taskID, false)
+}
+
+// DeleteDraftTask deletes the task like `DeleteTask` as long as it has no job
+func (ms *MemoryStorage) DeleteDraftTask(taskID types.TaskID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return ms.deleteJobTask(taskID, true)
+}
+
+// Remove a task from its job then delete it, only a draft one when `draft` is set, the lock must be held
+func (ms *MemoryStorage) deleteJobTask(taskID types.TaskID, draft bool) error {
+	task, exists := ms.task(taskID)
+	if !exists {
+		return fmt.Errorf("task %v doesn't exists", taskID)
+	}
+	if draft && task.JobID != "" {
+		return fmt.Errorf("%w: task %v is in job %v", types.ErrNotDraft, taskID, task.JobID)
+	}
+
+	if job, exists := ms.job(task.JobID); exists {
+		delete(job.Tasks, taskID)
+		taskIDs := []types.TaskID{}
+		for i := 0; i < len(job.TaskIDs); i++ {
+			if job.TaskIDs[i] != taskID {
+				taskIDs = append(taskIDs, job.TaskIDs[i])
+			}
+		}
+		job.TaskIDs = taskIDs
+	}
+
+	ms.deleteTask(taskID)
+
+	return nil
+}
+
+// DeleteTaskUnit removes the unit from its task and from the dependencies of the other units then deletes it
+func (ms *MemoryStorage) DeleteTaskUnit(taskUnitID types.TaskUnitID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return ms.deleteTaskUnit(taskUnitID, false)
+}
+
+// DeleteDraftTaskUnit deletes the unit like `DeleteTaskUnit` as long as it has no task
+func (ms *MemoryStorage) DeleteDraftTaskUnit(taskUnitID types.TaskUnitID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return ms.deleteTaskUnit(taskUnitID, true)
+}
+
+// Delete a unit, only a draft one when `draft` is set, the lock must be held
+func (ms *MemoryStorage) deleteTaskUnit(taskUnitID types.TaskUnitID, draft bool) error {
+	unit, exists := ms.unit(taskUnitID)
+	if !exists {
+		return fmt.Errorf("unit %v doesn't exists", taskUnitID)
+	}
+	if draft && unit.TaskID != "" {
+		return fmt.Errorf("%w: unit %v is in task %v", types.ErrNotDraft, taskUnitID, unit.TaskID)
+	}
+
+	if task, exists := ms.task(unit.TaskID); exists {
+		delete(task.TaskUnits, taskUnitID)
+		unitIDs := []types.TaskUnitID{}
+		for i := 0; i < len(task.TaskUnitIDs); i++ {
+			if task.TaskUnitIDs[i] != taskUnitID {
+				unitIDs = append(unitIDs, task.TaskUnitIDs[i])
+			}
+		}
+		task.TaskUnitIDs = unitIDs
+	}
+
+	for _, other := range ms.units {
+		dependsOnIDs := []types.TaskUnitID{}
+		for i := 0; i < len(other.DependsOnIDs); i++ {
+			if other.DependsOnIDs[i] != taskUnitID {
+				dependsOnIDs = append(dependsOnIDs, other.DependsOnIDs[i])
+			}
+		}
+		dependsOn := []*types.TaskUnit{}
+		for i := 0; i < len(other.DependsOn); i++ {
+			if other.DependsOn[i].Key != taskUnitID {
+				dependsOn = append(dependsOn, other.DependsOn[i])
+			}
+		}
+		other.DependsOnIDs = dependsOnIDs
+		other.DependsOn = dependsOn
+	}
+
+	delete(ms.units, taskUnitID)
+
+	return nil
+}
+
+// Delete a task and its units, the lock must be held
+func (ms *MemoryStorage) deleteTask(taskID types.TaskID) {
+	task, exists := ms.task(taskID)
+	if !exists {
+		return
+	}
+	for i := 0; i < len(task.TaskUnitIDs); i++ {
+		delete(ms.units, task.TaskUnitIDs[i])
+	}
+	delete(ms.tasks, taskID)
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+// ExpandFanOut checks the children before writing anything, like `CreateJobGraph`
+// The children are the work of a fan-out already launched, they expand even when its owner or its definition was deprecated since
+func (ms *MemoryStorage) ExpandFanOut(unitID types.TaskUnitID, children []*types.TaskUnit) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	fanOut, exists := ms.unit(unitID)
+	if !exists {
+		return fmt.Errorf("%w: unit %v doesn't exists", types.ErrFanOutInvalid, unitID)
+	}
+	if fanOut.FanOut == nil {
+		return fmt.Errorf("%w: unit %v is not a fan-out", types.ErrFanOutInvalid, unitID)
+	}
+	if fanOut.Status != types.NoneStatus {
+		return fmt.Errorf("%w: unit %v is already %v", types.ErrFanOutInvalid, unitID, fanOut.Status)
+	}
+	task, exists := ms.task(fanOut.TaskID)
+	if !exists {
+		return fmt.Errorf("%w: unit %v has no task", types.ErrFanOutInvalid, unitID)
+	}
+
+	seen := map[types.TaskUnitID]bool{}
+	for i := 0; i < len(children); i++ {
+		if children[i].Key == "" {
+			return fmt.Errorf("%w: child without id", types.ErrFanOutInvalid)
+		}
+		if _, exists := ms.units[children[i].Key]; exists || seen[children[i].Key] {
+			return fmt.Errorf("%w: unit %v already exists", types.ErrFanOutInvalid, children[i].Key)
+		}
+		if err := ms.checkUnitDefinition(children[i]); err != nil {
+			return err
+		}
+		for j := 0; j < len(children[i].DependsOnIDs); j++ {
+			if dependency, exists := ms.unit(children[i].DependsOnIDs[j]); !exists || dependency.TaskID != task.Key {
+				return fmt.Errorf("%w: child %v depends on %v which is not in its task", types.ErrFanOutInvalid, children[i].Key, children[i].DependsOnIDs[j])
+			}
+		}
+		seen[children[i].Key] = true
+	}
+
+	for i := 0; i < len(children); i++ {
+		child := children[i]
+		child.Mutate(types.WithTaskUnitTaskID(task.Key))
+		child.ParentID = unitID
+		child.Namespace = ms.namespace
+		child.DependsOn = []*types.TaskUnit{}
+		for j := 0; j < len(child.DependsOnIDs); j++ {
+			child.DependsOn = append(child.DependsOn, ms.units[child.DependsOnIDs[j]])
+		}
+		ms.units[child.Key] = child
+		task.Mutate(
+			types.WithTaskUnitsIDs(child.Key),
+			types.WithTaskUnits(child))
+	}
+	fanOut.Status = types.ProgressStatus
+	return nil
+}
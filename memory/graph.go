@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+// CreateJobGraph checks everything before writing anything, the storage is locked for the whole creation
+func (ms *MemoryStorage) CreateJobGraph(topicID types.TopicID, tasks [][]*types.TaskUnit, cfgs ...types.JobConfig) (*types.Job, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	topic, exists := ms.topic(topicID)
+	if !exists {
+		return nil, fmt.Errorf("%w: topic %v doesn't exists", types.ErrJobGraphInvalid, topicID)
+	}
+	if topic.Deprecated {
+		return nil, fmt.Errorf("%w: %v", types.ErrTopicDeprecated, topicID)
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("%w: a job needs at least one task", types.ErrJobGraphInvalid)
+	}
+
+	seen := map[types.TaskUnitID]bool{}
+	for i := 0; i < len(tasks); i++ {
+		if len(tasks[i]) == 0 {
+			return nil, fmt.Errorf("%w: task %v has no unit", types.ErrJobGraphInvalid, i)
+		}
+		inTask := map[types.TaskUnitID]bool{}
+		for j := 0; j < len(tasks[i]); j++ {
+			unit := tasks[i][j]
+			if unit.Key == "" {
+				return nil, fmt.Errorf("%w: unit without id", types.ErrJobGraphInvalid)
+			}
+			if _, exists := ms.units[unit.Key]; exists || seen[unit.Key] {
+				return nil, fmt.Errorf("%w: unit %v already exists", types.ErrJobGraphInvalid, unit.Key)
+			}
+			if err := ms.checkNewUnit(unit); err != nil {
+				return nil, err
+			}
+			seen[unit.Key] = true
+			inTask[unit.Key] = true
+		}
+		// a task is its own dag
+		for j := 0; j < len(tasks[i]); j++ {
+			for k := 0; k < len(tasks[i][j].DependsOnIDs); k++ {
+				if !inTask[tasks[i][j].DependsOnIDs[k]] {
+					return nil, fmt.Errorf("%w: unit %v depends on %v which is not in its task", types.ErrJobGraphInvalid, tasks[i][j].Key, tasks[i][j].DependsOnIDs[k])
+				}
+			}
+		}
+		taskUnits := make(map[types.TaskUnitID]*types.TaskUnit, len(tasks[i]))
+		for j := 0; j < len(tasks[i]); j++ {
+			taskUnits[tasks[i][j].Key] = tasks[i][j]
+		}
+		if _, err := types.TopologicalSort(taskUnits); err != nil {
+			return nil, fmt.Errorf("%w: task %v: %v", types.ErrJobGraphInvalid, i, err)
+		}
+		if err := types.ValidateFanOuts(tasks[i]); err != nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrJobGraphInvalid, err)
+		}
+		if err := types.ValidateJoins(tasks[i]); err != nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrJobGraphInvalid, err)
+		}
+	}
+
+	// ids are generated before writing so nothing can fail in the middle
+	var uuid string
+	var err error
+	if uuid, err = ms.NewUUID(); err != nil {
+		return nil, err
+	}
+	job := types.NewJob(types.JobID(uuid), cfgs...)
+	if _, exists := ms.jobs[job.Key]; exists {
+		return nil, fmt.Errorf("%w: job %v already exists", types.ErrJobGraphInvalid, job.Key)
+	}
+	switch job.Upstream {
+	case "", types.UpstreamBlock, types.UpstreamCancel, types.UpstreamIgnore:
+	default:
+		return nil, fmt.Errorf("%w: unknown upstream policy %q", types.ErrJobDependencyInvalid, job.Upstream)
+	}
+	if err = ms.checkJobDependencies(job.Key, job.DependsOn); err != nil {
+		return nil, err
+	}
+	taskIDs := []types.TaskID{}
+	for i := 0; i < len(tasks); i++ {
+		if uuid, err = ms.NewUUID(); err != nil {
+			return nil, err
+		}
+		if _, exists := ms.tasks[types.TaskID(uuid)]; exists {
+			return nil, fmt.Errorf("%w: task %v already exists", types.ErrJobGraphInvalid, uuid)
+		}
+		taskIDs = append(taskIDs, types.TaskID(uuid))
+	}
+
+	for i := 0; i < len(tasks); i++ {
+		task := types.NewTask(taskIDs[i], types.WithTaskJobID(job.Key))
+		task.Namespace = ms.namespace
+		for j := 0; j < len(tasks[i]); j++ {
+			unit := tasks[i][j]
+			unit.Mutate(types.WithTaskUnitTaskID(task.Key))
+			ms.pinDefinition(unit)
+			unit.Namespace = ms.namespace
+			ms.units[unit.Key] = unit
+			task.Mutate(
+				types.WithTaskUnitsIDs(unit.Key),
+				types.WithTaskUnits(unit))
+		}
+		// runtime pointers between the units of the task
+		for j := 0; j < len(tasks[i]); j++ {
+			unit := tasks[i][j]
+			unit.DependsOn = []*types.TaskUnit{}
+			for k := 0; k < len(unit.DependsOnIDs); k++ {
+				unit.DependsOn = append(unit.DependsOn, ms.units[unit.DependsOnIDs[k]])
+			}
+		}
+		ms.tasks[task.Key] = task
+		job.Mutate(
+			types.WithJobTaskIDs(task.Key),
+			types.WithJobTasks(task))
+	}
+
+	job.Mutate(types.WithJobTaskID(topicID))
+	job.Namespace = ms.namespace
+	ms.jobs[job.Key] = job
+	topic.Mutate(
+		types.WithTopicJobIDs(job.Key),
+		types.WithTopicJobs(job))
+
+	return job, nil
+}
+
+func (ms *MemoryStorage) GetBatchJobs(batchID types.BatchID) ([]types.Job, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	jobs := []types.Job{}
+	for _, job := range ms.jobs {
+		if job.BatchID == batchID && ms.sees(job.Namespace) {
+			jobs = append(jobs, *job)
+		}
+	}
+	if len(jobs) == 0 {
+		return nil, types.ErrBatchNotFound
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Key < jobs[j].Key })
+	return jobs, nil
+}
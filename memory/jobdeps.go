@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/davidroman0O/junjo/dag"
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Dependencies between jobs are a graph of their own, checked for cycles before each change
+
+func (ms *MemoryStorage) AddJobDependencies(jobID types.JobID, upstreamIDs []types.JobID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	job, exists := ms.job(jobID)
+	if !exists {
+		return fmt.Errorf("%w: job %v doesn't exists", types.ErrJobDependencyInvalid, jobID)
+	}
+	if err := ms.checkJobDependencies(jobID, upstreamIDs); err != nil {
+		return err
+	}
+	for i := 0; i < len(upstreamIDs); i++ {
+		if !hasJobID(job.DependsOn, upstreamIDs[i]) {
+			job.DependsOn = append(job.DependsOn, upstreamIDs[i])
+		}
+	}
+	return nil
+}
+
+func (ms *MemoryStorage) GetDependentJobs(jobID types.JobID) ([]types.Job, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if _, exists := ms.job(jobID); !exists {
+		return nil, fmt.Errorf("%w: %v", types.ErrJobNotFound, jobID)
+	}
+	jobs := []types.Job{}
+	for _, job := range ms.jobs {
+		if hasJobID(job.DependsOn, jobID) && ms.sees(job.Namespace) {
+			jobs = append(jobs, *job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+	})
+	return jobs, nil
+}
+
+// The upstream jobs must exist and must not lead back to the job, must be called with the lock
+func (ms *MemoryStorage) checkJobDependencies(jobID types.JobID, upstreamIDs []types.JobID) error {
+	if len(upstreamIDs) == 0 {
+		return nil
+	}
+
+	graph := &dag.AcyclicGraph{}
+	graph.Add(string(jobID))
+	for key, job := range ms.jobs {
+		graph.Add(string(key))
+		for i := 0; i < len(job.DependsOn); i++ {
+			graph.Add(string(job.DependsOn[i]))
+			graph.Connect(dag.BasicEdge(string(job.DependsOn[i]), string(key)))
+		}
+	}
+	for i := 0; i < len(upstreamIDs); i++ {
+		if upstreamIDs[i] == jobID {
+			return fmt.Errorf("%w: job %v can't depend on itself", types.ErrJobDependencyInvalid, jobID)
+		}
+		if _, exists := ms.job(upstreamIDs[i]); !exists {
+			return fmt.Errorf("%w: upstream job %v doesn't exists", types.ErrJobDependencyInvalid, upstreamIDs[i])
+		}
+		graph.Connect(dag.BasicEdge(string(upstreamIDs[i]), string(jobID)))
+	}
+
+	if cycles := graph.Cycles(); len(cycles) > 0 {
+		names := []string{}
+		for i := 0; i < len(cycles[0]); i++ {
+			names = append(names, dag.VertexName(cycles[0][i]))
+		}
+		return fmt.Errorf("%w: cycle between jobs %v", types.ErrJobDependencyInvalid, names)
+	}
+	return nil
+}
+
+// A job waits while one of its upstream jobs is not done, a missing one counts as failed, must be called with the lock
+func (ms *MemoryStorage) jobWaiting(job *types.Job) bool {
+	for i := 0; i < len(job.DependsOn); i++ {
+		status := types.ErrorStatus
+		if upstream, exists := ms.job(job.DependsOn[i]); exists {
+			status = upstream.Status
+		}
+		if !types.UpstreamDone(job.Upstream, status) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasJobID(ids []types.JobID, id types.JobID) bool {
+	for i := 0; i < len(ids); i++ {
+		if ids[i] == id {
+			return true
+		}
+	}
+	return false
+}
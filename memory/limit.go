@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+// What a `ConcurrencyLimit` counts, either an owner or a definition, on one topic or all of them, within a namespace
+type limitScope struct {
+	namespace    types.Namespace
+	ownerID      types.OwnerID
+	definitionID types.TaskDefinitionID
+	topicID      types.TopicID
+}
+
+func (s limitScope) limit(maximum int) types.ConcurrencyLimit {
+	return types.ConcurrencyLimit{
+		Namespace:        s.namespace,
+		OwnerID:          s.ownerID,
+		TaskDefinitionID: s.definitionID,
+		TopicID:          s.topicID,
+		Max:              maximum,
+	}
+}
+
+func (s limitScope) String() string {
+	name := fmt.Sprintf("owner %v", s.ownerID)
+	if s.definitionID != "" {
+		name = fmt.Sprintf("definition %v", s.definitionID)
+	}
+	if s.topicID != "" {
+		name += fmt.Sprintf(" on topic %v", s.topicID)
+	}
+	return name
+}
+
+func (ms *MemoryStorage) SetConcurrencyLimit(limit types.ConcurrencyLimit) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if (limit.OwnerID == "") == (limit.TaskDefinitionID == "") {
+		return fmt.Errorf("%w: set either an owner or a definition", types.ErrConcurrencyLimitInvalid)
+	}
+	if limit.Max < 0 {
+		return fmt.Errorf("%w: negative max %v", types.ErrConcurrencyLimitInvalid, limit.Max)
+	}
+	if _, exists := ms.owner(limit.OwnerID); limit.OwnerID != "" && !exists {
+		return fmt.Errorf("%w: owner %v not found", types.ErrConcurrencyLimitInvalid, limit.OwnerID)
+	}
+	if _, exists := ms.definition(limit.TaskDefinitionID); limit.TaskDefinitionID != "" && !exists {
+		return fmt.Errorf("%w: definition %v not found", types.ErrConcurrencyLimitInvalid, limit.TaskDefinitionID)
+	}
+	if _, exists := ms.topic(limit.TopicID); limit.TopicID != "" && !exists {
+		return fmt.Errorf("%w: topic %v not found", types.ErrConcurrencyLimitInvalid, limit.TopicID)
+	}
+
+	scope := limitScope{
+		namespace:    ms.namespace,
+		ownerID:      limit.OwnerID,
+		definitionID: limit.TaskDefinitionID,
+		topicID:      limit.TopicID,
+	}
+	if limit.Max == 0 {
+		delete(ms.limits, scope)
+		return nil
+	}
+	ms.limits[scope] = limit.Max
+
+	return nil
+}
+
+func (ms *MemoryStorage) GetConcurrencyLimits() ([]types.ConcurrencyLimit, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	limits := []types.ConcurrencyLimit{}
+	all := ms.concurrencyLimits()
+	for i := 0; i < len(all); i++ {
+		if ms.sees(all[i].Namespace) {
+			limits = append(limits, all[i])
+		}
+	}
+	return limits, nil
+}
+
+// Limits of every namespace, must be called with the lock held
+func (ms *MemoryStorage) concurrencyLimits() []types.ConcurrencyLimit {
+	limits := make([]types.ConcurrencyLimit, 0, len(ms.limits))
+	for scope, maximum := range ms.limits {
+		limits = append(limits, scope.limit(maximum))
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		if limits[i].Namespace != limits[j].Namespace {
+			return limits[i].Namespace < limits[j].Namespace
+		}
+		if limits[i].OwnerID != limits[j].OwnerID {
+			return limits[i].OwnerID < limits[j].OwnerID
+		}
+		if limits[i].TaskDefinitionID != limits[j].TaskDefinitionID {
+			return limits[i].TaskDefinitionID < limits[j].TaskDefinitionID
+		}
+		return limits[i].TopicID < limits[j].TopicID
+	})
+	return limits
+}
+
+// Every scope a unit counts against, whether a limit exists or not
+// Must be called with the lock held
+func (ms *MemoryStorage) unitScopes(unit *types.TaskUnit) []limitScope {
+	var topicID types.TopicID
+	if task, exists := ms.task(unit.TaskID); exists {
+		if job, exists := ms.job(task.JobID); exists {
+			topicID = job.TopicID
+		}
+	}
+
+	scopes := []limitScope{
+		{namespace: ms.namespace, definitionID: unit.TaskDefinitionID},
+	}
+	if topicID != "" {
+		scopes = append(scopes, limitScope{namespace: ms.namespace, definitionID: unit.TaskDefinitionID, topicID: topicID})
+	}
+	if ownerID := ms.unitOwner(unit); ownerID != "" {
+		scopes = append(scopes, limitScope{namespace: ms.namespace, ownerID: ownerID})
+		if topicID != "" {
+			scopes = append(scopes, limitScope{namespace: ms.namespace, ownerID: ownerID, topicID: topicID})
+		}
+	}
+	return scopes
+}
+
+// Number of queued and in progress units of each scope, nil without limits
+// Must be called with the lock held
+func (ms *MemoryStorage) activeCounts() map[limitScope]int {
+	if len(ms.limits) == 0 {
+		return nil
+	}
+	counts := map[limitScope]int{}
+	for _, unit := range ms.units {
+		// a fan-out runs through its children
+		if !types.IsActiveStatus(unit.Status) || unit.FanOut != nil || !ms.sees(unit.Namespace) {
+			continue
+		}
+		scopes := ms.unitScopes(unit)
+		for i := 0; i < len(scopes); i++ {
+			counts[scopes[i]]++
+		}
+	}
+	return counts
+}
+
+// Check that one more unit fits within the limits of its scopes
+// Must be called with the lock held
+func (ms *MemoryStorage) withinLimits(unit *types.TaskUnit, counts map[limitScope]int) error {
+	scopes := ms.unitScopes(unit)
+	for i := 0; i < len(scopes); i++ {
+		if maximum, exists := ms.limits[scopes[i]]; exists && counts[scopes[i]] >= maximum {
+			return fmt.Errorf("%w: %v can have %v unit(s) at once", types.ErrConcurrencyLimitReached, scopes[i], maximum)
+		}
+	}
+	return nil
+}
+
+// Keep the units the limits can still take, in order, the counts include the units kept
+// Must be called with the lock held
+func (ms *MemoryStorage) offerWithinLimits(units []types.TaskUnit, counts map[limitScope]int) []types.TaskUnit {
+	if counts == nil {
+		return units
+	}
+	offered := []types.TaskUnit{}
+	for i := 0; i < len(units); i++ {
+		if ms.withinLimits(&units[i], counts) != nil {
+			continue
+		}
+		scopes := ms.unitScopes(&units[i])
+		for k := 0; k < len(scopes); k++ {
+			counts[scopes[k]]++
+		}
+		offered = append(offered, units[i])
+	}
+	return offered
+}
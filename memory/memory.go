@@ -5,20 +5,41 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/davidroman0O/junjo/types"
 )
 
 /// `MemoryStorage` is an implementation that is intented for single applications or for your unit tests, if you need real databases storage then you should consider using other `junjo` repositories for storage
+/// Each `MemoryStorage` is a view of one `Namespace`, the views given by `Namespace` share the same content and only see their own entities
 
 type MemoryStorage struct {
+	*store
+	namespace types.Namespace
+}
+
+// Content shared by the views of every namespace
+type store struct {
 	mu          sync.RWMutex
 	topics      map[types.TopicID]*types.Topic
 	jobs        map[types.JobID]*types.Job
 	tasks       map[types.TaskID]*types.Task
 	units       map[types.TaskUnitID]*types.TaskUnit
 	definitions map[types.TaskDefinitionID]*types.TaskDefinition
-	owners      map[types.OwnerID]*types.Owner
+	// every version of each definition, oldest first, the last one is in `definitions`
+	definitionVersions map[types.TaskDefinitionID][]types.TaskDefinition
+	owners             map[types.OwnerID]*types.Owner
+	templates          map[types.TemplateID]*types.Template
+	limits             map[limitScope]int
+	schedules          map[types.ScheduleID]*types.Schedule
+	webhooks           map[types.WebhookID]*types.Webhook
+	deliveries         map[types.DeliveryID]*types.Delivery
+	// deliveries in the order they were added
+	deliveryOrder []types.DeliveryID
+	// events already given to each webhook
+	deliveryEvents map[deliveryEvent]bool
+	// permission checks, oldest first
+	audit []types.AuditRecord
 }
 
 func (ms *MemoryStorage) Print() {
@@ -81,9 +102,9 @@ func (ms *MemoryStorage) PrintTree() {
 
 	topics, _ := ms.GetTopics() // Assuming this function exists and works
 
-	topicKeys := make([]types.TopicID, 0, len(ms.topics))
-	for ti := range ms.topics {
-		topicKeys = append(topicKeys, ti)
+	topicKeys := make([]types.TopicID, 0, len(topics))
+	for i := 0; i < len(topics); i++ {
+		topicKeys = append(topicKeys, topics[i].Key)
 	}
 
 	for i := 0; i < len(topicKeys); i++ {
@@ -118,8 +139,8 @@ func (ms *MemoryStorage) PrintTree() {
 				for _, unitID := range sortedUnits {
 					unit := task.TaskUnits[unitID]
 					builder.WriteString(fmt.Sprintf("      - %s (%s)\n", unitID, unit.Status))
-					desc, _ := ms.GetTaskDefinition(unit.TaskDefinitionID) // Assuming this function exists and works
-					owner, _ := ms.GetOwner(desc.OwnerID)                  // Assuming this function exists and works
+					desc, _ := ms.GetTaskDefinitionVersion(unit.TaskDefinitionID, unit.DefinitionVersion)
+					owner, _ := ms.GetOwner(desc.OwnerID) // Assuming this function exists and works
 					builder.WriteString(fmt.Sprintf("        - %s (%s)\n", desc.Name, owner.Name))
 				}
 			}
@@ -184,8 +205,8 @@ func (ms *MemoryStorage) PrintDAG() {
 					indent := strings.Repeat("    ", depth+1) // Additional indentation for visual hierarchy.
 
 					// Fetch additional details like description and owner.
-					desc, errDesc := ms.GetTaskDefinition(unit.TaskDefinitionID) // Assuming this function exists and works.
-					owner, errOwner := ms.GetOwner(desc.OwnerID)                 // Assuming this function exists and works.
+					desc, errDesc := ms.GetTaskDefinitionVersion(unit.TaskDefinitionID, unit.DefinitionVersion)
+					owner, errOwner := ms.GetOwner(desc.OwnerID) // Assuming this function exists and works.
 
 					if errDesc != nil || errOwner != nil {
 						builder.WriteString(fmt.Sprintf("%s- %s (%s)\n", indent, unitID, unit.Status))
@@ -209,64 +230,70 @@ func (ms *MemoryStorage) PrintDAG() {
 	fmt.Println(builder.String())
 }
 
+// Storage of the default namespace, see `Namespace` for the others
 func NewMemoryStorage() *MemoryStorage {
-	return &MemoryStorage{
-		topics:      make(map[types.TopicID]*types.Topic),
-		jobs:        make(map[types.JobID]*types.Job),
-		tasks:       make(map[types.TaskID]*types.Task),
-		units:       make(map[types.TaskUnitID]*types.TaskUnit),
-		definitions: make(map[types.TaskDefinitionID]*types.TaskDefinition),
-		owners:      make(map[types.OwnerID]*types.Owner),
-	}
+	return &MemoryStorage{namespace: types.DefaultNamespace, store: &store{
+		topics:             make(map[types.TopicID]*types.Topic),
+		jobs:               make(map[types.JobID]*types.Job),
+		tasks:              make(map[types.TaskID]*types.Task),
+		units:              make(map[types.TaskUnitID]*types.TaskUnit),
+		definitions:        make(map[types.TaskDefinitionID]*types.TaskDefinition),
+		definitionVersions: make(map[types.TaskDefinitionID][]types.TaskDefinition),
+		owners:             make(map[types.OwnerID]*types.Owner),
+		templates:          make(map[types.TemplateID]*types.Template),
+		limits:             make(map[limitScope]int),
+		schedules:          make(map[types.ScheduleID]*types.Schedule),
+		webhooks:           make(map[types.WebhookID]*types.Webhook),
+		deliveries:         make(map[types.DeliveryID]*types.Delivery),
+		deliveryEvents:     make(map[deliveryEvent]bool),
+	}}
 }
 
 func (ms *MemoryStorage) NewUUID() (string, error) {
 	return types.GenerateUUID(), nil
 }
 
-// TODO (@droman): too lazy to implement the params but it's here
+// Units are ordered by priority then age, the aging of the params is applied, the concurrency limits cap how many are offered
+// TODO (@droman): too lazy to implement the offset and size but it's here
 func (ms *MemoryStorage) GetInbox(ownerID types.OwnerID, params *types.QueryParams) ([]types.InboxAllTaskUnit, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
 	var err error
 	var inboxUnits []types.InboxAllTaskUnit
+	ranks := []inboxRank{}
+	now := time.Now()
 
-	definitionIDs := []types.TaskDefinitionID{}
-	definitions := map[types.TaskDefinitionID]types.TaskDefinition{}
+	definitions := map[pinnedDefinition]types.TaskDefinition{}
 
-	for _, def := range ms.definitions {
-		if def.OwnerID == ownerID {
-			definitionIDs = append(definitionIDs, def.Key)
-		}
-	}
+	owners := ms.inboxOwners(ownerID)
 
 	watchTasksForOwner := []*types.Task{}
 
 	taskKeys := make([]types.TaskID, 0, len(ms.tasks))
-	for ti := range ms.tasks {
-		taskKeys = append(taskKeys, ti)
+	for ti, task := range ms.tasks {
+		if ms.sees(task.Namespace) {
+			taskKeys = append(taskKeys, ti)
+		}
 	}
 
 	for i := 0; i < len(taskKeys); i++ {
 		add := false
 		for _, unit := range ms.tasks[taskKeys[i]].TaskUnits {
-			for i := 0; i < len(definitionIDs); i++ {
-				if unit.TaskDefinitionID == definitionIDs[i] {
-					add = true
-				}
+			if owners[ms.unitOwner(unit)] || ms.awaitsReview(unit, ownerID) {
+				add = true
 			}
 		}
 		if add {
 			for _, unit := range ms.tasks[taskKeys[i]].TaskUnits {
-				definitions[unit.TaskDefinitionID] = *ms.definitions[unit.TaskDefinitionID]
+				definitions[pinnedDefinition{unit.TaskDefinitionID, unit.DefinitionVersion}] = *ms.unitDefinition(unit)
 			}
 			watchTasksForOwner = append(watchTasksForOwner, ms.tasks[taskKeys[i]])
 		}
 
 	}
 
-	defsKeys := make([]types.TaskDefinitionID, 0, len(definitions))
+	defsKeys := make([]pinnedDefinition, 0, len(definitions))
 	for tdi := range definitions {
 		defsKeys = append(defsKeys, tdi)
 	}
@@ -278,10 +305,11 @@ func (ms *MemoryStorage) GetInbox(ownerID types.OwnerID, params *types.QueryPara
 
 	for idxTask := 0; idxTask < len(watchTasksForOwner); idxTask++ {
 
+		// drafts, a task without job or a job without topic, aren't offered yet
 		if len(watchTasksForOwner[idxTask].JobID) == 0 {
-			return nil, fmt.Errorf("critical error a task without JobID")
+			continue
 		}
-		if _, ok := ms.jobs[watchTasksForOwner[idxTask].JobID]; !ok {
+		if job, ok := ms.job(watchTasksForOwner[idxTask].JobID); !ok || job.TopicID == "" || ms.jobWaiting(job) || ms.taskClosed(watchTasksForOwner[idxTask]) {
 			continue
 		}
 		units := []types.TaskUnit{}
@@ -297,69 +325,73 @@ func (ms *MemoryStorage) GetInbox(ownerID types.OwnerID, params *types.QueryPara
 			return nil, err
 		}
 
-		workOwner := []types.TaskUnit{}
-		workAvailable := dag.AvailableNodeUnitWithOwner(ownerID)
-
-		// this make no sense, you don't have a jobid
-		for _, v := range workAvailable {
-			workOwner = append(workOwner, *v.Unit)
-		}
+		workOwner := ms.availableForOwner(dag, ownerID, owners)
 		if len(workOwner) > 0 {
+			rank := ms.rankInboxUnits(watchTasksForOwner[idxTask].Key, workOwner, params, now)
+			ranks = append(ranks, rank)
 			inboxUnits = append(inboxUnits, types.InboxAllTaskUnit{
 				TopicID:   ms.jobs[watchTasksForOwner[idxTask].JobID].TopicID,
 				JobID:     watchTasksForOwner[idxTask].JobID,
 				TaskID:    watchTasksForOwner[idxTask].Key,
 				TaskUnits: workOwner,
+				Priority:  rank.priority,
 			})
 		}
 	}
 
-	return inboxUnits, nil
+	sorted := make([]types.InboxAllTaskUnit, 0, len(inboxUnits))
+	counts := ms.activeCounts()
+	for _, idx := range inboxOrder(ranks) {
+		entry := inboxUnits[idx]
+		if entry.TaskUnits = ms.offerWithinLimits(entry.TaskUnits, counts); len(entry.TaskUnits) > 0 {
+			sorted = append(sorted, entry)
+		}
+	}
+
+	return sorted, nil
 }
 
-// TODO (@droman): too lazy to implement the params but it's here
+// Units are ordered by priority then age, the aging of the params is applied, the concurrency limits cap how many are offered
+// TODO (@droman): too lazy to implement the offset and size but it's here
 func (ms *MemoryStorage) GetInboxTopic(ownerID types.OwnerID, topicID types.TopicID, params *types.QueryParams) ([]types.InboxTopicTaskUnit, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
 	var err error
 	var inboxUnits []types.InboxTopicTaskUnit
+	ranks := []inboxRank{}
+	now := time.Now()
 
-	definitionIDs := []types.TaskDefinitionID{}
-	definitions := map[types.TaskDefinitionID]types.TaskDefinition{}
+	definitions := map[pinnedDefinition]types.TaskDefinition{}
 
-	for _, def := range ms.definitions {
-		if def.OwnerID == ownerID {
-			definitionIDs = append(definitionIDs, def.Key)
-		}
-	}
+	owners := ms.inboxOwners(ownerID)
 
 	watchTasksForOwner := []*types.Task{}
 
 	taskKeys := make([]types.TaskID, 0, len(ms.tasks))
-	for ti := range ms.tasks {
-		taskKeys = append(taskKeys, ti)
+	for ti, task := range ms.tasks {
+		if ms.sees(task.Namespace) {
+			taskKeys = append(taskKeys, ti)
+		}
 	}
 
 	for i := 0; i < len(taskKeys); i++ {
 		add := false
 		for _, unit := range ms.tasks[taskKeys[i]].TaskUnits {
-			for i := 0; i < len(definitionIDs); i++ {
-				if unit.TaskDefinitionID == definitionIDs[i] {
-					add = true
-				}
+			if owners[ms.unitOwner(unit)] || ms.awaitsReview(unit, ownerID) {
+				add = true
 			}
 		}
 		if add {
 			for _, unit := range ms.tasks[taskKeys[i]].TaskUnits {
-				definitions[unit.TaskDefinitionID] = *ms.definitions[unit.TaskDefinitionID]
+				definitions[pinnedDefinition{unit.TaskDefinitionID, unit.DefinitionVersion}] = *ms.unitDefinition(unit)
 			}
 			watchTasksForOwner = append(watchTasksForOwner, ms.tasks[taskKeys[i]])
 		}
 
 	}
 
-	defsKeys := make([]types.TaskDefinitionID, 0, len(definitions))
+	defsKeys := make([]pinnedDefinition, 0, len(definitions))
 	for tdi := range definitions {
 		defsKeys = append(defsKeys, tdi)
 	}
@@ -372,9 +404,9 @@ func (ms *MemoryStorage) GetInboxTopic(ownerID types.OwnerID, topicID types.Topi
 	for idxTask := 0; idxTask < len(watchTasksForOwner); idxTask++ {
 
 		if len(watchTasksForOwner[idxTask].JobID) == 0 {
-			return nil, fmt.Errorf("critical error a task without JobID")
+			continue
 		}
-		if _, ok := ms.jobs[watchTasksForOwner[idxTask].JobID]; !ok {
+		if job, ok := ms.job(watchTasksForOwner[idxTask].JobID); !ok || job.TopicID == "" || job.TopicID != topicID || ms.jobWaiting(job) || ms.taskClosed(watchTasksForOwner[idxTask]) {
 			continue
 		}
 		units := []types.TaskUnit{}
@@ -390,32 +422,40 @@ func (ms *MemoryStorage) GetInboxTopic(ownerID types.OwnerID, topicID types.Topi
 			return nil, err
 		}
 
-		workOwner := []types.TaskUnit{}
-		workAvailable := dag.AvailableNodeUnitWithOwner(ownerID)
-
-		// this make no sense, you don't have a jobid
-		for _, v := range workAvailable {
-			workOwner = append(workOwner, *v.Unit)
-		}
+		workOwner := ms.availableForOwner(dag, ownerID, owners)
 		if len(workOwner) > 0 {
-			inboxUnits = append(inboxUnits, types.InboxAllTaskUnit{
-				TopicID:   ms.jobs[watchTasksForOwner[idxTask].JobID].TopicID,
+			rank := ms.rankInboxUnits(watchTasksForOwner[idxTask].Key, workOwner, params, now)
+			ranks = append(ranks, rank)
+			inboxUnits = append(inboxUnits, types.InboxTopicTaskUnit{
 				JobID:     watchTasksForOwner[idxTask].JobID,
 				TaskID:    watchTasksForOwner[idxTask].Key,
 				TaskUnits: workOwner,
+				Priority:  rank.priority,
 			})
 		}
 	}
 
-	return inboxUnits, nil
+	sorted := make([]types.InboxTopicTaskUnit, 0, len(inboxUnits))
+	counts := ms.activeCounts()
+	for _, idx := range inboxOrder(ranks) {
+		entry := inboxUnits[idx]
+		if entry.TaskUnits = ms.offerWithinLimits(entry.TaskUnits, counts); len(entry.TaskUnits) > 0 {
+			sorted = append(sorted, entry)
+		}
+	}
+
+	return sorted, nil
 }
 
 // Helper function to find the job ID associated with a task unit.
 func (ms *MemoryStorage) findJobIDByTaskUnit(taskUnitID types.TaskUnitID) types.JobID {
 	for _, job := range ms.jobs {
+		if !ms.sees(job.Namespace) {
+			continue
+		}
 		for _, taskID := range job.TaskIDs {
-			task, err := ms.GetTask(taskID)
-			if err != nil {
+			task, exists := ms.task(taskID)
+			if !exists {
 				continue
 			}
 			if _, ok := task.TaskUnits[taskUnitID]; ok {
@@ -429,7 +469,7 @@ func (ms *MemoryStorage) findJobIDByTaskUnit(taskUnitID types.TaskUnitID) types.
 // Helper function to find the task ID associated with a task unit.
 func (ms *MemoryStorage) findTaskIDByTaskUnit(taskUnitID types.TaskUnitID) types.TaskID {
 	for _, task := range ms.tasks {
-		if _, ok := task.TaskUnits[taskUnitID]; ok {
+		if _, ok := task.TaskUnits[taskUnitID]; ok && ms.sees(task.Namespace) {
 			return task.Key
 		}
 	}
@@ -438,13 +478,16 @@ func (ms *MemoryStorage) findTaskIDByTaskUnit(taskUnitID types.TaskUnitID) types
 
 // Assign a drafted `Job` to a `Topic` for processing
 func (ms *MemoryStorage) AssignJob(topicID types.TopicID, jobID types.JobID) error {
-	ms.mu.RLock()
-	defer ms.mu.RUnlock()
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 
-	if _, exists := ms.topics[topicID]; !exists {
+	if _, exists := ms.topic(topicID); !exists {
 		return fmt.Errorf("topic %v doesn't exists", topicID)
 	}
-	if _, exists := ms.jobs[jobID]; !exists {
+	if ms.topics[topicID].Deprecated {
+		return fmt.Errorf("%w: %v", types.ErrTopicDeprecated, topicID)
+	}
+	if _, exists := ms.job(jobID); !exists {
 		return fmt.Errorf("job %v doesn't exists", jobID)
 	}
 
@@ -454,18 +497,21 @@ func (ms *MemoryStorage) AssignJob(topicID types.TopicID, jobID types.JobID) err
 			types.WithTopicJobIDs(jobID),
 			types.WithTopicJobs(ms.jobs[jobID]))
 
+	ms.jobs[jobID].
+		Mutate(types.WithJobTaskID(topicID))
+
 	return nil
 }
 
 // Assign a drafted `Task` to a `Job` for processing
 func (ms *MemoryStorage) AssignTask(jobID types.JobID, taskID types.TaskID) error {
-	ms.mu.RLock()
-	defer ms.mu.RUnlock()
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 
-	if _, exists := ms.tasks[taskID]; !exists {
+	if _, exists := ms.task(taskID); !exists {
 		return fmt.Errorf("task %v doesn't exists", taskID)
 	}
-	if _, exists := ms.jobs[jobID]; !exists {
+	if _, exists := ms.job(jobID); !exists {
 		return fmt.Errorf("job %v doesn't exists", jobID)
 	}
 
@@ -484,14 +530,14 @@ func (ms *MemoryStorage) AssignTask(jobID types.JobID, taskID types.TaskID) erro
 
 // Assign a drafted `TaskUnit` to a `Task` for processing
 func (ms *MemoryStorage) AssignTaskUnits(taskID types.TaskID, ids []types.TaskUnitID) error {
-	ms.mu.RLock()
-	defer ms.mu.RUnlock()
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 
-	if _, exists := ms.tasks[taskID]; !exists {
+	if _, exists := ms.task(taskID); !exists {
 		return fmt.Errorf("task %v doesn't exists", taskID)
 	}
 	for i := 0; i < len(ids); i++ {
-		if _, exists := ms.units[ids[i]]; !exists {
+		if _, exists := ms.unit(ids[i]); !exists {
 			return fmt.Errorf("unit %v doesn't exists", ids[i])
 		}
 	}
@@ -514,7 +560,7 @@ func (ms *MemoryStorage) GetOwner(ownerID types.OwnerID) (*types.Owner, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
-	owner, exists := ms.owners[ownerID]
+	owner, exists := ms.owner(ownerID)
 	if !exists {
 		return nil, fmt.Errorf("owner with ID %s not found", ownerID)
 	}
@@ -526,7 +572,7 @@ func (ms *MemoryStorage) GetTaskDefinition(id types.TaskDefinitionID) (*types.Ta
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
-	unitDesc, exists := ms.definitions[id]
+	unitDesc, exists := ms.definition(id)
 	if !exists {
 		return nil, fmt.Errorf("unit description with ID %s not found", id)
 	}
@@ -540,7 +586,7 @@ func (ms *MemoryStorage) CreateUnitOnTask(taskID types.TaskID, units []*types.Ta
 	defer ms.mu.Unlock()
 
 	// Retrieve the task
-	_, exists := ms.tasks[taskID]
+	_, exists := ms.task(taskID)
 	if !exists {
 		return fmt.Errorf("task with ID %s does not exist", taskID)
 	}
@@ -556,6 +602,7 @@ func (ms *MemoryStorage) CreateUnitOnTask(taskID types.TaskID, units []*types.Ta
 		ids = append(ids, units[i].Key)
 
 		// Add the TaskUnit to the storage
+		units[i].Namespace = ms.namespace
 		ms.units[units[i].Key] = units[i]
 	}
 
@@ -578,7 +625,7 @@ func (ms *MemoryStorage) CreateTasksOnJob(jobID types.JobID, tasks []types.Task)
 	defer ms.mu.Unlock()
 
 	// Retrieve the job
-	job, exists := ms.jobs[jobID]
+	job, exists := ms.job(jobID)
 	if !exists {
 		return fmt.Errorf("job with ID %s does not exist", jobID)
 	}
@@ -591,6 +638,7 @@ func (ms *MemoryStorage) CreateTasksOnJob(jobID types.JobID, tasks []types.Task)
 		// Set job and description
 		task.JobID = jobID
 		task.Key = taskID
+		task.Namespace = ms.namespace
 
 		// Initialize TaskUnits map
 		task.TaskUnits = make(map[types.TaskUnitID]*types.TaskUnit)
@@ -611,7 +659,7 @@ func (s *MemoryStorage) HasTopic(id types.TopicID) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, exists := s.topics[id]
+	_, exists := s.topic(id)
 	return exists, nil
 }
 
@@ -620,7 +668,7 @@ func (s *MemoryStorage) HasJob(id types.JobID) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, exists := s.jobs[id]
+	_, exists := s.job(id)
 	return exists, nil
 }
 
@@ -629,7 +677,7 @@ func (s *MemoryStorage) HasTask(id types.TaskID) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, exists := s.tasks[id]
+	_, exists := s.task(id)
 	return exists, nil
 }
 
@@ -640,7 +688,9 @@ func (ms *MemoryStorage) GetOwners() ([]types.Owner, error) {
 
 	owners := make([]types.Owner, 0, len(ms.owners))
 	for _, owner := range ms.owners {
-		owners = append(owners, *owner)
+		if ms.sees(owner.Namespace) {
+			owners = append(owners, *owner)
+		}
 	}
 
 	return owners, nil
@@ -653,7 +703,9 @@ func (ms *MemoryStorage) GetTaskDefinitions() ([]types.TaskDefinition, error) {
 
 	unitDescriptions := make([]types.TaskDefinition, 0, len(ms.definitions))
 	for _, unitDescription := range ms.definitions {
-		unitDescriptions = append(unitDescriptions, *unitDescription)
+		if ms.sees(unitDescription.Namespace) {
+			unitDescriptions = append(unitDescriptions, *unitDescription)
+		}
 	}
 
 	return unitDescriptions, nil
@@ -664,7 +716,7 @@ func (s *MemoryStorage) HasTaskUnit(id types.TaskUnitID) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, exists := s.units[id]
+	_, exists := s.unit(id)
 	return exists, nil
 }
 
@@ -673,7 +725,7 @@ func (s *MemoryStorage) HasTaskDefinition(id types.TaskDefinitionID) (bool, erro
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, exists := s.definitions[id]
+	_, exists := s.definition(id)
 	return exists, nil
 }
 
@@ -682,7 +734,7 @@ func (s *MemoryStorage) HasOwner(id types.OwnerID) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	_, exists := s.owners[id]
+	_, exists := s.owner(id)
 	return exists, nil
 }
 
@@ -705,24 +757,32 @@ func (ms *MemoryStorage) CreateOwner(name string, cfgs ...types.OwnerConfig) (*t
 	}
 	// we don't want the same name
 	for _, v := range ms.owners {
-		if v.Name == name {
+		if v.Name == name && ms.sees(v.Namespace) {
 			return nil, types.ErrOwnerNameAlreadyExists
 		}
 	}
 
+	owner.Namespace = ms.namespace
 	ms.owners[owner.Key] = owner
 	return owner, nil
 }
 
 func (ms *MemoryStorage) CreateTaskUnits(units []*types.TaskUnit) ([]types.TaskUnitID, error) {
-	ms.mu.RLock()
-	defer ms.mu.RUnlock()
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
 
+	for i := 0; i < len(units); i++ {
+		if err := ms.checkNewUnit(units[i]); err != nil {
+			return nil, err
+		}
+	}
 	ids := []types.TaskUnitID{}
 	for i := 0; i < len(units); i++ {
 		if _, exists := ms.units[units[i].Key]; exists {
 			return nil, types.ErrOwnerIDAlreadyExists
 		}
+		ms.pinDefinition(units[i])
+		units[i].Namespace = ms.namespace
 		ms.units[units[i].Key] = units[i]
 		ids = append(ids, units[i].Key)
 	}
@@ -735,7 +795,7 @@ func (ms *MemoryStorage) UpdateOwner(ownerID types.OwnerID, name string) (*types
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	owner, exists := ms.owners[ownerID]
+	owner, exists := ms.owner(ownerID)
 	if !exists {
 		return nil, fmt.Errorf("not found")
 	}
@@ -744,17 +804,17 @@ func (ms *MemoryStorage) UpdateOwner(ownerID types.OwnerID, name string) (*types
 	return owner, nil
 }
 
-// DeprecateOwner deprecates an owner by ID
+// DeprecateOwner deprecates an owner by ID, it stays for the units it already has
 func (ms *MemoryStorage) DeprecateOwner(ownerID types.OwnerID) (*types.Owner, error) {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	owner, exists := ms.owners[ownerID]
+	owner, exists := ms.owner(ownerID)
 	if !exists {
 		return nil, fmt.Errorf("not found")
 	}
 
-	delete(ms.owners, ownerID)
+	owner.Deprecated = true
 	return owner, nil
 }
 
@@ -769,6 +829,10 @@ func (ms *MemoryStorage) CreateTaskDefinition(name string, ownerID types.OwnerID
 		return nil, err
 	}
 
+	if err = ms.checkOwner(ownerID); err != nil {
+		return nil, err
+	}
+
 	unitDescription := types.NewUnitDescription(types.TaskDefinitionID(uuid), name, ownerID, cfgs...)
 
 	// we don't want the same id
@@ -777,58 +841,73 @@ func (ms *MemoryStorage) CreateTaskDefinition(name string, ownerID types.OwnerID
 	}
 	// we don't want the same name
 	for _, v := range ms.definitions {
-		if v.Name == name {
+		if v.Name == name && ms.sees(v.Namespace) {
 			return nil, types.ErrOwnerNameAlreadyExists
 		}
 	}
 
+	unitDescription.Namespace = ms.namespace
 	ms.definitions[unitDescription.Key] = unitDescription
+	ms.definitionVersions[unitDescription.Key] = []types.TaskDefinition{*unitDescription}
 	return unitDescription, nil
 }
 
-// UpdateTaskDefinition updates an existing unit description by ID.
+// UpdateTaskDefinition saves the next version of a unit description
 func (s *MemoryStorage) UpdateTaskDefinition(id types.TaskDefinitionID, ownerID types.OwnerID, name string, description string, identifier string) (*types.TaskDefinition, error) {
-	unitDesc, exists := s.definitions[id]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unitDesc, exists := s.definition(id)
 	if !exists {
 		return nil, errors.New("unit description not found")
 	}
+	if unitDesc.Deprecated {
+		return nil, fmt.Errorf("%w: %v", types.ErrTaskDefinitionDeprecated, id)
+	}
 
 	// Check if the owner is the same.
 	if unitDesc.OwnerID != ownerID {
 		return nil, errors.New("invalid owner for unit description")
 	}
 
-	unitDesc.Name = name
-	unitDesc.Details = description
-	unitDesc.Identifier = identifier
+	next := *unitDesc
+	next.Name = name
+	next.Details = description
+	next.Identifier = identifier
+	next.Version = len(s.definitionVersions[id]) + 1
+	next.CreatedAt = time.Now()
 
-	s.definitions[id] = unitDesc
-	return unitDesc, nil
+	s.definitions[id] = &next
+	s.definitionVersions[id] = append(s.definitionVersions[id], next)
+	return &next, nil
 }
 
-// DeprecateTaskDefinition deprecates a unit description by ID.
+// DeprecateTaskDefinition deprecates every version of a unit description, the units keep reading them
 func (s *MemoryStorage) DeprecateTaskDefinition(id types.TaskDefinitionID) error {
-	_, exists := s.definitions[id]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unitDesc, exists := s.definition(id)
 	if !exists {
 		return errors.New("unit description not found")
 	}
 
-	// Check if the unit description is associated with any task units.
-	for _, taskUnit := range s.units {
-		if taskUnit.TaskDefinitionID == id {
-			return errors.New("cannot deprecate unit description associated with task unit(s)")
-		}
+	unitDesc.Deprecated = true
+	versions := s.definitionVersions[id]
+	for i := 0; i < len(versions); i++ {
+		versions[i].Deprecated = true
 	}
-
-	delete(s.definitions, id)
 	return nil
 }
 
 // CancelJob cancels a job by ID.
 func (s *MemoryStorage) CancelJob(jobID types.JobID) error {
-	job, exists := s.jobs[jobID]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.job(jobID)
 	if !exists {
-		return errors.New("job not found")
+		return fmt.Errorf("%w: %v", types.ErrJobNotFound, jobID)
 	}
 
 	// Set the status of the job to "canceled."
@@ -837,13 +916,13 @@ func (s *MemoryStorage) CancelJob(jobID types.JobID) error {
 
 	// Update the status of all task units in the job.
 	for _, taskID := range job.TaskIDs {
-		task, exists := s.tasks[taskID]
+		task, exists := s.task(taskID)
 		if !exists {
 			continue
 		}
 
 		for _, taskUnitID := range task.TaskUnitIDs {
-			taskUnit, exists := s.units[taskUnitID]
+			taskUnit, exists := s.unit(taskUnitID)
 			if !exists {
 				continue
 			}
@@ -861,7 +940,10 @@ func (s *MemoryStorage) CancelJob(jobID types.JobID) error {
 
 // CancelTask cancels a task by ID.
 func (s *MemoryStorage) CancelTask(taskID types.TaskID) error {
-	task, exists := s.tasks[taskID]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, exists := s.task(taskID)
 	if !exists {
 		return errors.New("task not found")
 	}
@@ -872,7 +954,7 @@ func (s *MemoryStorage) CancelTask(taskID types.TaskID) error {
 
 	// Update the status of all task units in the task.
 	for _, taskUnitID := range task.TaskUnitIDs {
-		taskUnit, exists := s.units[taskUnitID]
+		taskUnit, exists := s.unit(taskUnitID)
 		if !exists {
 			continue
 		}
@@ -906,11 +988,12 @@ func (ms *MemoryStorage) CreateTopic(name string, cfgs ...types.TopicConfig) (*t
 	}
 	// we don't want the same name
 	for _, v := range ms.topics {
-		if v.Name == name {
+		if v.Name == name && ms.sees(v.Namespace) {
 			return nil, types.ErrTopicNameAlreadyExists
 		}
 	}
 
+	topic.Namespace = ms.namespace
 	ms.topics[topic.Key] = topic
 	return topic, nil
 }
@@ -932,6 +1015,7 @@ func (ms *MemoryStorage) CreateJob(cfgs ...types.JobConfig) (*types.Job, error)
 		return nil, types.ErrTopicIDAlreadyExists
 	}
 
+	job.Namespace = ms.namespace
 	ms.jobs[job.Key] = job
 
 	return job, nil
@@ -955,6 +1039,7 @@ func (ms *MemoryStorage) CreateTask(cfgs ...types.TaskConfig) (*types.Task, erro
 		return nil, types.ErrOwnerIDAlreadyExists
 	}
 
+	task.Namespace = ms.namespace
 	ms.tasks[task.Key] = task
 	return task, nil
 }
@@ -979,6 +1064,7 @@ func (ms *MemoryStorage) CreateTaskUnit(cfgs ...types.TaskUnitConfig) (*types.Ta
 	}
 
 	// Add the TaskUnit to the storage
+	taskUnit.Namespace = ms.namespace
 	ms.units[taskUnit.Key] = taskUnit
 
 	return taskUnit, nil
@@ -989,14 +1075,14 @@ func (m *MemoryStorage) GetTasks(jobID types.JobID) ([]types.Task, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	job, ok := m.jobs[jobID]
+	job, ok := m.job(jobID)
 	if !ok {
 		return nil, fmt.Errorf("job with ID %s not found", jobID)
 	}
 
 	tasks := make([]types.Task, 0, len(job.TaskIDs))
 	for _, taskID := range job.TaskIDs {
-		task, ok := m.tasks[taskID]
+		task, ok := m.task(taskID)
 		if !ok {
 			return nil, fmt.Errorf("task with ID %s not found", taskID)
 		}
@@ -1007,16 +1093,15 @@ func (m *MemoryStorage) GetTasks(jobID types.JobID) ([]types.Task, error) {
 }
 
 func (ms *MemoryStorage) GetTopics() ([]types.Topic, error) {
-	// Implement the logic to retrieve all topics from memory.
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	// `Topic.Jobs` is kept up to date by `AssignJob`
 	topics := make([]types.Topic, 0)
 	for _, topic := range ms.topics {
-		for jid, value := range ms.jobs {
-			if topic.Key == value.TopicID {
-				job, _ := ms.GetJob(jid)
-				topic.Jobs[jid] = job
-			}
+		if ms.sees(topic.Namespace) {
+			topics = append(topics, *topic)
 		}
-		topics = append(topics, *topic)
 	}
 	return topics, nil
 }
@@ -1053,7 +1138,7 @@ func (ms *MemoryStorage) GetTopic(id types.TopicID) (*types.Topic, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
-	topic, exists := ms.topics[id]
+	topic, exists := ms.topic(id)
 	if !exists {
 		return nil, errors.New("topic not found")
 	}
@@ -1066,7 +1151,7 @@ func (ms *MemoryStorage) UpdateTopic(id types.TopicID, name string) (*types.Topi
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	topic, exists := ms.topics[id]
+	topic, exists := ms.topic(id)
 	if !exists {
 		return nil, errors.New("topic not found")
 	}
@@ -1081,16 +1166,11 @@ func (ms *MemoryStorage) DeprecateTopic(id types.TopicID) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	_, exists := ms.topics[id]
+	topic, exists := ms.topic(id)
 	if !exists {
 		return errors.New("topic not found")
 	}
 
-	topic, err := ms.GetTopic(id)
-	if err != nil {
-		return err
-	}
-
 	topic.Deprecated = true
 
 	return nil
@@ -1100,18 +1180,12 @@ func (ms *MemoryStorage) GetJob(jobID types.JobID) (*types.Job, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
-	job, exists := ms.jobs[jobID]
+	job, exists := ms.job(jobID)
 	if !exists {
-		return nil, errors.New("job not found")
-	}
-
-	for _, v := range ms.tasks {
-		if v.JobID == jobID {
-			task, _ := ms.GetTask(v.Key)
-			job.Tasks[v.Key] = task
-		}
+		return nil, fmt.Errorf("%w: %v", types.ErrJobNotFound, jobID)
 	}
 
+	// `Job.Tasks` is kept up to date by `AssignTask`
 	return job, nil
 }
 
@@ -1119,18 +1193,12 @@ func (ms *MemoryStorage) GetTask(taskID types.TaskID) (*types.Task, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
-	task, exists := ms.tasks[taskID]
+	task, exists := ms.task(taskID)
 	if !exists {
 		return nil, errors.New("task not found")
 	}
 
-	for _, v := range ms.units {
-		if v.TaskID == taskID {
-			unit, _ := ms.GetTaskUnit(v.Key)
-			task.TaskUnits[v.Key] = unit
-		}
-	}
-
+	// `Task.TaskUnits` is kept up to date by `AssignTaskUnits`
 	return task, nil
 }
 
@@ -1138,7 +1206,7 @@ func (ms *MemoryStorage) GetTaskUnit(taskUnitID types.TaskUnitID) (*types.TaskUn
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
-	unit, exists := ms.units[taskUnitID]
+	unit, exists := ms.unit(taskUnitID)
 	if !exists {
 		return nil, errors.New("task unit not found")
 	}
@@ -1150,9 +1218,9 @@ func (ms *MemoryStorage) UpdateJobStatus(jobID types.JobID, status types.StatusT
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	job, exists := ms.jobs[jobID]
+	job, exists := ms.job(jobID)
 	if !exists {
-		return errors.New("job not found")
+		return fmt.Errorf("%w: %v", types.ErrJobNotFound, jobID)
 	}
 
 	job.Status = status
@@ -1163,7 +1231,7 @@ func (ms *MemoryStorage) UpdateTaskStatus(taskID types.TaskID, status types.Stat
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	task, exists := ms.tasks[taskID]
+	task, exists := ms.task(taskID)
 	if !exists {
 		return errors.New("task not found")
 	}
@@ -1176,25 +1244,80 @@ func (ms *MemoryStorage) UpdateTaskUnitStatus(taskUnitID types.TaskUnitID, statu
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
-	unit, exists := ms.units[taskUnitID]
+	unit, exists := ms.unit(taskUnitID)
 	if !exists {
 		return errors.New("task unit not found")
 	}
+	if ms.unitClosed(unit) {
+		return fmt.Errorf("%w: %v", types.ErrTaskUnitClosed, taskUnitID)
+	}
+
+	if types.IsActiveStatus(status) && !types.IsActiveStatus(unit.Status) {
+		if limitErr := ms.withinLimits(unit, ms.activeCounts()); limitErr != nil {
+			return limitErr
+		}
+	}
 
 	unit.Status = status
 	unit.Error = err
 
-	fmt.Println(unit.Key)
+	return nil
+}
+
+func (ms *MemoryStorage) MarkTaskUnitAvailable(taskUnitID types.TaskUnitID, at time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	unit, exists := ms.unit(taskUnitID)
+	if !exists {
+		return errors.New("task unit not found")
+	}
+	if unit.AvailableAt.IsZero() {
+		unit.AvailableAt = at
+	}
+	return nil
+}
+
+// AddTaskUnitCommand records a command on a task unit.
+func (ms *MemoryStorage) AddTaskUnitCommand(taskUnitID types.TaskUnitID, cmd types.Command) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	unit, exists := ms.unit(taskUnitID)
+	if !exists {
+		return errors.New("task unit not found")
+	}
+	if ms.unitClosed(unit) {
+		return fmt.Errorf("%w: %v", types.ErrTaskUnitClosed, taskUnitID)
+	}
+
+	unit.Commands = append(unit.Commands, cmd)
 
 	return nil
 }
 
+// The task or the job failed or was canceled, its units can't change anymore
+// Must be called with the lock held
+func (ms *MemoryStorage) taskClosed(task *types.Task) bool {
+	if task.Status == types.ErrorStatus {
+		return true
+	}
+	job, exists := ms.job(task.JobID)
+	return exists && job.Status == types.ErrorStatus
+}
+
+// Must be called with the lock held
+func (ms *MemoryStorage) unitClosed(unit *types.TaskUnit) bool {
+	task, exists := ms.task(unit.TaskID)
+	return exists && ms.taskClosed(task)
+}
+
 // GetJobs retrieves all jobs for a topic.
 func (ms *MemoryStorage) GetJobs(topicID types.TopicID) ([]types.Job, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
-	topic, exists := ms.topics[topicID]
+	topic, exists := ms.topic(topicID)
 	if !exists {
 		return nil, fmt.Errorf("topic not found")
 	}
@@ -1212,7 +1335,7 @@ func (ms *MemoryStorage) GetTaskUnits(taskID types.TaskID) ([]types.TaskUnit, er
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
-	task, exists := ms.tasks[taskID]
+	task, exists := ms.task(taskID)
 	if !exists {
 		return nil, fmt.Errorf("task not found")
 	}
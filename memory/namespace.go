@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Every entity records the `Namespace` of the view that created it and a view only finds the entities of its own namespace
+/// The entities of another namespace are reported as not found and their names don't collide with the ones of the view
+///
+///	storage := memory.NewMemoryStorage()
+///	namespaces := junjo.NewNamespaces(func(namespace types.Namespace) (types.StorageInterface, error) {
+///		return storage.Namespace(namespace), nil
+///	})
+
+// View of the same content for another namespace
+func (ms *MemoryStorage) Namespace(namespace types.Namespace) *MemoryStorage {
+	return &MemoryStorage{store: ms.store, namespace: namespace}
+}
+
+// Whether an entity of that namespace belongs to the view
+func (ms *MemoryStorage) sees(namespace types.Namespace) bool {
+	return namespace == ms.namespace
+}
+
+func (ms *MemoryStorage) topic(id types.TopicID) (*types.Topic, bool) {
+	topic, exists := ms.topics[id]
+	return topic, exists && ms.sees(topic.Namespace)
+}
+
+func (ms *MemoryStorage) job(id types.JobID) (*types.Job, bool) {
+	job, exists := ms.jobs[id]
+	return job, exists && ms.sees(job.Namespace)
+}
+
+func (ms *MemoryStorage) task(id types.TaskID) (*types.Task, bool) {
+	task, exists := ms.tasks[id]
+	return task, exists && ms.sees(task.Namespace)
+}
+
+func (ms *MemoryStorage) unit(id types.TaskUnitID) (*types.TaskUnit, bool) {
+	unit, exists := ms.units[id]
+	return unit, exists && ms.sees(unit.Namespace)
+}
+
+func (ms *MemoryStorage) definition(id types.TaskDefinitionID) (*types.TaskDefinition, bool) {
+	definition, exists := ms.definitions[id]
+	return definition, exists && ms.sees(definition.Namespace)
+}
+
+func (ms *MemoryStorage) owner(id types.OwnerID) (*types.Owner, bool) {
+	owner, exists := ms.owners[id]
+	return owner, exists && ms.sees(owner.Namespace)
+}
+
+func (ms *MemoryStorage) template(id types.TemplateID) (*types.Template, bool) {
+	template, exists := ms.templates[id]
+	return template, exists && ms.sees(template.Namespace)
+}
+
+func (ms *MemoryStorage) schedule(id types.ScheduleID) (*types.Schedule, bool) {
+	schedule, exists := ms.schedules[id]
+	return schedule, exists && ms.sees(schedule.Namespace)
+}
+
+func (ms *MemoryStorage) webhook(id types.WebhookID) (*types.Webhook, bool) {
+	webhook, exists := ms.webhooks[id]
+	return webhook, exists && ms.sees(webhook.Namespace)
+}
+
+func (ms *MemoryStorage) delivery(id types.DeliveryID) (*types.Delivery, bool) {
+	delivery, exists := ms.deliveries[id]
+	return delivery, exists && ms.sees(delivery.Namespace)
+}
@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+func (ms *MemoryStorage) SetOwnerMembers(ownerID types.OwnerID, members []types.OwnerID) (*types.Owner, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	owner, exists := ms.owner(ownerID)
+	if !exists {
+		return nil, fmt.Errorf("owner with ID %s not found", ownerID)
+	}
+	for i := 0; i < len(members); i++ {
+		if _, exists := ms.owner(members[i]); !exists {
+			return nil, fmt.Errorf("%w: member %v not found", types.ErrOwnerPoolInvalid, members[i])
+		}
+		if err := ms.checkOwner(members[i]); err != nil {
+			return nil, err
+		}
+	}
+	owner.Members = append([]types.OwnerID{}, members...)
+
+	value := *owner
+	return &value, nil
+}
+
+func (ms *MemoryStorage) ClaimTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	unit, exists := ms.unit(taskUnitID)
+	if !exists {
+		return fmt.Errorf("task unit with ID %s not found", taskUnitID)
+	}
+	// the caller read the unit before taking the lock, it may have started or finished since
+	if unit.Status != types.NoneStatus {
+		return types.ErrTaskUnitNotAvailable
+	}
+	if ms.unitClosed(unit) {
+		return fmt.Errorf("%w: %v", types.ErrTaskUnitClosed, taskUnitID)
+	}
+	if !unit.ClaimableBy(ownerID) {
+		return fmt.Errorf("%w: %v", types.ErrTaskUnitClaimed, unit.ClaimedBy)
+	}
+	if err := ms.withinLimits(unit, ms.activeCounts()); err != nil {
+		return err
+	}
+	unit.ClaimedBy = ownerID
+	return nil
+}
+
+func (ms *MemoryStorage) ReleaseTaskUnit(taskUnitID types.TaskUnitID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	unit, exists := ms.unit(taskUnitID)
+	if !exists {
+		return fmt.Errorf("task unit with ID %s not found", taskUnitID)
+	}
+	unit.ClaimedBy = ""
+	return nil
+}
+
+// The owner and the pools it's a member of, their units are offered to the owner
+func (ms *MemoryStorage) inboxOwners(ownerID types.OwnerID) map[types.OwnerID]bool {
+	owners := map[types.OwnerID]bool{ownerID: true}
+	for _, owner := range ms.owners {
+		if owner.HasMember(ownerID) && ms.sees(owner.Namespace) {
+			owners[owner.Key] = true
+		}
+	}
+	return owners
+}
+
+// Available units of the owner and of its pools, without the ones claimed by another member
+// The approval gates are only offered to the approvers who didn't review them
+func (ms *MemoryStorage) availableForOwner(dag *types.WorkUnitDag, ownerID types.OwnerID, owners map[types.OwnerID]bool) []types.TaskUnit {
+	units := []types.TaskUnit{}
+	for _, v := range dag.AvailableNodeUnit() {
+		unit := ms.units[v.Unit.Key]
+		if v.Definition != nil && v.Definition.Approval != nil {
+			if ms.awaitsReview(unit, ownerID) {
+				units = append(units, *unit)
+			}
+			continue
+		}
+		if owners[v.OwnerID()] && unit.ClaimableBy(ownerID) {
+			units = append(units, *unit)
+		}
+	}
+	return units
+}
+
+// The unit is an approval gate the owner can still review, an expired gate can't be, must be called with the lock held
+func (ms *MemoryStorage) awaitsReview(unit *types.TaskUnit, ownerID types.OwnerID) bool {
+	definition := ms.unitDefinition(unit)
+	return definition != nil && definition.Approval.Allows(ownerID) && !types.Reviewed(unit.Commands, ownerID) && !definition.Approval.Expired(unit.AvailableAt, time.Now())
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+// Rank of an inbox entry, highest priority first then the oldest unit, the task key only keeps the order stable
+type inboxRank struct {
+	priority types.Priority
+	since    time.Time
+	taskID   types.TaskID
+}
+
+func (r inboxRank) before(other inboxRank) bool {
+	if r.priority != other.priority {
+		return r.priority > other.priority
+	}
+	if !r.since.Equal(other.since) {
+		return r.since.Before(other.since)
+	}
+	return r.taskID < other.taskID
+}
+
+// Sort the units of an inbox entry by their own rank and return the rank of the entry
+// Must be called with the lock held
+func (ms *MemoryStorage) rankInboxUnits(taskID types.TaskID, units []types.TaskUnit, params *types.QueryParams, now time.Time) inboxRank {
+	var aging time.Duration
+	if params != nil && params.Aging != nil {
+		aging = *params.Aging
+	}
+
+	task := ms.tasks[taskID]
+	var job *types.Job
+	if task != nil {
+		job = ms.jobs[task.JobID]
+	}
+
+	ranks := make(map[types.TaskUnitID]inboxRank, len(units))
+	entry := inboxRank{taskID: taskID}
+	for i := 0; i < len(units); i++ {
+		rank := inboxRank{
+			priority: types.AgedPriority(types.EffectivePriority(job, task, &units[i]), units[i].CreatedAt, aging, now),
+			since:    units[i].CreatedAt,
+			taskID:   taskID,
+		}
+		ranks[units[i].Key] = rank
+		if i == 0 || rank.priority > entry.priority {
+			entry.priority = rank.priority
+		}
+		if i == 0 || rank.since.Before(entry.since) {
+			entry.since = rank.since
+		}
+	}
+
+	sort.SliceStable(units, func(a, b int) bool {
+		return ranks[units[a].Key].before(ranks[units[b].Key])
+	})
+
+	return entry
+}
+
+// Order in which the inbox entries must be returned
+func inboxOrder(ranks []inboxRank) []int {
+	order := make([]int, len(ranks))
+	for i := 0; i < len(order); i++ {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return ranks[order[a]].before(ranks[order[b]])
+	})
+	return order
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+func (ms *MemoryStorage) ReassignTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	unit, exists := ms.unit(taskUnitID)
+	if !exists {
+		return fmt.Errorf("task unit with ID %s not found", taskUnitID)
+	}
+	if _, exists := ms.owner(ownerID); !exists {
+		return fmt.Errorf("%w: owner %v not found", types.ErrReassignInvalid, ownerID)
+	}
+	if err := ms.checkOwner(ownerID); err != nil {
+		return err
+	}
+	unit.OwnerID = ownerID
+	unit.ClaimedBy = ""
+	return nil
+}
+
+// Owner working on the unit, must be called with the lock held
+func (ms *MemoryStorage) unitOwner(unit *types.TaskUnit) types.OwnerID {
+	return unit.Owner(ms.unitDefinition(unit))
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+func (ms *MemoryStorage) CreateSchedule(topicID types.TopicID, name string, cron string, cfgs ...types.ScheduleConfig) (*types.Schedule, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var uuid string
+	var err error
+	if uuid, err = ms.NewUUID(); err != nil {
+		return nil, err
+	}
+
+	schedule := types.NewSchedule(types.ScheduleID(uuid), topicID, name, cron, cfgs...)
+	schedule.Namespace = ms.namespace
+	ms.schedules[schedule.Key] = schedule
+
+	value := *schedule
+	return &value, nil
+}
+
+func (ms *MemoryStorage) GetSchedule(id types.ScheduleID) (*types.Schedule, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	schedule, exists := ms.schedule(id)
+	if !exists {
+		return nil, types.ErrScheduleNotFound
+	}
+	value := *schedule
+	return &value, nil
+}
+
+func (ms *MemoryStorage) GetSchedules() ([]types.Schedule, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	schedules := make([]types.Schedule, 0, len(ms.schedules))
+	for _, schedule := range ms.schedules {
+		if ms.sees(schedule.Namespace) {
+			schedules = append(schedules, *schedule)
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].Name != schedules[j].Name {
+			return schedules[i].Name < schedules[j].Name
+		}
+		return schedules[i].Key < schedules[j].Key
+	})
+	return schedules, nil
+}
+
+func (ms *MemoryStorage) UpdateSchedule(schedule types.Schedule) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, exists := ms.schedule(schedule.Key); !exists {
+		return types.ErrScheduleNotFound
+	}
+	schedule.Namespace = ms.namespace
+	ms.schedules[schedule.Key] = &schedule
+	return nil
+}
+
+func (ms *MemoryStorage) DeleteSchedule(id types.ScheduleID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, exists := ms.schedule(id); !exists {
+		return types.ErrScheduleNotFound
+	}
+	delete(ms.schedules, id)
+	return nil
+}
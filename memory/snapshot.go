@@ -0,0 +1,405 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// `Snapshot` and `Restore` let you export the content of a `MemoryStorage` as JSON so you can warm-start your fixtures or survive a restart without a database
+/// Only the persistent fields are written, the runtime pointers (`Topic.Jobs`, `Job.Tasks`, `Task.TaskUnits`, `TaskUnit.DependsOn`) are rebuilt from the ids on load
+/// Both cover every namespace of the storage, whichever view you call them on
+
+// Version of the format written by `Snapshot`, bump it each time the layout change
+// 2: namespaces, definition versions pinned by the units, creation dates, jobs with their topic, templates, limits, schedules, webhooks and audit records
+// `Restore` still reads the version 1, see `upgradeSnapshotV1`
+const SnapshotVersion = 2
+
+var (
+	ErrSnapshotVersion = errors.New("unsupported snapshot version")
+)
+
+type snapshot struct {
+	Version     int                    `json:"version"`
+	Owners      []types.Owner          `json:"owners"`
+	Definitions []types.TaskDefinition `json:"definitions"`
+	// the versions before the current one of each definition
+	PreviousDefinitions []types.TaskDefinition   `json:"previousDefinitions,omitempty"`
+	Topics              []types.Topic            `json:"topics"`
+	Jobs                []types.Job              `json:"jobs"`
+	Tasks               []types.Task             `json:"tasks"`
+	Units               []snapshotTaskUnit       `json:"units"`
+	Templates           []types.Template         `json:"templates,omitempty"`
+	Limits              []types.ConcurrencyLimit `json:"limits,omitempty"`
+	Schedules           []types.Schedule         `json:"schedules,omitempty"`
+	Webhooks            []types.Webhook          `json:"webhooks,omitempty"`
+	Deliveries          []types.Delivery         `json:"deliveries,omitempty"`
+	Audit               []types.AuditRecord      `json:"audit,omitempty"`
+}
+
+// `TaskUnit.Error` is an interface which can't be decoded, we keep the message only
+type snapshotTaskUnit struct {
+	types.TaskUnit
+	Error string `json:"error,omitempty"`
+}
+
+// Snapshot writes the whole content of the storage (owners, definitions and their versions, topics, jobs, tasks, units and their commands, templates, limits, schedules, webhooks and their deliveries, audit records) as versioned JSON
+func (ms *MemoryStorage) Snapshot(w io.Writer) error {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	snap := snapshot{
+		Version:     SnapshotVersion,
+		Owners:      make([]types.Owner, 0, len(ms.owners)),
+		Definitions: make([]types.TaskDefinition, 0, len(ms.definitions)),
+		Topics:      make([]types.Topic, 0, len(ms.topics)),
+		Jobs:        make([]types.Job, 0, len(ms.jobs)),
+		Tasks:       make([]types.Task, 0, len(ms.tasks)),
+		Units:       make([]snapshotTaskUnit, 0, len(ms.units)),
+		Templates:   make([]types.Template, 0, len(ms.templates)),
+	}
+
+	for _, owner := range ms.owners {
+		snap.Owners = append(snap.Owners, *owner)
+	}
+	for _, def := range ms.definitions {
+		snap.Definitions = append(snap.Definitions, *def)
+	}
+	for _, versions := range ms.definitionVersions {
+		snap.PreviousDefinitions = append(snap.PreviousDefinitions, versions[:len(versions)-1]...)
+	}
+	// copies without the runtime fields
+	for _, topic := range ms.topics {
+		value := *topic
+		value.Jobs = nil
+		value.TotalCompleted = nil
+		value.TotalPending = nil
+		value.TotalError = nil
+		value.TotalPause = nil
+		snap.Topics = append(snap.Topics, value)
+	}
+	for _, job := range ms.jobs {
+		value := *job
+		value.Tasks = nil
+		snap.Jobs = append(snap.Jobs, value)
+	}
+	for _, task := range ms.tasks {
+		value := *task
+		value.TaskUnits = nil
+		snap.Tasks = append(snap.Tasks, value)
+	}
+	for _, unit := range ms.units {
+		value := snapshotTaskUnit{TaskUnit: *unit}
+		value.TaskUnit.DependsOn = nil
+		value.TaskUnit.Error = nil
+		if unit.Error != nil {
+			value.Error = unit.Error.Error()
+		}
+		snap.Units = append(snap.Units, value)
+	}
+	for _, template := range ms.templates {
+		snap.Templates = append(snap.Templates, *template)
+	}
+	snap.Limits = ms.concurrencyLimits()
+	for _, schedule := range ms.schedules {
+		snap.Schedules = append(snap.Schedules, *schedule)
+	}
+	for _, webhook := range ms.webhooks {
+		snap.Webhooks = append(snap.Webhooks, *webhook)
+	}
+	// in the order they were added
+	for i := 0; i < len(ms.deliveryOrder); i++ {
+		snap.Deliveries = append(snap.Deliveries, *ms.deliveries[ms.deliveryOrder[i]])
+	}
+	snap.Audit = append(snap.Audit, ms.audit...)
+
+	// maps are random, we want the same snapshot for the same content
+	sort.Slice(snap.Owners, func(i, j int) bool { return snap.Owners[i].Key < snap.Owners[j].Key })
+	sort.Slice(snap.Definitions, func(i, j int) bool { return snap.Definitions[i].Key < snap.Definitions[j].Key })
+	sort.Slice(snap.PreviousDefinitions, func(i, j int) bool {
+		if snap.PreviousDefinitions[i].Key != snap.PreviousDefinitions[j].Key {
+			return snap.PreviousDefinitions[i].Key < snap.PreviousDefinitions[j].Key
+		}
+		return snap.PreviousDefinitions[i].Version < snap.PreviousDefinitions[j].Version
+	})
+	sort.Slice(snap.Topics, func(i, j int) bool { return snap.Topics[i].Key < snap.Topics[j].Key })
+	sort.Slice(snap.Jobs, func(i, j int) bool { return snap.Jobs[i].Key < snap.Jobs[j].Key })
+	sort.Slice(snap.Tasks, func(i, j int) bool { return snap.Tasks[i].Key < snap.Tasks[j].Key })
+	sort.Slice(snap.Units, func(i, j int) bool { return snap.Units[i].Key < snap.Units[j].Key })
+	sort.Slice(snap.Templates, func(i, j int) bool { return snap.Templates[i].Key < snap.Templates[j].Key })
+	sort.Slice(snap.Schedules, func(i, j int) bool { return snap.Schedules[i].Key < snap.Schedules[j].Key })
+	sort.Slice(snap.Webhooks, func(i, j int) bool { return snap.Webhooks[i].Key < snap.Webhooks[j].Key })
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snap)
+}
+
+// Restore replaces the content of the storage with a snapshot previously written by `Snapshot`
+// The storage is left untouched if the snapshot can't be read or is inconsistent
+func (ms *MemoryStorage) Restore(r io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return err
+	}
+	if snap.Version < 1 || snap.Version > SnapshotVersion {
+		return fmt.Errorf("%w: %v", ErrSnapshotVersion, snap.Version)
+	}
+	if snap.Version == 1 {
+		upgradeSnapshotV1(&snap)
+	}
+
+	// snapshots written before the creation dates, the drafts will age from now on
+	now := time.Now()
+
+	topics := make(map[types.TopicID]*types.Topic, len(snap.Topics))
+	jobs := make(map[types.JobID]*types.Job, len(snap.Jobs))
+	tasks := make(map[types.TaskID]*types.Task, len(snap.Tasks))
+	units := make(map[types.TaskUnitID]*types.TaskUnit, len(snap.Units))
+	definitions := make(map[types.TaskDefinitionID]*types.TaskDefinition, len(snap.Definitions))
+	definitionVersions := make(map[types.TaskDefinitionID][]types.TaskDefinition, len(snap.Definitions))
+	owners := make(map[types.OwnerID]*types.Owner, len(snap.Owners))
+	templates := make(map[types.TemplateID]*types.Template, len(snap.Templates))
+	limits := make(map[limitScope]int, len(snap.Limits))
+	schedules := make(map[types.ScheduleID]*types.Schedule, len(snap.Schedules))
+	webhooks := make(map[types.WebhookID]*types.Webhook, len(snap.Webhooks))
+	deliveries := make(map[types.DeliveryID]*types.Delivery, len(snap.Deliveries))
+	deliveryOrder := make([]types.DeliveryID, 0, len(snap.Deliveries))
+	deliveryEvents := make(map[deliveryEvent]bool, len(snap.Deliveries))
+
+	// snapshots written before the namespaces
+	restoreNamespaces(&snap)
+
+	for i := 0; i < len(snap.Owners); i++ {
+		owners[snap.Owners[i].Key] = &snap.Owners[i]
+	}
+	for i := 0; i < len(snap.PreviousDefinitions); i++ {
+		def := snap.PreviousDefinitions[i]
+		definitionVersions[def.Key] = append(definitionVersions[def.Key], def)
+	}
+	for i := 0; i < len(snap.Definitions); i++ {
+		def := &snap.Definitions[i]
+		// snapshots written before the versions
+		if def.Version == 0 {
+			def.Version = 1
+		}
+		definitions[def.Key] = def
+		definitionVersions[def.Key] = append(definitionVersions[def.Key], *def)
+		if len(definitionVersions[def.Key]) != def.Version {
+			return fmt.Errorf("definition %v has %v versions for its version %v", def.Key, len(definitionVersions[def.Key]), def.Version)
+		}
+	}
+	for id := range definitionVersions {
+		if _, exists := definitions[id]; !exists {
+			return fmt.Errorf("previous version of unknown definition %v", id)
+		}
+	}
+	for i := 0; i < len(snap.Templates); i++ {
+		templates[snap.Templates[i].Key] = &snap.Templates[i]
+	}
+	for i := 0; i < len(snap.Schedules); i++ {
+		schedules[snap.Schedules[i].Key] = &snap.Schedules[i]
+	}
+	for i := 0; i < len(snap.Webhooks); i++ {
+		webhooks[snap.Webhooks[i].Key] = &snap.Webhooks[i]
+	}
+	for i := 0; i < len(snap.Deliveries); i++ {
+		delivery := &snap.Deliveries[i]
+		if _, exists := webhooks[delivery.WebhookID]; !exists {
+			return fmt.Errorf("delivery %v references unknown webhook %v", delivery.Key, delivery.WebhookID)
+		}
+		deliveries[delivery.Key] = delivery
+		deliveryOrder = append(deliveryOrder, delivery.Key)
+		deliveryEvents[deliveryEvent{webhookID: delivery.WebhookID, eventID: delivery.Event.ID}] = true
+	}
+	for i := 0; i < len(snap.Limits); i++ {
+		limits[limitScope{
+			namespace:    snap.Limits[i].Namespace,
+			ownerID:      snap.Limits[i].OwnerID,
+			definitionID: snap.Limits[i].TaskDefinitionID,
+			topicID:      snap.Limits[i].TopicID,
+		}] = snap.Limits[i].Max
+	}
+	for i := 0; i < len(snap.Units); i++ {
+		unit := snap.Units[i].TaskUnit
+		if snap.Units[i].Error != "" {
+			unit.Error = errors.New(snap.Units[i].Error)
+		}
+		if unit.DependsOnIDs == nil {
+			unit.DependsOnIDs = []types.TaskUnitID{}
+		}
+		if unit.Commands == nil {
+			unit.Commands = make([]types.Command, 0)
+		}
+		if unit.CreatedAt.IsZero() {
+			unit.CreatedAt = now
+		}
+		unit.DependsOn = []*types.TaskUnit{}
+		units[unit.Key] = &unit
+	}
+	for i := 0; i < len(snap.Tasks); i++ {
+		task := &snap.Tasks[i]
+		if task.TaskUnitIDs == nil {
+			task.TaskUnitIDs = []types.TaskUnitID{}
+		}
+		if task.CreatedAt.IsZero() {
+			task.CreatedAt = now
+		}
+		task.TaskUnits = make(map[types.TaskUnitID]*types.TaskUnit)
+		tasks[task.Key] = task
+	}
+	for i := 0; i < len(snap.Jobs); i++ {
+		job := &snap.Jobs[i]
+		if job.TaskIDs == nil {
+			job.TaskIDs = []types.TaskID{}
+		}
+		if job.CreatedAt.IsZero() {
+			job.CreatedAt = now
+		}
+		job.Tasks = make(map[types.TaskID]*types.Task)
+		jobs[job.Key] = job
+	}
+	for i := 0; i < len(snap.Topics); i++ {
+		topic := &snap.Topics[i]
+		if topic.JobIDs == nil {
+			topic.JobIDs = []types.JobID{}
+		}
+		topic.Jobs = make(map[types.JobID]*types.Job)
+		topics[topic.Key] = topic
+	}
+
+	// rebuild the runtime pointers from the ids
+	for _, unit := range units {
+		for i := 0; i < len(unit.DependsOnIDs); i++ {
+			dependency, exists := units[unit.DependsOnIDs[i]]
+			if !exists || dependency.Namespace != unit.Namespace {
+				return fmt.Errorf("unit %v depends on unknown unit %v", unit.Key, unit.DependsOnIDs[i])
+			}
+			unit.DependsOn = append(unit.DependsOn, dependency)
+		}
+	}
+	for _, task := range tasks {
+		for i := 0; i < len(task.TaskUnitIDs); i++ {
+			unit, exists := units[task.TaskUnitIDs[i]]
+			if !exists || unit.Namespace != task.Namespace {
+				return fmt.Errorf("task %v references unknown unit %v", task.Key, task.TaskUnitIDs[i])
+			}
+			task.TaskUnits[unit.Key] = unit
+		}
+	}
+	for _, job := range jobs {
+		for i := 0; i < len(job.TaskIDs); i++ {
+			task, exists := tasks[job.TaskIDs[i]]
+			if !exists || task.Namespace != job.Namespace {
+				return fmt.Errorf("job %v references unknown task %v", job.Key, job.TaskIDs[i])
+			}
+			job.Tasks[task.Key] = task
+		}
+	}
+	for _, topic := range topics {
+		for i := 0; i < len(topic.JobIDs); i++ {
+			job, exists := jobs[topic.JobIDs[i]]
+			if !exists || job.Namespace != topic.Namespace {
+				return fmt.Errorf("topic %v references unknown job %v", topic.Key, topic.JobIDs[i])
+			}
+			topic.Jobs[job.Key] = job
+		}
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.topics = topics
+	ms.jobs = jobs
+	ms.tasks = tasks
+	ms.units = units
+	ms.definitions = definitions
+	ms.definitionVersions = definitionVersions
+	ms.owners = owners
+	ms.templates = templates
+	ms.limits = limits
+	ms.schedules = schedules
+	ms.webhooks = webhooks
+	ms.deliveries = deliveries
+	ms.deliveryOrder = deliveryOrder
+	ms.deliveryEvents = deliveryEvents
+	ms.audit = snap.Audit
+
+	return nil
+}
+
+// The version 1 grew with the features without being bumped, fill what the older ones miss
+// - the jobs only known by their topic get it back, they would be drafts otherwise
+// - the units without version pin the first one of their definition, the only one back then
+// The namespaces and the creation dates are filled for every version
+func upgradeSnapshotV1(snap *snapshot) {
+	topics := map[types.JobID]types.TopicID{}
+	for i := 0; i < len(snap.Topics); i++ {
+		for k := 0; k < len(snap.Topics[i].JobIDs); k++ {
+			topics[snap.Topics[i].JobIDs[k]] = snap.Topics[i].Key
+		}
+	}
+	for i := 0; i < len(snap.Jobs); i++ {
+		if snap.Jobs[i].TopicID == "" {
+			snap.Jobs[i].TopicID = topics[snap.Jobs[i].Key]
+		}
+	}
+	for i := 0; i < len(snap.Units); i++ {
+		if snap.Units[i].TaskDefinitionID != "" && snap.Units[i].DefinitionVersion == 0 {
+			snap.Units[i].DefinitionVersion = 1
+		}
+	}
+}
+
+// Entities without namespace belong to the default one
+func restoreNamespaces(snap *snapshot) {
+	restore := func(namespace *types.Namespace) {
+		if *namespace == "" {
+			*namespace = types.DefaultNamespace
+		}
+	}
+	for i := 0; i < len(snap.Owners); i++ {
+		restore(&snap.Owners[i].Namespace)
+	}
+	for i := 0; i < len(snap.Definitions); i++ {
+		restore(&snap.Definitions[i].Namespace)
+	}
+	for i := 0; i < len(snap.PreviousDefinitions); i++ {
+		restore(&snap.PreviousDefinitions[i].Namespace)
+	}
+	for i := 0; i < len(snap.Topics); i++ {
+		restore(&snap.Topics[i].Namespace)
+	}
+	for i := 0; i < len(snap.Jobs); i++ {
+		restore(&snap.Jobs[i].Namespace)
+	}
+	for i := 0; i < len(snap.Tasks); i++ {
+		restore(&snap.Tasks[i].Namespace)
+	}
+	for i := 0; i < len(snap.Units); i++ {
+		restore(&snap.Units[i].Namespace)
+	}
+	for i := 0; i < len(snap.Templates); i++ {
+		restore(&snap.Templates[i].Namespace)
+	}
+	for i := 0; i < len(snap.Limits); i++ {
+		restore(&snap.Limits[i].Namespace)
+	}
+	for i := 0; i < len(snap.Schedules); i++ {
+		restore(&snap.Schedules[i].Namespace)
+	}
+	for i := 0; i < len(snap.Webhooks); i++ {
+		restore(&snap.Webhooks[i].Namespace)
+	}
+	for i := 0; i < len(snap.Deliveries); i++ {
+		restore(&snap.Deliveries[i].Namespace)
+	}
+	for i := 0; i < len(snap.Audit); i++ {
+		restore(&snap.Audit[i].Namespace)
+	}
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+// CreateTemplate saves the next version of the template with that name
+func (ms *MemoryStorage) CreateTemplate(name string, dag json.RawMessage, cfgs ...types.TemplateConfig) (*types.Template, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var uuid string
+	var err error
+	if uuid, err = ms.NewUUID(); err != nil {
+		return nil, err
+	}
+
+	template := types.NewTemplate(types.TemplateID(uuid), name, dag, cfgs...)
+
+	for _, v := range ms.templates {
+		if v.Name == name && v.Version >= template.Version && ms.sees(v.Namespace) {
+			template.Version = v.Version + 1
+		}
+	}
+
+	template.Namespace = ms.namespace
+	ms.templates[template.Key] = template
+	return template, nil
+}
+
+func (ms *MemoryStorage) GetTemplate(id types.TemplateID) (*types.Template, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	template, exists := ms.template(id)
+	if !exists {
+		return nil, types.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (ms *MemoryStorage) GetTemplates() ([]types.Template, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	latest := map[string]*types.Template{}
+	for _, v := range ms.templates {
+		if !ms.sees(v.Namespace) {
+			continue
+		}
+		if current, exists := latest[v.Name]; !exists || v.Version > current.Version {
+			latest[v.Name] = v
+		}
+	}
+
+	templates := make([]types.Template, 0, len(latest))
+	for _, v := range latest {
+		templates = append(templates, *v)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+func (ms *MemoryStorage) GetTemplateVersions(name string) ([]types.Template, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	templates := []types.Template{}
+	for _, v := range ms.templates {
+		if v.Name == name && ms.sees(v.Namespace) {
+			templates = append(templates, *v)
+		}
+	}
+	if len(templates) == 0 {
+		return nil, types.ErrTemplateNotFound
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Version < templates[j].Version })
+	return templates, nil
+}
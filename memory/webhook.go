@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+// An event given to a webhook, to give it only once
+type deliveryEvent struct {
+	webhookID types.WebhookID
+	eventID   string
+}
+
+func (ms *MemoryStorage) CreateWebhook(url string, cfgs ...types.WebhookConfig) (*types.Webhook, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var uuid string
+	var err error
+	if uuid, err = ms.NewUUID(); err != nil {
+		return nil, err
+	}
+
+	webhook := types.NewWebhook(types.WebhookID(uuid), url, cfgs...)
+	webhook.Namespace = ms.namespace
+	ms.webhooks[webhook.Key] = webhook
+
+	value := *webhook
+	return &value, nil
+}
+
+func (ms *MemoryStorage) GetWebhook(id types.WebhookID) (*types.Webhook, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	webhook, exists := ms.webhook(id)
+	if !exists {
+		return nil, types.ErrWebhookNotFound
+	}
+	value := *webhook
+	return &value, nil
+}
+
+func (ms *MemoryStorage) GetWebhooks() ([]types.Webhook, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	webhooks := make([]types.Webhook, 0, len(ms.webhooks))
+	for _, webhook := range ms.webhooks {
+		if ms.sees(webhook.Namespace) {
+			webhooks = append(webhooks, *webhook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].Key < webhooks[j].Key
+	})
+	return webhooks, nil
+}
+
+func (ms *MemoryStorage) DeleteWebhook(id types.WebhookID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, exists := ms.webhook(id); !exists {
+		return types.ErrWebhookNotFound
+	}
+	delete(ms.webhooks, id)
+	kept := make([]types.DeliveryID, 0, len(ms.deliveryOrder))
+	for i := 0; i < len(ms.deliveryOrder); i++ {
+		delivery := ms.deliveries[ms.deliveryOrder[i]]
+		if delivery.WebhookID != id {
+			kept = append(kept, delivery.Key)
+			continue
+		}
+		delete(ms.deliveryEvents, deliveryEvent{webhookID: id, eventID: delivery.Event.ID})
+		delete(ms.deliveries, delivery.Key)
+	}
+	ms.deliveryOrder = kept
+	return nil
+}
+
+func (ms *MemoryStorage) AddDeliveries(deliveries []types.Delivery) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for i := 0; i < len(deliveries); i++ {
+		if _, exists := ms.webhook(deliveries[i].WebhookID); !exists {
+			return types.ErrWebhookNotFound
+		}
+	}
+	for i := 0; i < len(deliveries); i++ {
+		event := deliveryEvent{webhookID: deliveries[i].WebhookID, eventID: deliveries[i].Event.ID}
+		if ms.deliveryEvents[event] {
+			continue
+		}
+		delivery := deliveries[i]
+		if delivery.Key == "" {
+			uuid, err := ms.NewUUID()
+			if err != nil {
+				return err
+			}
+			delivery.Key = types.DeliveryID(uuid)
+		}
+		if delivery.CreatedAt.IsZero() {
+			delivery.CreatedAt = time.Now()
+		}
+		if delivery.Status == "" {
+			delivery.Status = types.DeliveryPending
+		}
+		delivery.Namespace = ms.namespace
+		ms.deliveries[delivery.Key] = &delivery
+		ms.deliveryOrder = append(ms.deliveryOrder, delivery.Key)
+		ms.deliveryEvents[event] = true
+	}
+	return nil
+}
+
+func (ms *MemoryStorage) GetDeliveries(webhookID types.WebhookID) ([]types.Delivery, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if _, exists := ms.webhook(webhookID); !exists {
+		return nil, types.ErrWebhookNotFound
+	}
+	deliveries := []types.Delivery{}
+	for i := 0; i < len(ms.deliveryOrder); i++ {
+		if delivery := ms.deliveries[ms.deliveryOrder[i]]; delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (ms *MemoryStorage) GetPendingDeliveries() ([]types.Delivery, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	deliveries := []types.Delivery{}
+	for i := 0; i < len(ms.deliveryOrder); i++ {
+		if delivery := ms.deliveries[ms.deliveryOrder[i]]; delivery.Status == types.DeliveryPending && ms.sees(delivery.Namespace) {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+	// the events of a same change keep their order
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].NextAttempt.Before(deliveries[j].NextAttempt)
+	})
+	return deliveries, nil
+}
+
+func (ms *MemoryStorage) UpdateDelivery(delivery types.Delivery) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, exists := ms.delivery(delivery.Key); !exists {
+		return types.ErrWebhookNotFound
+	}
+	delivery.Namespace = ms.namespace
+	ms.deliveries[delivery.Key] = &delivery
+	return nil
+}
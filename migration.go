@@ -0,0 +1,145 @@
+package junjo
+
+import (
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Migrations move the pending units away from a deprecated `Owner` or `TaskDefinition`
+///
+///	jj.DeprecateTaskDefinition(flashV1.Key)
+///	jj.MigrateTaskDefinition(flashV1.Key, flashV2.Key) // the units not started yet run with flashV2
+///
+/// The units already started, claimed or finished stay as they are
+
+// Reassign the pending units of an owner to its replacement, each one records a `DelegateCmd` with the reason
+func (j *Junjoold) MigrateOwner(from types.OwnerID, to types.OwnerID, reason string) ([]types.TaskUnitID, error) {
+	if err := j.authorize(types.ActionOwnerManage, types.Scope{}, string(from)); err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, fmt.Errorf("%w: %v replaces itself", types.ErrMigrationInvalid, from)
+	}
+	replacement, err := j.storageImplementation.GetOwner(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrMigrationInvalid, err)
+	}
+	if replacement.Deprecated {
+		return nil, fmt.Errorf("%w: %v", types.ErrOwnerDeprecated, to)
+	}
+	// a pending fan-out is not expanded yet, its children will be created for the replacement
+	units, err := j.pendingUnits(func(unit *types.TaskUnit, definition *types.TaskDefinition) bool {
+		return unit.Owner(definition) == from
+	})
+	if err != nil {
+		return nil, err
+	}
+	migrated := []types.TaskUnitID{}
+	for i := 0; i < len(units); i++ {
+		if err = j.reassignTaskUnit(&units[i], to, reason); err != nil {
+			return migrated, err
+		}
+		migrated = append(migrated, units[i].Key)
+	}
+	return migrated, nil
+}
+
+// Move the pending units of a definition to the current version of its replacement, each one records a `LogCmd`
+func (j *Junjoold) MigrateTaskDefinition(from types.TaskDefinitionID, to types.TaskDefinitionID) ([]types.TaskUnitID, error) {
+	if err := j.authorize(types.ActionDefinitionManage, types.Scope{}, string(from)); err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, fmt.Errorf("%w: %v replaces itself", types.ErrMigrationInvalid, from)
+	}
+	previous, err := j.storageImplementation.GetTaskDefinition(from)
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := j.storageImplementation.GetTaskDefinition(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrMigrationInvalid, err)
+	}
+	if replacement.Deprecated {
+		return nil, fmt.Errorf("%w: %v", types.ErrTaskDefinitionDeprecated, to)
+	}
+	units, err := j.pendingUnits(func(unit *types.TaskUnit, definition *types.TaskDefinition) bool {
+		return unit.TaskDefinitionID == from
+	})
+	if err != nil {
+		return nil, err
+	}
+	migrated := []types.TaskUnitID{}
+	for i := 0; i < len(units); i++ {
+		if err = j.storageImplementation.MigrateTaskUnit(units[i].Key, to); err != nil {
+			return migrated, err
+		}
+		err = j.storageImplementation.AddTaskUnitCommand(units[i].Key, types.Command{
+			Type:    types.LogCmd,
+			Details: fmt.Sprintf("migrated from version %v of %v to version %v of %v", units[i].DefinitionVersion, from, replacement.Version, to),
+			Data: map[string]string{
+				types.MigratedFrom: string(from),
+				types.MigratedTo:   string(to),
+			},
+		})
+		if err != nil {
+			return migrated, err
+		}
+		migrated = append(migrated, units[i].Key)
+		// the units may lead to other owners now
+		if err = j.rollup(units[i].TaskID); err != nil {
+			return migrated, err
+		}
+	}
+	// and not to the previous one anymore
+	if members, err := j.poolMembers(previous.OwnerID); err == nil && len(migrated) > 0 {
+		j.inbox.notify(members...)
+	}
+	return migrated, nil
+}
+
+// The `Pending` units of the unfinished jobs matching with the version of their definition
+func (j *Junjoold) pendingUnits(match func(unit *types.TaskUnit, definition *types.TaskDefinition) bool) ([]types.TaskUnit, error) {
+	pending := []types.TaskUnit{}
+	topics, err := j.storageImplementation.GetTopics()
+	if err != nil {
+		return nil, err
+	}
+	for _, topic := range topics {
+		jobs, err := j.storageImplementation.GetJobs(topic.Key)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			if job.Status == types.SuccessStatus || job.Status == types.ErrorStatus {
+				continue
+			}
+			tasks, err := j.storageImplementation.GetTasks(job.Key)
+			if err != nil {
+				return nil, err
+			}
+			for _, task := range tasks {
+				units, err := j.storageImplementation.GetTaskUnits(task.Key)
+				if err != nil {
+					return nil, err
+				}
+				for i := 0; i < len(units); i++ {
+					if !units[i].Pending() {
+						continue
+					}
+					var definition *types.TaskDefinition
+					if units[i].TaskDefinitionID != "" {
+						if definition, err = j.unitDefinition(&units[i]); err != nil {
+							return nil, err
+						}
+					}
+					if match(&units[i], definition) {
+						pending = append(pending, units[i])
+					}
+				}
+			}
+		}
+	}
+	return pending, nil
+}
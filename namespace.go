@@ -0,0 +1,115 @@
+package junjo
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// `Namespaces` runs one engine per `Namespace`, each on a storage serving that namespace only, so a query never leaves its namespace
+/// The storages can share their content as long as each one only reaches its own entities, like the views of `memory.MemoryStorage`
+///
+///	storage := memory.NewMemoryStorage()
+///	namespaces := junjo.NewNamespaces(func(namespace types.Namespace) (types.StorageInterface, error) {
+///		return storage.Namespace(namespace), nil
+///	}, junjo.WithNamespacesAuth(types.StaticTokens{"s3cret": {Name: "ci", Namespaces: []types.Namespace{"billing", "payroll"}}}))
+///
+/// Without authentication every namespace is reachable, fine when a single team runs junjo
+/// With it the engines given by `Access` are bound to the `Principal` of the token, see `Junjoold.As`
+
+type Namespaces struct {
+	mu      sync.Mutex
+	open    func(namespace types.Namespace) (types.StorageInterface, error)
+	auth    types.AuthenticationInterface
+	engines map[types.Namespace]*Junjoold
+}
+
+type NamespacesConfig func(n *Namespaces)
+
+// Check the tokens given to `Access`
+func WithNamespacesAuth(auth types.AuthenticationInterface) NamespacesConfig {
+	return func(n *Namespaces) {
+		n.auth = auth
+	}
+}
+
+// Create the `Namespaces`, `open` gives the storage of a namespace the first time it's used
+// Refuse the namespaces you don't know with `types.ErrNamespaceInvalid`
+func NewNamespaces(open func(namespace types.Namespace) (types.StorageInterface, error), cfgs ...NamespacesConfig) *Namespaces {
+	namespaces := &Namespaces{
+		open:    open,
+		engines: map[types.Namespace]*Junjoold{},
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](namespaces)
+	}
+	return namespaces
+}
+
+// Engine of the namespace, without checking any token
+func (n *Namespaces) Get(namespace types.Namespace) (*Junjoold, error) {
+	if err := types.ValidateNamespace(namespace); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if jj, ok := n.engines[namespace]; ok {
+		return jj, nil
+	}
+	storage, err := n.open(namespace)
+	if err != nil {
+		return nil, err
+	}
+	jj := NewJ(storage)
+	n.engines[namespace] = jj
+	return jj, nil
+}
+
+// Namespaces opened so far, sorted
+func (n *Namespaces) Opened() []types.Namespace {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	namespaces := make([]types.Namespace, 0, len(n.engines))
+	for namespace := range n.engines {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i] < namespaces[j] })
+	return namespaces
+}
+
+// Engine of the namespace for the token, bound to its principal, refused with `types.ErrNamespaceDenied` when the token doesn't reach it
+// An empty namespace is the only one the token reaches, or the default one
+func (n *Namespaces) Access(token string, namespace types.Namespace) (*Junjoold, error) {
+	if n.auth == nil {
+		if namespace == "" {
+			namespace = types.DefaultNamespace
+		}
+		return n.Get(namespace)
+	}
+	principal, err := n.auth.Authenticate(token)
+	if err != nil {
+		return nil, err
+	}
+	allowed := principal.Namespaces
+	if namespace == "" {
+		namespace = types.DefaultNamespace
+		if len(allowed) == 1 {
+			namespace = allowed[0]
+		}
+	}
+	if err = types.ValidateNamespace(namespace); err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(allowed); i++ {
+		if allowed[i] == namespace {
+			jj, err := n.Get(namespace)
+			if err != nil {
+				return nil, err
+			}
+			return jj.As(principal), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %v", types.ErrNamespaceDenied, namespace)
+}
@@ -0,0 +1,110 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestNamespaces$ .
+func TestNamespaces(t *testing.T) {
+	var err error
+	// one storage for every namespace, each engine only sees its own
+	storage := memory.NewMemoryStorage()
+	namespaces := NewNamespaces(func(namespace types.Namespace) (types.StorageInterface, error) {
+		return storage.Namespace(namespace), nil
+	}, WithNamespacesAuth(types.StaticTokens{
+		"b1lling": {Name: "billing", Namespaces: []types.Namespace{"billing"}, Roles: []types.RoleBinding{{Role: types.RoleAdmin}}},
+		"4dmin":   {Name: "ops", Namespaces: []types.Namespace{"billing", "payroll"}, Roles: []types.RoleBinding{{Role: types.RoleAdmin}}},
+	}))
+
+	var billing, payroll *Junjoold
+	if billing, err = namespaces.Access("b1lling", ""); err != nil {
+		t.Error(err)
+		return
+	}
+	if payroll, err = namespaces.Access("4dmin", "payroll"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = namespaces.Access("b1lling", "payroll"); !errors.Is(err, types.ErrNamespaceDenied) {
+		t.Errorf("expected %v, got %v", types.ErrNamespaceDenied, err)
+		return
+	}
+	if _, err = namespaces.Access("guess", "billing"); !errors.Is(err, types.ErrUnauthenticated) {
+		t.Errorf("expected %v, got %v", types.ErrUnauthenticated, err)
+		return
+	}
+	if _, err = namespaces.Access("4dmin", "Payroll!"); !errors.Is(err, types.ErrNamespaceInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrNamespaceInvalid, err)
+		return
+	}
+
+	// the same names live in both namespaces
+	var alice *types.Owner
+	if alice, err = billing.CreateOwner("Alice"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = payroll.CreateOwner("Alice"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = billing.CreateOwner("Alice"); !errors.Is(err, types.ErrOwnerNameAlreadyExists) {
+		t.Errorf("expected %v within a namespace, got %v", types.ErrOwnerNameAlreadyExists, err)
+		return
+	}
+
+	var topic *types.Topic
+	if topic, err = billing.CreateTopic("Invoices"); err != nil {
+		t.Error(err)
+		return
+	}
+	var send *types.TaskDefinition
+	if send, err = billing.CreateTaskDefinition("send the invoice", alice.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	unitDag := billing.CreateDagTaskUnits()
+	unitDag.AddTaskDefinition(send)()
+	if _, err = billing.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var inbox []types.InboxAllTaskUnit
+	if inbox, err = billing.GetInbox(alice.Key); err != nil || len(inbox) != 1 {
+		t.Errorf("expected the task in the inbox, got %v %v", inbox, err)
+		return
+	}
+	// nothing of billing can be reached from payroll, even by its ids
+	if inbox, err = payroll.GetInbox(alice.Key); err == nil && len(inbox) > 0 {
+		t.Errorf("expected nothing in payroll, got %v", inbox)
+		return
+	}
+	if _, err = payroll.GetTopic(topic.Key); err == nil {
+		t.Error("expected the topic of billing not found in payroll")
+		return
+	}
+	var topics []types.Topic
+	if topics, err = payroll.GetTopics(); err != nil || len(topics) != 0 {
+		t.Errorf("expected no topic in payroll, got %v %v", topics, err)
+		return
+	}
+	if _, err = payroll.CreateTopic("Invoices"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = billing.CreateTopic("Invoices"); !errors.Is(err, types.ErrTopicNameAlreadyExists) {
+		t.Errorf("expected %v within a namespace, got %v", types.ErrTopicNameAlreadyExists, err)
+		return
+	}
+
+	opened := namespaces.Opened()
+	if len(opened) != 2 || opened[0] != "billing" || opened[1] != "payroll" {
+		t.Errorf("expected billing and payroll opened, got %v", opened)
+		return
+	}
+}
@@ -0,0 +1,140 @@
+package junjo
+
+import (
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Owner pools share the units of a `TaskDefinition` between their members, like an on-call rotation
+///
+///	rotation, _ := jj.CreateOwner("on-call", types.WithOwnerMembers(alice.Key, bob.Key))
+///	rack, _ := jj.CreateTaskDefinition("rack the server", rotation.Key)
+///	...
+///	jj.ClaimTaskUnit(unit.Key, alice.Key) // bob doesn't see it anymore
+///
+/// The claim hides the unit from the other members and only the claimer can command it, see `types.Claimant`
+///
+///	jj.SubmitCommand(unit.Key, types.Command{Type: types.SuccessCmd, Data: map[string]string{types.Claimant: string(alice.Key)}})
+
+// Replace the members of a pool, none turns it back into a simple owner
+func (j *Junjoold) SetOwnerMembers(ownerID types.OwnerID, members ...types.OwnerID) (*types.Owner, error) {
+	if err := j.authorize(types.ActionOwnerManage, types.Scope{}, string(ownerID)); err != nil {
+		return nil, err
+	}
+	if err := j.validateMembers(ownerID, members); err != nil {
+		return nil, err
+	}
+	// the previous members lose the units of the pool
+	previous, err := j.poolMembers(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := j.storageImplementation.SetOwnerMembers(ownerID, members)
+	if err != nil {
+		return nil, err
+	}
+	j.inbox.notify(previous...)
+	j.inbox.notify(members...)
+	return owner, nil
+}
+
+func (j *Junjoold) validateMembers(ownerID types.OwnerID, members []types.OwnerID) error {
+	if len(members) == 0 {
+		return nil
+	}
+	owners, err := j.storageImplementation.GetOwners()
+	if err != nil {
+		return err
+	}
+	byID := map[types.OwnerID]types.Owner{}
+	for i := 0; i < len(owners); i++ {
+		byID[owners[i].Key] = owners[i]
+		// a pool can't be a member
+		if owners[i].HasMember(ownerID) {
+			return fmt.Errorf("%w: %v is a member of the pool %v", types.ErrOwnerPoolInvalid, ownerID, owners[i].Key)
+		}
+	}
+	seen := map[types.OwnerID]bool{}
+	for i := 0; i < len(members); i++ {
+		member, ok := byID[members[i]]
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: member %v not found", types.ErrOwnerPoolInvalid, members[i])
+		case members[i] == ownerID:
+			return fmt.Errorf("%w: a pool can't be its own member", types.ErrOwnerPoolInvalid)
+		case member.IsPool():
+			return fmt.Errorf("%w: member %v is a pool", types.ErrOwnerPoolInvalid, members[i])
+		case seen[members[i]]:
+			return fmt.Errorf("%w: member %v is given twice", types.ErrOwnerPoolInvalid, members[i])
+		}
+		seen[members[i]] = true
+	}
+	return nil
+}
+
+// Take an available unit of a pool, the other members stop seeing it in their inbox
+// Claiming again a unit already claimed by the same owner does nothing
+// The storage refuses the claim when the unit started meanwhile or when its concurrency limits are full
+func (j *Junjoold) ClaimTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID) error {
+	if err := j.authorizeUnit(types.ActionUnitCommand, taskUnitID, ownerID); err != nil {
+		return err
+	}
+	unit, err := j.storageImplementation.GetTaskUnit(taskUnitID)
+	if err != nil {
+		return err
+	}
+	definition, err := j.unitDefinition(unit)
+	if err != nil {
+		return err
+	}
+	if poolID := unit.Owner(definition); poolID != ownerID {
+		pool, err := j.storageImplementation.GetOwner(poolID)
+		if err != nil {
+			return err
+		}
+		if !pool.HasMember(ownerID) {
+			return fmt.Errorf("%w: %v is not in %v", types.ErrOwnerNotMember, ownerID, pool.Key)
+		}
+	}
+	if unit.Status != types.NoneStatus {
+		return types.ErrTaskUnitNotAvailable
+	}
+	available, err := j.canChangeStatus(unit)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return types.ErrTaskUnitNotAvailable
+	}
+	if err = j.storageImplementation.ClaimTaskUnit(taskUnitID, ownerID); err != nil {
+		return err
+	}
+	j.notifyTask(unit.TaskID)
+	return nil
+}
+
+// Give back a claimed unit to the pool
+func (j *Junjoold) ReleaseTaskUnit(taskUnitID types.TaskUnitID) error {
+	if err := j.authorizeUnit(types.ActionUnitCommand, taskUnitID); err != nil {
+		return err
+	}
+	unit, err := j.storageImplementation.GetTaskUnit(taskUnitID)
+	if err != nil {
+		return err
+	}
+	if err = j.storageImplementation.ReleaseTaskUnit(taskUnitID); err != nil {
+		return err
+	}
+	j.notifyTask(unit.TaskID)
+	return nil
+}
+
+// The owner with the members of its pool
+func (j *Junjoold) poolMembers(ownerID types.OwnerID) ([]types.OwnerID, error) {
+	owner, err := j.storageImplementation.GetOwner(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]types.OwnerID{ownerID}, owner.Members...), nil
+}
@@ -0,0 +1,191 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestOwnerPool$ .
+func TestOwnerPool(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Datacenter"); err != nil {
+		t.Error(err)
+		return
+	}
+	var alice, bob, carol *types.Owner
+	if alice, err = jj.CreateOwner("Alice"); err != nil {
+		t.Error(err)
+		return
+	}
+	if bob, err = jj.CreateOwner("Bob"); err != nil {
+		t.Error(err)
+		return
+	}
+	if carol, err = jj.CreateOwner("Carol"); err != nil {
+		t.Error(err)
+		return
+	}
+	var rotation *types.Owner
+	if rotation, err = jj.CreateOwner("On-call", types.WithOwnerMembers(alice.Key, bob.Key)); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = jj.SetOwnerMembers(alice.Key, bob.Key); !errors.Is(err, types.ErrOwnerPoolInvalid) {
+		t.Errorf("expected %v for a pool in a pool, got %v", types.ErrOwnerPoolInvalid, err)
+		return
+	}
+
+	var rack *types.TaskDefinition
+	if rack, err = jj.CreateTaskDefinition("rack the server", rotation.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	unitDag := jj.CreateDagTaskUnits()
+	node := unitDag.AddTaskDefinition(rack)()
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	unitID := node.(*types.NodeTaskUnit).Unit.Key
+
+	offered := func(ownerID types.OwnerID) bool {
+		inbox, err := jj.GetInbox(ownerID)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		return len(inbox) == 1 && len(inbox[0].TaskUnits) == 1 && inbox[0].TaskUnits[0].Key == unitID
+	}
+	if !offered(alice.Key) || !offered(bob.Key) || offered(carol.Key) {
+		t.Error("expected the unit offered to the members only")
+		return
+	}
+
+	if err = jj.ClaimTaskUnit(unitID, carol.Key); !errors.Is(err, types.ErrOwnerNotMember) {
+		t.Errorf("expected %v, got %v", types.ErrOwnerNotMember, err)
+		return
+	}
+	bobChanged := jj.InboxChanged(bob.Key)
+	if err = jj.ClaimTaskUnit(unitID, alice.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	select {
+	case <-bobChanged:
+	default:
+		t.Error("expected the inbox of bob to change")
+		return
+	}
+	if !offered(alice.Key) || offered(bob.Key) {
+		t.Error("expected the unit offered to alice only")
+		return
+	}
+	if err = jj.ClaimTaskUnit(unitID, bob.Key); !errors.Is(err, types.ErrTaskUnitClaimed) {
+		t.Errorf("expected %v, got %v", types.ErrTaskUnitClaimed, err)
+		return
+	}
+
+	if err = jj.ReleaseTaskUnit(unitID); err != nil {
+		t.Error(err)
+		return
+	}
+	if !offered(bob.Key) {
+		t.Error("expected the released unit offered to bob again")
+		return
+	}
+
+	if err = jj.ClaimTaskUnit(unitID, bob.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(unitID, types.Command{Type: types.ProgressCmd}); !errors.Is(err, types.ErrTaskUnitClaimed) {
+		t.Errorf("expected a command without claimant refused, got %v", err)
+		return
+	}
+	if err = jj.SubmitCommand(unitID, types.Command{Type: types.ProgressCmd, Data: map[string]string{types.Claimant: string(alice.Key)}}); !errors.Is(err, types.ErrTaskUnitClaimed) {
+		t.Errorf("expected the command of alice refused, got %v", err)
+		return
+	}
+	if err = jj.SubmitCommand(unitID, types.Command{Type: types.ProgressCmd, Data: map[string]string{types.Claimant: string(bob.Key)}}); err != nil {
+		t.Error(err)
+		return
+	}
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestOwnerPoolClaimRefused$ .
+func TestOwnerPoolClaimRefused(t *testing.T) {
+	var err error
+	storage := memory.NewMemoryStorage()
+	jj := NewJ(storage)
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Datacenter"); err != nil {
+		t.Error(err)
+		return
+	}
+	var alice, bob *types.Owner
+	if alice, err = jj.CreateOwner("Alice"); err != nil {
+		t.Error(err)
+		return
+	}
+	if bob, err = jj.CreateOwner("Bob"); err != nil {
+		t.Error(err)
+		return
+	}
+	var rotation *types.Owner
+	if rotation, err = jj.CreateOwner("On-call", types.WithOwnerMembers(alice.Key, bob.Key)); err != nil {
+		t.Error(err)
+		return
+	}
+	var rack *types.TaskDefinition
+	if rack, err = jj.CreateTaskDefinition("rack the server", rotation.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	unitDag := jj.CreateDagTaskUnits()
+	first := unitDag.AddTaskDefinition(rack)().(*types.NodeTaskUnit).Unit.Key
+	second := unitDag.AddTaskDefinition(rack)().(*types.NodeTaskUnit).Unit.Key
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// one unit of the pool at once, alice already works on the first
+	if err = jj.SetConcurrencyLimit(types.NewConcurrencyLimit(1, types.WithLimitOwner(rotation.Key))); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.ClaimTaskUnit(first, alice.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	claimant := map[string]string{types.Claimant: string(alice.Key)}
+	if err = jj.SubmitCommand(first, types.Command{Type: types.ProgressCmd, Data: claimant}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.ClaimTaskUnit(second, bob.Key); !errors.Is(err, types.ErrConcurrencyLimitReached) {
+		t.Errorf("expected %v, got %v", types.ErrConcurrencyLimitReached, err)
+		return
+	}
+
+	// a finished unit read before it finished, the storage still refuses the claim
+	if err = jj.SubmitCommand(first, types.Command{Type: types.SuccessCmd, Data: claimant}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = storage.ClaimTaskUnit(first, bob.Key); !errors.Is(err, types.ErrTaskUnitNotAvailable) {
+		t.Errorf("expected %v, got %v", types.ErrTaskUnitNotAvailable, err)
+		return
+	}
+	if err = jj.ClaimTaskUnit(second, bob.Key); err != nil {
+		t.Error(err)
+		return
+	}
+}
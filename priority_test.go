@@ -0,0 +1,87 @@
+package junjo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestInboxPriority$ .
+func TestInboxPriority(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	launch := func(priority types.Priority) (*types.Job, error) {
+		unitDag := jj.CreateDagTaskUnits()
+		unitDag.AddTaskDefinition(provisioning)()
+		return jj.LaunchJob(topic.Key, unitDag, types.WithJobPriority(priority))
+	}
+
+	var routine, older, emergency *types.Job
+	if routine, err = launch(types.PriorityNormal); err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(time.Millisecond)
+	if older, err = launch(types.PriorityNormal); err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(time.Millisecond)
+	if emergency, err = launch(types.PriorityEmergency); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var inbox []types.InboxAllTaskUnit
+	if inbox, err = jj.GetInbox(btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox) != 3 {
+		t.Errorf("expected 3 entries, got %v", len(inbox))
+		return
+	}
+	expected := []types.JobID{emergency.Key, routine.Key, older.Key}
+	for i := 0; i < len(expected); i++ {
+		if inbox[i].JobID != expected[i] {
+			t.Errorf("expected job %v at %v, got %v", expected[i], i, inbox[i].JobID)
+			return
+		}
+	}
+	if inbox[0].Priority != types.PriorityEmergency {
+		t.Errorf("expected priority %v, got %v", types.PriorityEmergency, inbox[0].Priority)
+		return
+	}
+
+	// one point per microsecond, the routine job waited way more than 100 points
+	var topicInbox []types.InboxTopicTaskUnit
+	if topicInbox, err = jj.GetInboxTopic(btl.Key, topic.Key, types.WithQueryAging(time.Microsecond)); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(topicInbox) != 3 || topicInbox[0].JobID != routine.Key {
+		t.Errorf("expected the routine job to be aged first, got %v", topicInbox)
+		return
+	}
+}
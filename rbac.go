@@ -0,0 +1,136 @@
+package junjo
+
+import (
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// A `Junjoold` bound to a `Principal` checks its roles before each change, see `types.Permissions`
+///
+///	alice := jj.As(&types.Principal{Name: "alice", Roles: []types.RoleBinding{{Role: types.RoleOwner, OwnerID: btl.Key}}})
+///	alice.SubmitCommand(unit.Key, types.Command{Type: types.SuccessCmd}) // a unit of btl
+///	alice.CancelJob(job.Key)                                              // types.ErrPermissionDenied
+///
+/// An unbound `Junjoold` is the system, it can do everything and isn't audited: keep it for your own loops like the `Scheduler`
+/// The cascades of a change (cancelling the dependent jobs, launching the ticks of a schedule) are not checked again
+
+// Same engine for a `Principal`, the storage and the inboxes are shared
+func (j *Junjoold) As(principal *types.Principal) *Junjoold {
+	return &Junjoold{
+		storageImplementation: j.storageImplementation,
+		inbox:                 j.inbox,
+		principal:             principal,
+	}
+}
+
+// Records of the permission checks, oldest first
+func (j *Junjoold) GetAuditRecords() ([]types.AuditRecord, error) {
+	if err := j.authorize(types.ActionAuditRead, types.Scope{}, ""); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetAuditRecords()
+}
+
+// Check and record the action of the principal, the record comes first so a denial is never lost
+func (j *Junjoold) authorize(action types.Action, scope types.Scope, target string) error {
+	if j.principal == nil {
+		return nil
+	}
+	allowed := j.principal.Allows(action, scope)
+	if err := j.storageImplementation.AddAuditRecord(types.AuditRecord{
+		Principal: j.principal.Name,
+		Action:    action,
+		TopicID:   scope.TopicID,
+		Target:    target,
+		Allowed:   allowed,
+	}); err != nil {
+		return err
+	}
+	switch {
+	case allowed:
+	case target == "":
+		return fmt.Errorf("%w: %v can't %v", types.ErrPermissionDenied, j.principal.Name, action)
+	default:
+		return fmt.Errorf("%w: %v can't %v %v", types.ErrPermissionDenied, j.principal.Name, action, target)
+	}
+	return nil
+}
+
+// The inbox of the owner, on one topic or all of them
+func (j *Junjoold) authorizeInbox(ownerID types.OwnerID, topicID types.TopicID) error {
+	return j.authorize(types.ActionInboxRead, types.Scope{TopicID: topicID, OwnerIDs: []types.OwnerID{ownerID}}, string(ownerID))
+}
+
+func (j *Junjoold) authorizeTopic(action types.Action, topicID types.TopicID) error {
+	return j.authorize(action, types.Scope{TopicID: topicID}, string(topicID))
+}
+
+func (j *Junjoold) authorizeJob(action types.Action, jobID types.JobID) error {
+	if j.principal == nil {
+		return nil
+	}
+	job, err := j.storageImplementation.GetJob(jobID)
+	if err != nil {
+		return err
+	}
+	return j.authorize(action, types.Scope{TopicID: job.TopicID}, string(jobID))
+}
+
+func (j *Junjoold) authorizeTask(action types.Action, taskID types.TaskID) error {
+	if j.principal == nil {
+		return nil
+	}
+	task, err := j.storageImplementation.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+	topicID, err := j.jobTopic(task.JobID)
+	if err != nil {
+		return err
+	}
+	return j.authorize(action, types.Scope{TopicID: topicID}, string(taskID))
+}
+
+// The owners of the unit (its owner and the members of its pool) can act on it, unless the action is done as someone given
+func (j *Junjoold) authorizeUnit(action types.Action, taskUnitID types.TaskUnitID, actingAs ...types.OwnerID) error {
+	if j.principal == nil {
+		return nil
+	}
+	unit, err := j.storageImplementation.GetTaskUnit(taskUnitID)
+	if err != nil {
+		return err
+	}
+	scope := types.Scope{OwnerIDs: actingAs}
+	if len(actingAs) == 0 {
+		definition, err := j.unitDefinition(unit)
+		if err != nil {
+			return err
+		}
+		if scope.OwnerIDs, err = j.poolMembers(unit.Owner(definition)); err != nil {
+			return err
+		}
+	}
+	if unit.TaskID != "" {
+		task, err := j.storageImplementation.GetTask(unit.TaskID)
+		if err != nil {
+			return err
+		}
+		if scope.TopicID, err = j.jobTopic(task.JobID); err != nil {
+			return err
+		}
+	}
+	return j.authorize(action, scope, string(taskUnitID))
+}
+
+// Topic of a job, none for a draft
+func (j *Junjoold) jobTopic(jobID types.JobID) (types.TopicID, error) {
+	if jobID == "" {
+		return "", nil
+	}
+	job, err := j.storageImplementation.GetJob(jobID)
+	if err != nil {
+		return "", err
+	}
+	return job.TopicID, nil
+}
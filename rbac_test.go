@@ -0,0 +1,183 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestRoles$ .
+func TestRoles(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var network, storage *types.Topic
+	if network, err = jj.CreateTopic("Network"); err != nil {
+		t.Error(err)
+		return
+	}
+	if storage, err = jj.CreateTopic("Storage"); err != nil {
+		t.Error(err)
+		return
+	}
+	var netops, sans *types.Owner
+	if netops, err = jj.CreateOwner("NetOps"); err != nil {
+		t.Error(err)
+		return
+	}
+	if sans, err = jj.CreateOwner("SANs"); err != nil {
+		t.Error(err)
+		return
+	}
+	var cabling, zoning *types.TaskDefinition
+	if cabling, err = jj.CreateTaskDefinition("cabling", netops.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if zoning, err = jj.CreateTaskDefinition("zoning", sans.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	manager := jj.As(&types.Principal{Name: "network-lead", Roles: []types.RoleBinding{{Role: types.RoleTopicManager, TopicID: network.Key}}})
+	worker := jj.As(&types.Principal{Name: "netops-bot", Roles: []types.RoleBinding{{Role: types.RoleOwner, OwnerID: netops.Key}}})
+	viewer := jj.As(&types.Principal{Name: "auditor", Roles: []types.RoleBinding{{Role: types.RoleViewer}}})
+
+	launch := func(jj *Junjoold, topicID types.TopicID, definition *types.TaskDefinition) (*types.Job, types.TaskUnitID, error) {
+		unitDag := jj.CreateDagTaskUnits()
+		node := unitDag.AddTaskDefinition(definition)()
+		job, err := jj.LaunchJob(topicID, unitDag)
+		if err != nil {
+			return nil, "", err
+		}
+		return job, node.(*types.NodeTaskUnit).Unit.Key, nil
+	}
+
+	var networkJob, storageJob *types.Job
+	var cable, zone types.TaskUnitID
+	if networkJob, cable, err = launch(manager, network.Key, cabling); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, _, err = launch(manager, storage.Key, zoning); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v outside of the topic, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if storageJob, zone, err = launch(jj, storage.Key, zoning); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = manager.CreateTopic("Compute"); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v for a topic manager creating a topic, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+
+	// workers report on their own units only, and never cancel jobs
+	if err = worker.SubmitCommand(cable, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = worker.SubmitCommand(zone, types.Command{Type: types.SuccessCmd}); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v on the unit of another owner, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if err = worker.CancelJob(storageJob.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v for a worker cancelling a job, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if err = worker.ClaimTaskUnit(zone, sans.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v claiming as another owner, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if err = viewer.CancelJob(networkJob.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v for a viewer, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if err = manager.CancelJob(storageJob.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v cancelling the job of another topic, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+
+	// owners read their own inbox and units, the others read the jobs of their topics
+	if _, err = worker.GetInbox(netops.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = worker.GetInbox(sans.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v reading the inbox of another owner, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if _, err = worker.GetTaskUnit(cable); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = viewer.GetJob(storageJob.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = manager.GetJobs(storage.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v reading the jobs of another topic, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if _, err = manager.GetTaskUnit(zone); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v reading the unit of another topic, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	var batchID types.BatchID
+	batchDag := jj.CreateDagTaskUnits()
+	batchDag.AddTaskDefinition(zoning)()
+	if batchID, err = jj.LaunchBatch(storage.Key, batchDag, []map[string]string{{"rack": "A"}}); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = manager.GetBatch(batchID); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v reading the batch of another topic, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+
+	// the webhooks carry their secret, only the ones managing them read them
+	var hook *types.Webhook
+	if hook, err = jj.CreateWebhook("http://localhost/hook", types.WithWebhookTopic(storage.Key), types.WithWebhookSecret("s3cr3t")); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = manager.GetWebhook(hook.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v reading the webhook of another topic, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if _, err = manager.GetDeliveries(hook.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v reading the deliveries of another topic, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	if _, err = viewer.GetWebhooks(storage.Key); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v for a viewer reading the webhooks, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+
+	if _, err = manager.GetAuditRecords(); !errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected %v reading the audit, got %v", types.ErrPermissionDenied, err)
+		return
+	}
+	var records []types.AuditRecord
+	if records, err = jj.GetAuditRecords(); err != nil {
+		t.Error(err)
+		return
+	}
+	// the system isn't audited
+	denied := 0
+	for i := 0; i < len(records); i++ {
+		if !records[i].Allowed {
+			denied++
+		}
+	}
+	if len(records) != 20 || denied != 15 {
+		t.Errorf("expected 20 records with 15 denials, got %v", records)
+		return
+	}
+	if records[1].Principal != "network-lead" || records[1].Action != types.ActionJobLaunch || records[1].TopicID != storage.Key || records[1].Allowed {
+		t.Errorf("expected the denied launch recorded, got %v", records[1])
+		return
+	}
+}
@@ -0,0 +1,246 @@
+package junjo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Every unassigned `Job`, `Task` and `TaskUnit` is a draft, the `Reaper` deletes the ones older than a TTL
+/// It starts in dry-run mode: it only reports what it would delete until you switch it to enforce mode
+///
+///	reaper := jj.NewReaper(junjo.WithReaperTTL(time.Hour), junjo.WithReaperMode(junjo.ReaperEnforce))
+///	go reaper.Run(ctx)
+///
+/// When a draft `Job` is deleted its tasks and their units are deleted with it, same for a draft `Task` and its units
+/// A draft assigned between the listing and the delete is kept, the report lists it as skipped
+
+type ReaperMode string
+
+const (
+	ReaperDryRun  ReaperMode = "dry-run"
+	ReaperEnforce ReaperMode = "enforce"
+)
+
+// What a pass of the `Reaper` found, and deleted in enforce mode
+type ReapReport struct {
+	Mode      ReaperMode         `json:"mode"`
+	At        time.Time          `json:"at"`
+	TTL       time.Duration      `json:"ttl"`
+	Jobs      []types.JobID      `json:"jobs"`
+	Tasks     []types.TaskID     `json:"tasks"`
+	TaskUnits []types.TaskUnitID `json:"taskUnits"`
+	// the drafts assigned before we could delete them, kept in enforce mode
+	SkippedJobs      []types.JobID      `json:"skippedJobs,omitempty"`
+	SkippedTasks     []types.TaskID     `json:"skippedTasks,omitempty"`
+	SkippedTaskUnits []types.TaskUnitID `json:"skippedTaskUnits,omitempty"`
+	Err              error              `json:"-"`
+}
+
+func (r ReapReport) String() string {
+	verb := "would delete"
+	if r.Mode == ReaperEnforce {
+		verb = "deleted"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("reaper (%v, ttl %v): %v %v job(s), %v task(s), %v unit(s)", r.Mode, r.TTL, verb, len(r.Jobs), len(r.Tasks), len(r.TaskUnits)))
+	if skipped := len(r.SkippedJobs) + len(r.SkippedTasks) + len(r.SkippedTaskUnits); skipped > 0 {
+		builder.WriteString(fmt.Sprintf(", skipped %v assigned draft(s)", skipped))
+	}
+	if r.Err != nil {
+		builder.WriteString(fmt.Sprintf(", error: %v", r.Err))
+	}
+	return builder.String()
+}
+
+type Reaper struct {
+	jj       *Junjoold
+	ttl      time.Duration
+	interval time.Duration
+	mode     ReaperMode
+	report   func(ReapReport)
+	now      func() time.Time
+}
+
+type ReaperConfig func(r *Reaper)
+
+// Age after which a draft is deleted, one day by default
+func WithReaperTTL(ttl time.Duration) ReaperConfig {
+	return func(r *Reaper) {
+		r.ttl = ttl
+	}
+}
+
+// Delay between two passes of `Run`, one hour by default
+func WithReaperInterval(interval time.Duration) ReaperConfig {
+	return func(r *Reaper) {
+		r.interval = interval
+	}
+}
+
+func WithReaperMode(mode ReaperMode) ReaperConfig {
+	return func(r *Reaper) {
+		r.mode = mode
+	}
+}
+
+// Receive the report of each pass of `Run`, they are logged by default
+func WithReaperReport(report func(ReapReport)) ReaperConfig {
+	return func(r *Reaper) {
+		r.report = report
+	}
+}
+
+// Replace the clock, useful for your tests
+func WithReaperClock(now func() time.Time) ReaperConfig {
+	return func(r *Reaper) {
+		r.now = now
+	}
+}
+
+// Create a `Reaper` for the drafts of the storage, call `Run` to start it in the background
+func (j *Junjoold) NewReaper(cfgs ...ReaperConfig) *Reaper {
+	reaper := &Reaper{
+		jj:       j,
+		ttl:      24 * time.Hour,
+		interval: time.Hour,
+		mode:     ReaperDryRun,
+		report: func(report ReapReport) {
+			log.Println(report)
+		},
+		now: time.Now,
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](reaper)
+	}
+	return reaper
+}
+
+// Reap once every interval until the context is done
+func (r *Reaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.report(r.Reap())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Find the drafts older than the TTL and delete them in enforce mode
+// The storage only deletes what is still a draft, the ones assigned since the listing are kept and reported as skipped
+func (r *Reaper) Reap() ReapReport {
+	storage := r.jj.storageImplementation
+	report := ReapReport{
+		Mode:      r.mode,
+		At:        r.now(),
+		TTL:       r.ttl,
+		Jobs:      []types.JobID{},
+		Tasks:     []types.TaskID{},
+		TaskUnits: []types.TaskUnitID{},
+	}
+	deadline := report.At.Add(-r.ttl)
+
+	jobs, err := storage.GetDraftJobs()
+	if err != nil {
+		report.Err = err
+		return report
+	}
+	for i := 0; i < len(jobs); i++ {
+		if !jobs[i].CreatedAt.Before(deadline) {
+			continue
+		}
+		tasks, units, err := r.collectTasks(jobs[i].TaskIDs)
+		if err != nil {
+			report.Err = err
+			return report
+		}
+		if r.mode == ReaperEnforce {
+			err = storage.DeleteDraftJob(jobs[i].Key)
+			if errors.Is(err, types.ErrNotDraft) {
+				report.SkippedJobs = append(report.SkippedJobs, jobs[i].Key)
+				continue
+			}
+			if err != nil {
+				report.Err = err
+				return report
+			}
+		}
+		report.Jobs = append(report.Jobs, jobs[i].Key)
+		report.Tasks = append(report.Tasks, tasks...)
+		report.TaskUnits = append(report.TaskUnits, units...)
+	}
+
+	tasks, err := storage.GetDraftTasks()
+	if err != nil {
+		report.Err = err
+		return report
+	}
+	for i := 0; i < len(tasks); i++ {
+		if !tasks[i].CreatedAt.Before(deadline) {
+			continue
+		}
+		if r.mode == ReaperEnforce {
+			err = storage.DeleteDraftTask(tasks[i].Key)
+			if errors.Is(err, types.ErrNotDraft) {
+				report.SkippedTasks = append(report.SkippedTasks, tasks[i].Key)
+				continue
+			}
+			if err != nil {
+				report.Err = err
+				return report
+			}
+		}
+		report.Tasks = append(report.Tasks, tasks[i].Key)
+		report.TaskUnits = append(report.TaskUnits, tasks[i].TaskUnitIDs...)
+	}
+
+	units, err := storage.GetDraftTaskUnits()
+	if err != nil {
+		report.Err = err
+		return report
+	}
+	for i := 0; i < len(units); i++ {
+		if !units[i].CreatedAt.Before(deadline) {
+			continue
+		}
+		if r.mode == ReaperEnforce {
+			err = storage.DeleteDraftTaskUnit(units[i].Key)
+			if errors.Is(err, types.ErrNotDraft) {
+				report.SkippedTaskUnits = append(report.SkippedTaskUnits, units[i].Key)
+				continue
+			}
+			if err != nil {
+				report.Err = err
+				return report
+			}
+		}
+		report.TaskUnits = append(report.TaskUnits, units[i].Key)
+	}
+
+	return report
+}
+
+// Tasks and units deleted with their draft job
+func (r *Reaper) collectTasks(taskIDs []types.TaskID) ([]types.TaskID, []types.TaskUnitID, error) {
+	tasks := []types.TaskID{}
+	units := []types.TaskUnitID{}
+	for i := 0; i < len(taskIDs); i++ {
+		task, err := r.jj.storageImplementation.GetTask(taskIDs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		tasks = append(tasks, task.Key)
+		units = append(units, task.TaskUnitIDs...)
+	}
+	return tasks, units, nil
+}
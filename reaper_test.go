@@ -0,0 +1,251 @@
+package junjo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestReaper$ .
+func TestReaper(t *testing.T) {
+	storage := memory.NewMemoryStorage()
+	var err error
+	jj := NewJ(storage)
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	unitDag.AddTaskDefinition(provisioning)()
+
+	// a launched job is never a draft
+	var job *types.Job
+	if job, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// abandoned halfway: a task with its unit, an empty job and a lonely unit
+	var clone *types.WorkUnitDag
+	if clone, err = unitDag.Clone(); err != nil {
+		t.Error(err)
+		return
+	}
+	var units []*types.TaskUnit
+	if units, err = clone.ToTaskUnits(); err != nil {
+		t.Error(err)
+		return
+	}
+	var ids []types.TaskUnitID
+	if ids, err = storage.CreateTaskUnits(units); err != nil {
+		t.Error(err)
+		return
+	}
+	var task *types.Task
+	if task, err = jj.CreateTask(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = storage.AssignTaskUnits(task.Key, ids); err != nil {
+		t.Error(err)
+		return
+	}
+	var draftJob *types.Job
+	if draftJob, err = jj.CreateJob(); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = storage.CreateTaskUnits([]*types.TaskUnit{types.NewTaskUnit("lonely")}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// nothing is old enough yet
+	reaper := jj.NewReaper(WithReaperTTL(time.Hour), WithReaperMode(ReaperEnforce))
+	if report := reaper.Reap(); report.Err != nil || len(report.Jobs)+len(report.Tasks)+len(report.TaskUnits) != 0 {
+		t.Errorf("expected nothing to reap, got %v", report)
+		return
+	}
+
+	later := func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	dryRun := jj.NewReaper(WithReaperTTL(time.Hour), WithReaperClock(later))
+	report := dryRun.Reap()
+	if report.Err != nil {
+		t.Error(report.Err)
+		return
+	}
+	if len(report.Jobs) != 1 || report.Jobs[0] != draftJob.Key || len(report.Tasks) != 1 || report.Tasks[0] != task.Key || len(report.TaskUnits) != 2 {
+		t.Errorf("unexpected dry-run report %v %v %v", report.Jobs, report.Tasks, report.TaskUnits)
+		return
+	}
+	if _, err = jj.GetTask(task.Key); err != nil {
+		t.Errorf("dry-run should not delete anything: %v", err)
+		return
+	}
+
+	enforce := jj.NewReaper(WithReaperTTL(time.Hour), WithReaperClock(later), WithReaperMode(ReaperEnforce))
+	if report = enforce.Reap(); report.Err != nil {
+		t.Error(report.Err)
+		return
+	}
+	if _, err = jj.GetTask(task.Key); err == nil {
+		t.Errorf("task %v should be deleted", task.Key)
+		return
+	}
+	if _, err = jj.GetTaskUnit("lonely"); err == nil {
+		t.Errorf("unit should be deleted")
+		return
+	}
+	if _, err = jj.GetJob(job.Key); err != nil {
+		t.Errorf("launched job should be kept: %v", err)
+		return
+	}
+	if report = enforce.Reap(); len(report.Jobs)+len(report.Tasks)+len(report.TaskUnits) != 0 {
+		t.Errorf("expected nothing left, got %v", report)
+		return
+	}
+}
+
+// Assigns the drafts right after the reaper listed them, as a launch running alongside the pass would
+type assigningStorage struct {
+	types.StorageInterface
+	topicID types.TopicID
+	jobID   types.JobID
+	taskID  types.TaskID
+}
+
+func (s *assigningStorage) GetDraftJobs() ([]types.Job, error) {
+	jobs, err := s.StorageInterface.GetDraftJobs()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(jobs); i++ {
+		if err = s.AssignJob(s.topicID, jobs[i].Key); err != nil {
+			return nil, err
+		}
+	}
+	return jobs, nil
+}
+
+func (s *assigningStorage) GetDraftTasks() ([]types.Task, error) {
+	tasks, err := s.StorageInterface.GetDraftTasks()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(tasks); i++ {
+		if err = s.AssignTask(s.jobID, tasks[i].Key); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+func (s *assigningStorage) GetDraftTaskUnits() ([]types.TaskUnit, error) {
+	units, err := s.StorageInterface.GetDraftTaskUnits()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(units); i++ {
+		if err = s.AssignTaskUnits(s.taskID, []types.TaskUnitID{units[i].Key}); err != nil {
+			return nil, err
+		}
+	}
+	return units, nil
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestReaperAssignedDraft$ .
+func TestReaperAssignedDraft(t *testing.T) {
+	storage := memory.NewMemoryStorage()
+	var err error
+
+	var topic *types.Topic
+	if topic, err = storage.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the job and the task the drafts are assigned to, created as drafts too so we assign them first
+	var target *types.Job
+	if target, err = storage.CreateJob(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = storage.AssignJob(topic.Key, target.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	var targetTask *types.Task
+	if targetTask, err = storage.CreateTask(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = storage.AssignTask(target.Key, targetTask.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var draftJob *types.Job
+	if draftJob, err = storage.CreateJob(); err != nil {
+		t.Error(err)
+		return
+	}
+	var draftTask *types.Task
+	if draftTask, err = storage.CreateTask(); err != nil {
+		t.Error(err)
+		return
+	}
+	var ids []types.TaskUnitID
+	if ids, err = storage.CreateTaskUnits([]*types.TaskUnit{types.NewTaskUnit("lonely")}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	jj := NewJ(&assigningStorage{StorageInterface: storage, topicID: topic.Key, jobID: target.Key, taskID: targetTask.Key})
+	later := func() time.Time { return time.Now().Add(2 * time.Hour) }
+	report := jj.NewReaper(WithReaperTTL(time.Hour), WithReaperClock(later), WithReaperMode(ReaperEnforce)).Reap()
+	if report.Err != nil {
+		t.Error(report.Err)
+		return
+	}
+	if len(report.Jobs)+len(report.Tasks)+len(report.TaskUnits) != 0 {
+		t.Errorf("assigned drafts should not be deleted, got %v %v %v", report.Jobs, report.Tasks, report.TaskUnits)
+		return
+	}
+	if len(report.SkippedJobs) != 1 || report.SkippedJobs[0] != draftJob.Key ||
+		len(report.SkippedTasks) != 1 || report.SkippedTasks[0] != draftTask.Key ||
+		len(report.SkippedTaskUnits) != 1 || report.SkippedTaskUnits[0] != ids[0] {
+		t.Errorf("assigned drafts should be reported as skipped, got %v %v %v", report.SkippedJobs, report.SkippedTasks, report.SkippedTaskUnits)
+		return
+	}
+
+	if _, err = storage.GetJob(draftJob.Key); err != nil {
+		t.Errorf("assigned job should be kept: %v", err)
+		return
+	}
+	if _, err = storage.GetTask(draftTask.Key); err != nil {
+		t.Errorf("assigned task should be kept: %v", err)
+		return
+	}
+	if _, err = storage.GetTaskUnit(ids[0]); err != nil {
+		t.Errorf("assigned unit should be kept: %v", err)
+		return
+	}
+}
@@ -0,0 +1,71 @@
+package junjo
+
+import (
+	"fmt"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Reassigning a unit overrides the owner of its definition for that unit only, when a contractor goes dark
+///
+///	jj.ReassignTaskUnit(unit.Key, backup.Key, "contractor unreachable since monday")
+///
+/// A unit already started goes back to none, the new owner starts it over from its inbox
+
+// Hand an unfinished unit to another owner, the change is kept in its commands as a `DelegateCmd`
+func (j *Junjoold) ReassignTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID, reason string) error {
+	if err := j.authorizeUnit(types.ActionUnitCommand, taskUnitID); err != nil {
+		return err
+	}
+	unit, err := j.storageImplementation.GetTaskUnit(taskUnitID)
+	if err != nil {
+		return err
+	}
+	return j.reassignTaskUnit(unit, ownerID, reason)
+}
+
+// Reassign without checking the roles, for the migrations
+func (j *Junjoold) reassignTaskUnit(unit *types.TaskUnit, ownerID types.OwnerID, reason string) error {
+	taskUnitID := unit.Key
+	// an expanded fan-out only moves with its children, they are reassigned one by one
+	if (unit.FanOut != nil && unit.Status != types.NoneStatus) || unit.Status == types.SuccessStatus || unit.Status == types.ErrorStatus {
+		return types.ErrTaskUnitNotAvailable
+	}
+	definition, err := j.unitDefinition(unit)
+	if err != nil {
+		return err
+	}
+	previous := unit.Owner(definition)
+	if previous == ownerID {
+		return fmt.Errorf("%w: the unit is already owned by %v", types.ErrReassignInvalid, ownerID)
+	}
+
+	if err = j.storageImplementation.ReassignTaskUnit(taskUnitID, ownerID); err != nil {
+		return err
+	}
+	if unit.Status != types.NoneStatus {
+		if err = j.storageImplementation.UpdateTaskUnitStatus(taskUnitID, types.NoneStatus, nil); err != nil {
+			return err
+		}
+	}
+	err = j.storageImplementation.AddTaskUnitCommand(taskUnitID, types.Command{
+		Type:    types.DelegateCmd,
+		Details: reason,
+		Data: map[string]string{
+			types.DelegateFrom: string(previous),
+			types.DelegateTo:   string(ownerID),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the units don't lead to the previous owner anymore
+	if members, err := j.poolMembers(previous); err == nil {
+		j.inbox.notify(members...)
+	}
+	if unit.TaskID == "" {
+		return nil
+	}
+	return j.rollup(unit.TaskID)
+}
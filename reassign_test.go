@@ -0,0 +1,97 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestReassignTaskUnit$ .
+func TestReassignTaskUnit(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Migration"); err != nil {
+		t.Error(err)
+		return
+	}
+	var contractor, backup *types.Owner
+	if contractor, err = jj.CreateOwner("Contractor"); err != nil {
+		t.Error(err)
+		return
+	}
+	if backup, err = jj.CreateOwner("Backup"); err != nil {
+		t.Error(err)
+		return
+	}
+	var migrate *types.TaskDefinition
+	if migrate, err = jj.CreateTaskDefinition("migrate the database", contractor.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	unitDag := jj.CreateDagTaskUnits()
+	node := unitDag.AddTaskDefinition(migrate)()
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	unitID := node.(*types.NodeTaskUnit).Unit.Key
+
+	offered := func(ownerID types.OwnerID) bool {
+		inbox, err := jj.GetInbox(ownerID)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		return len(inbox) == 1 && len(inbox[0].TaskUnits) == 1 && inbox[0].TaskUnits[0].Key == unitID
+	}
+
+	// the contractor started then went dark
+	if err = jj.SubmitCommand(unitID, types.Command{Type: types.ProgressCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	contractorChanged := jj.InboxChanged(contractor.Key)
+	if err = jj.ReassignTaskUnit(unitID, backup.Key, "unreachable"); err != nil {
+		t.Error(err)
+		return
+	}
+	select {
+	case <-contractorChanged:
+	default:
+		t.Error("expected the inbox of the contractor to change")
+		return
+	}
+	if offered(contractor.Key) || !offered(backup.Key) {
+		t.Error("expected the unit offered to the backup only")
+		return
+	}
+	var unit *types.TaskUnit
+	if unit, err = jj.GetTaskUnit(unitID); err != nil {
+		t.Error(err)
+		return
+	}
+	last := unit.Commands[len(unit.Commands)-1]
+	if unit.Status != types.NoneStatus || last.Type != types.DelegateCmd || last.Details != "unreachable" || last.Data[types.DelegateFrom] != string(contractor.Key) {
+		t.Errorf("expected the reassignment recorded and the unit back to none, got %v %v", unit.Status, last)
+		return
+	}
+	if err = jj.ReassignTaskUnit(unitID, backup.Key, "again"); !errors.Is(err, types.ErrReassignInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrReassignInvalid, err)
+		return
+	}
+
+	// the backup gives it back once the contractor is reachable
+	delegate := types.Command{Type: types.DelegateCmd, Details: "back online", Data: map[string]string{types.DelegateTo: string(contractor.Key)}}
+	if err = jj.SubmitCommand(unitID, delegate); err != nil {
+		t.Error(err)
+		return
+	}
+	if !offered(contractor.Key) || offered(backup.Key) {
+		t.Error("expected the unit offered to the contractor again")
+		return
+	}
+}
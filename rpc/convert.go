@@ -0,0 +1,633 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/davidroman0O/junjo/rpc/junjopb"
+	"github.com/davidroman0O/junjo/types"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/// Conversions between the `types` entities and their `junjopb` messages
+/// Like the HTTP api the runtime pointers are not sent, `TaskUnit.Error` is sent as a message
+
+// A zero time is sent as no timestamp
+func toTimestamp(value time.Time) *timestamppb.Timestamp {
+	if value.IsZero() {
+		return nil
+	}
+	return timestamppb.New(value)
+}
+
+func fromTimestamp(value *timestamppb.Timestamp) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	return value.AsTime()
+}
+
+func toTopic(topic types.Topic) *junjopb.Topic {
+	value := &junjopb.Topic{
+		Id:          string(topic.Key),
+		Name:        topic.Name,
+		Description: topic.Description,
+		Deprecated:  topic.Deprecated,
+	}
+	for i := 0; i < len(topic.JobIDs); i++ {
+		value.JobIds = append(value.JobIds, string(topic.JobIDs[i]))
+	}
+	return value
+}
+
+func FromTopic(topic *junjopb.Topic) types.Topic {
+	value := types.Topic{
+		Key:         types.TopicID(topic.GetId()),
+		Name:        topic.GetName(),
+		Description: topic.GetDescription(),
+		Deprecated:  topic.GetDeprecated(),
+		JobIDs:      []types.JobID{},
+	}
+	for _, id := range topic.GetJobIds() {
+		value.JobIDs = append(value.JobIDs, types.JobID(id))
+	}
+	return value
+}
+
+func toOwner(owner types.Owner) *junjopb.Owner {
+	return &junjopb.Owner{
+		Id:          string(owner.Key),
+		Name:        owner.Name,
+		Description: owner.Description,
+		Members:     fromOwnerIDs(owner.Members),
+		Deprecated:  owner.Deprecated,
+	}
+}
+
+func FromOwner(owner *junjopb.Owner) types.Owner {
+	return types.Owner{
+		Key:         types.OwnerID(owner.GetId()),
+		Name:        owner.GetName(),
+		Description: owner.GetDescription(),
+		Members:     toOwnerIDs(owner.GetMembers()),
+		Deprecated:  owner.GetDeprecated(),
+	}
+}
+
+func toTaskUnitIDs(ids []types.TaskUnitID) *junjopb.TaskUnitIDs {
+	value := &junjopb.TaskUnitIDs{}
+	for i := 0; i < len(ids); i++ {
+		value.Ids = append(value.Ids, string(ids[i]))
+	}
+	return value
+}
+
+func toOwnerIDs(ids []string) []types.OwnerID {
+	if len(ids) == 0 {
+		return nil
+	}
+	values := make([]types.OwnerID, 0, len(ids))
+	for i := 0; i < len(ids); i++ {
+		values = append(values, types.OwnerID(ids[i]))
+	}
+	return values
+}
+
+func fromOwnerIDs(ids []types.OwnerID) []string {
+	values := make([]string, 0, len(ids))
+	for i := 0; i < len(ids); i++ {
+		values = append(values, string(ids[i]))
+	}
+	return values
+}
+
+func toTaskDefinition(definition types.TaskDefinition) *junjopb.TaskDefinition {
+	return &junjopb.TaskDefinition{
+		Id:          string(definition.Key),
+		Name:        definition.Name,
+		Description: definition.Description,
+		Details:     definition.Details,
+		Identifier:  definition.Identifier,
+		OwnerId:     string(definition.OwnerID),
+		Approval:    toApproval(definition.Approval),
+		Version:     int32(definition.Version),
+		Deprecated:  definition.Deprecated,
+		CreatedAt:   toTimestamp(definition.CreatedAt),
+	}
+}
+
+func FromTaskDefinition(definition *junjopb.TaskDefinition) types.TaskDefinition {
+	return types.TaskDefinition{
+		Key:         types.TaskDefinitionID(definition.GetId()),
+		Name:        definition.GetName(),
+		Description: definition.GetDescription(),
+		Details:     definition.GetDetails(),
+		Identifier:  definition.GetIdentifier(),
+		OwnerID:     types.OwnerID(definition.GetOwnerId()),
+		Approval:    FromApproval(definition.GetApproval()),
+		Version:     int(definition.GetVersion()),
+		Deprecated:  definition.GetDeprecated(),
+		CreatedAt:   fromTimestamp(definition.GetCreatedAt()),
+	}
+}
+
+func toApproval(approval *types.Approval) *junjopb.Approval {
+	if approval == nil {
+		return nil
+	}
+	value := &junjopb.Approval{
+		Required:  int32(approval.Required),
+		Approvers: fromOwnerIDs(approval.Approvers),
+	}
+	if approval.ExpiresAfter > 0 {
+		value.ExpiresAfter = durationpb.New(approval.ExpiresAfter)
+	}
+	return value
+}
+
+func FromApproval(approval *junjopb.Approval) *types.Approval {
+	if approval == nil {
+		return nil
+	}
+	return &types.Approval{
+		Required:     int(approval.GetRequired()),
+		Approvers:    toOwnerIDs(approval.GetApprovers()),
+		ExpiresAfter: approval.GetExpiresAfter().AsDuration(),
+	}
+}
+
+func toJob(job types.Job) *junjopb.Job {
+	value := &junjopb.Job{
+		Id:             string(job.Key),
+		TopicId:        string(job.TopicID),
+		Status:         string(job.Status),
+		Data:           job.Data,
+		Priority:       int32(job.Priority),
+		BatchId:        string(job.BatchID),
+		ScheduleId:     string(job.ScheduleID),
+		UpstreamPolicy: string(job.Upstream),
+		CreatedAt:      toTimestamp(job.CreatedAt),
+	}
+	for i := 0; i < len(job.TaskIDs); i++ {
+		value.TaskIds = append(value.TaskIds, string(job.TaskIDs[i]))
+	}
+	value.DependsOn = fromJobIDs(job.DependsOn)
+	return value
+}
+
+func toJobs(jobs []types.Job) *junjopb.Jobs {
+	values := &junjopb.Jobs{}
+	for i := 0; i < len(jobs); i++ {
+		values.Jobs = append(values.Jobs, toJob(jobs[i]))
+	}
+	return values
+}
+
+func FromJob(job *junjopb.Job) types.Job {
+	value := types.Job{
+		Key:        types.JobID(job.GetId()),
+		TopicID:    types.TopicID(job.GetTopicId()),
+		Status:     types.StatusType(job.GetStatus()),
+		Data:       job.GetData(),
+		Priority:   types.Priority(job.GetPriority()),
+		BatchID:    types.BatchID(job.GetBatchId()),
+		ScheduleID: types.ScheduleID(job.GetScheduleId()),
+		DependsOn:  toJobIDs(job.GetDependsOn()),
+		Upstream:   types.UpstreamPolicy(job.GetUpstreamPolicy()),
+		CreatedAt:  fromTimestamp(job.GetCreatedAt()),
+		TaskIDs:    []types.TaskID{},
+	}
+	for _, id := range job.GetTaskIds() {
+		value.TaskIDs = append(value.TaskIDs, types.TaskID(id))
+	}
+	return value
+}
+
+func toJobIDs(ids []string) []types.JobID {
+	if len(ids) == 0 {
+		return nil
+	}
+	values := make([]types.JobID, 0, len(ids))
+	for i := 0; i < len(ids); i++ {
+		values = append(values, types.JobID(ids[i]))
+	}
+	return values
+}
+
+func fromJobIDs(ids []types.JobID) []string {
+	values := make([]string, 0, len(ids))
+	for i := 0; i < len(ids); i++ {
+		values = append(values, string(ids[i]))
+	}
+	return values
+}
+
+func toTask(task types.Task) *junjopb.Task {
+	value := &junjopb.Task{
+		Id:        string(task.Key),
+		JobId:     string(task.JobID),
+		Status:    string(task.Status),
+		CreatedAt: toTimestamp(task.CreatedAt),
+	}
+	if task.Priority != nil {
+		priority := int32(*task.Priority)
+		value.Priority = &priority
+	}
+	for i := 0; i < len(task.TaskUnitIDs); i++ {
+		value.TaskUnitIds = append(value.TaskUnitIds, string(task.TaskUnitIDs[i]))
+	}
+	return value
+}
+
+func FromTask(task *junjopb.Task) types.Task {
+	value := types.Task{
+		Key:         types.TaskID(task.GetId()),
+		JobID:       types.JobID(task.GetJobId()),
+		Status:      types.StatusType(task.GetStatus()),
+		CreatedAt:   fromTimestamp(task.GetCreatedAt()),
+		TaskUnitIDs: []types.TaskUnitID{},
+	}
+	if task.Priority != nil {
+		priority := types.Priority(task.GetPriority())
+		value.Priority = &priority
+	}
+	for _, id := range task.GetTaskUnitIds() {
+		value.TaskUnitIDs = append(value.TaskUnitIDs, types.TaskUnitID(id))
+	}
+	return value
+}
+
+func toCommand(cmd types.Command) *junjopb.Command {
+	return &junjopb.Command{
+		Type:    string(cmd.Type),
+		Status:  string(cmd.Status),
+		Details: cmd.Details,
+		Data:    cmd.Data,
+	}
+}
+
+func FromCommand(cmd *junjopb.Command) types.Command {
+	return types.Command{
+		Type:    types.CommandType(cmd.GetType()),
+		Status:  types.StatusType(cmd.GetStatus()),
+		Details: cmd.GetDetails(),
+		Data:    cmd.GetData(),
+	}
+}
+
+func toTaskUnit(unit types.TaskUnit) *junjopb.TaskUnit {
+	value := &junjopb.TaskUnit{
+		Id:                string(unit.Key),
+		TaskDefinitionId:  string(unit.TaskDefinitionID),
+		TaskId:            string(unit.TaskID),
+		Status:            string(unit.Status),
+		Data:              unit.Data,
+		ParentId:          string(unit.ParentID),
+		CreatedAt:         toTimestamp(unit.CreatedAt),
+		ClaimedBy:         string(unit.ClaimedBy),
+		OwnerId:           string(unit.OwnerID),
+		DefinitionVersion: int32(unit.DefinitionVersion),
+		AvailableAt:       toTimestamp(unit.AvailableAt),
+	}
+	if unit.Error != nil {
+		value.Error = unit.Error.Error()
+	}
+	if unit.Priority != nil {
+		priority := int32(*unit.Priority)
+		value.Priority = &priority
+	}
+	for i := 0; i < len(unit.DependsOnIDs); i++ {
+		value.DependsOnIds = append(value.DependsOnIds, string(unit.DependsOnIDs[i]))
+	}
+	for i := 0; i < len(unit.Commands); i++ {
+		value.Commands = append(value.Commands, toCommand(unit.Commands[i]))
+	}
+	return value
+}
+
+func toTaskUnits(units []types.TaskUnit) []*junjopb.TaskUnit {
+	values := make([]*junjopb.TaskUnit, 0, len(units))
+	for i := 0; i < len(units); i++ {
+		values = append(values, toTaskUnit(units[i]))
+	}
+	return values
+}
+
+func FromTaskUnit(unit *junjopb.TaskUnit) types.TaskUnit {
+	value := types.TaskUnit{
+		Key:               types.TaskUnitID(unit.GetId()),
+		TaskDefinitionID:  types.TaskDefinitionID(unit.GetTaskDefinitionId()),
+		TaskID:            types.TaskID(unit.GetTaskId()),
+		Status:            types.StatusType(unit.GetStatus()),
+		Data:              unit.GetData(),
+		ParentID:          types.TaskUnitID(unit.GetParentId()),
+		CreatedAt:         fromTimestamp(unit.GetCreatedAt()),
+		ClaimedBy:         types.OwnerID(unit.GetClaimedBy()),
+		OwnerID:           types.OwnerID(unit.GetOwnerId()),
+		DefinitionVersion: int(unit.GetDefinitionVersion()),
+		AvailableAt:       fromTimestamp(unit.GetAvailableAt()),
+		DependsOnIDs:      []types.TaskUnitID{},
+		Commands:          []types.Command{},
+	}
+	if unit.GetError() != "" {
+		value.Error = errors.New(unit.GetError())
+	}
+	if unit.Priority != nil {
+		priority := types.Priority(unit.GetPriority())
+		value.Priority = &priority
+	}
+	for _, id := range unit.GetDependsOnIds() {
+		value.DependsOnIDs = append(value.DependsOnIDs, types.TaskUnitID(id))
+	}
+	for _, cmd := range unit.GetCommands() {
+		value.Commands = append(value.Commands, FromCommand(cmd))
+	}
+	return value
+}
+
+func FromTaskUnits(units []*junjopb.TaskUnit) []types.TaskUnit {
+	values := make([]types.TaskUnit, 0, len(units))
+	for i := 0; i < len(units); i++ {
+		values = append(values, FromTaskUnit(units[i]))
+	}
+	return values
+}
+
+func toTaskUnitState(state types.TaskUnitState) *junjopb.TaskUnitState {
+	return &junjopb.TaskUnitState{
+		TaskUnitId: string(state.TaskUnitID),
+		Index:      int32(state.Index),
+		Commands:   int32(state.Commands),
+		Data:       state.Data,
+	}
+}
+
+func FromTaskUnitState(state *junjopb.TaskUnitState) types.TaskUnitState {
+	data := state.GetData()
+	if data == nil {
+		data = map[string]string{}
+	}
+	return types.TaskUnitState{
+		TaskUnitID: types.TaskUnitID(state.GetTaskUnitId()),
+		Index:      int(state.GetIndex()),
+		Commands:   int(state.GetCommands()),
+		Data:       data,
+	}
+}
+
+func toConcurrencyLimit(limit types.ConcurrencyLimit) *junjopb.ConcurrencyLimit {
+	return &junjopb.ConcurrencyLimit{
+		OwnerId:          string(limit.OwnerID),
+		TaskDefinitionId: string(limit.TaskDefinitionID),
+		TopicId:          string(limit.TopicID),
+		Max:              int32(limit.Max),
+	}
+}
+
+func toAuditRecord(record types.AuditRecord) *junjopb.AuditRecord {
+	return &junjopb.AuditRecord{
+		Id:        string(record.Key),
+		Principal: record.Principal,
+		Action:    string(record.Action),
+		TopicId:   string(record.TopicID),
+		Target:    record.Target,
+		Allowed:   record.Allowed,
+		CreatedAt: toTimestamp(record.CreatedAt),
+	}
+}
+
+func FromConcurrencyLimit(limit *junjopb.ConcurrencyLimit) types.ConcurrencyLimit {
+	return types.ConcurrencyLimit{
+		OwnerID:          types.OwnerID(limit.GetOwnerId()),
+		TaskDefinitionID: types.TaskDefinitionID(limit.GetTaskDefinitionId()),
+		TopicID:          types.TopicID(limit.GetTopicId()),
+		Max:              int(limit.GetMax()),
+	}
+}
+
+func toTemplate(template types.Template) *junjopb.Template {
+	return &junjopb.Template{
+		Id:          string(template.Key),
+		Name:        template.Name,
+		Version:     int32(template.Version),
+		Description: template.Description,
+		Dag:         template.Dag,
+		CreatedAt:   toTimestamp(template.CreatedAt),
+	}
+}
+
+func toTemplates(templates []types.Template) *junjopb.Templates {
+	values := &junjopb.Templates{}
+	for i := 0; i < len(templates); i++ {
+		values.Templates = append(values.Templates, toTemplate(templates[i]))
+	}
+	return values
+}
+
+func FromTemplate(template *junjopb.Template) types.Template {
+	return types.Template{
+		Key:         types.TemplateID(template.GetId()),
+		Name:        template.GetName(),
+		Version:     int(template.GetVersion()),
+		Description: template.GetDescription(),
+		Dag:         json.RawMessage(template.GetDag()),
+		CreatedAt:   fromTimestamp(template.GetCreatedAt()),
+	}
+}
+
+func toBatchProgress(progress types.BatchProgress) *junjopb.BatchProgress {
+	value := &junjopb.BatchProgress{
+		Id:       string(progress.Key),
+		TopicId:  string(progress.TopicID),
+		Status:   string(progress.Status),
+		Total:    int32(progress.Total),
+		Statuses: map[string]int32{},
+		JobIds:   fromJobIDs(progress.JobIDs),
+	}
+	for status, count := range progress.Statuses {
+		value.Statuses[string(status)] = int32(count)
+	}
+	return value
+}
+
+func FromBatchProgress(progress *junjopb.BatchProgress) types.BatchProgress {
+	value := types.BatchProgress{
+		Key:      types.BatchID(progress.GetId()),
+		TopicID:  types.TopicID(progress.GetTopicId()),
+		Status:   types.StatusType(progress.GetStatus()),
+		Total:    int(progress.GetTotal()),
+		Statuses: map[types.StatusType]int{},
+		JobIDs:   toJobIDs(progress.GetJobIds()),
+	}
+	for status, count := range progress.GetStatuses() {
+		value.Statuses[types.StatusType(status)] = int(count)
+	}
+	return value
+}
+
+func toSchedule(schedule types.Schedule) *junjopb.Schedule {
+	return &junjopb.Schedule{
+		Id:         string(schedule.Key),
+		TopicId:    string(schedule.TopicID),
+		Name:       schedule.Name,
+		Cron:       schedule.Cron,
+		Timezone:   schedule.Timezone,
+		TemplateId: string(schedule.TemplateID),
+		Dag:        schedule.Dag,
+		Data:       schedule.Data,
+		Priority:   int32(schedule.Priority),
+		Overlap:    string(schedule.Overlap),
+		CatchUp:    schedule.CatchUp,
+		Paused:     schedule.Paused,
+		LastTick:   toTimestamp(schedule.LastTick),
+		Pending:    int32(schedule.Pending),
+		LastJobId:  string(schedule.LastJobID),
+		CreatedAt:  toTimestamp(schedule.CreatedAt),
+	}
+}
+
+func toSchedules(schedules []types.Schedule) *junjopb.Schedules {
+	values := &junjopb.Schedules{}
+	for i := 0; i < len(schedules); i++ {
+		values.Schedules = append(values.Schedules, toSchedule(schedules[i]))
+	}
+	return values
+}
+
+func FromSchedule(schedule *junjopb.Schedule) types.Schedule {
+	return types.Schedule{
+		Key:        types.ScheduleID(schedule.GetId()),
+		TopicID:    types.TopicID(schedule.GetTopicId()),
+		Name:       schedule.GetName(),
+		Cron:       schedule.GetCron(),
+		Timezone:   schedule.GetTimezone(),
+		TemplateID: types.TemplateID(schedule.GetTemplateId()),
+		Dag:        json.RawMessage(schedule.GetDag()),
+		Data:       schedule.GetData(),
+		Priority:   types.Priority(schedule.GetPriority()),
+		Overlap:    types.OverlapPolicy(schedule.GetOverlap()),
+		CatchUp:    schedule.GetCatchUp(),
+		Paused:     schedule.GetPaused(),
+		LastTick:   fromTimestamp(schedule.GetLastTick()),
+		Pending:    int(schedule.GetPending()),
+		LastJobID:  types.JobID(schedule.GetLastJobId()),
+		CreatedAt:  fromTimestamp(schedule.GetCreatedAt()),
+	}
+}
+
+func toWebhook(webhook types.Webhook) *junjopb.Webhook {
+	events := make([]string, 0, len(webhook.Events))
+	for i := 0; i < len(webhook.Events); i++ {
+		events = append(events, string(webhook.Events[i]))
+	}
+	return &junjopb.Webhook{
+		Id:        string(webhook.Key),
+		Url:       webhook.URL,
+		TopicId:   string(webhook.TopicID),
+		OwnerId:   string(webhook.OwnerID),
+		Events:    events,
+		CreatedAt: toTimestamp(webhook.CreatedAt),
+	}
+}
+
+func toWebhooks(webhooks []types.Webhook) *junjopb.Webhooks {
+	values := &junjopb.Webhooks{}
+	for i := 0; i < len(webhooks); i++ {
+		values.Webhooks = append(values.Webhooks, toWebhook(webhooks[i]))
+	}
+	return values
+}
+
+func FromWebhook(webhook *junjopb.Webhook) types.Webhook {
+	events := make([]types.EventType, 0, len(webhook.GetEvents()))
+	for _, event := range webhook.GetEvents() {
+		events = append(events, types.EventType(event))
+	}
+	return types.Webhook{
+		Key:       types.WebhookID(webhook.GetId()),
+		URL:       webhook.GetUrl(),
+		TopicID:   types.TopicID(webhook.GetTopicId()),
+		OwnerID:   types.OwnerID(webhook.GetOwnerId()),
+		Events:    events,
+		CreatedAt: fromTimestamp(webhook.GetCreatedAt()),
+	}
+}
+
+func toDeliveries(deliveries []types.Delivery) *junjopb.Deliveries {
+	values := &junjopb.Deliveries{}
+	for i := 0; i < len(deliveries); i++ {
+		event := deliveries[i].Event
+		values.Deliveries = append(values.Deliveries, &junjopb.Delivery{
+			Id:        string(deliveries[i].Key),
+			WebhookId: string(deliveries[i].WebhookID),
+			Event: &junjopb.Event{
+				Id:         event.ID,
+				Type:       string(event.Type),
+				Time:       toTimestamp(event.Time),
+				TopicId:    string(event.TopicID),
+				JobId:      string(event.JobID),
+				TaskId:     string(event.TaskID),
+				TaskUnitId: string(event.TaskUnitID),
+				OwnerId:    string(event.OwnerID),
+				Status:     string(event.Status),
+				Error:      event.Error,
+			},
+			Status:       string(deliveries[i].Status),
+			Attempts:     int32(deliveries[i].Attempts),
+			ResponseCode: int32(deliveries[i].ResponseCode),
+			LastError:    deliveries[i].LastError,
+			NextAttempt:  toTimestamp(deliveries[i].NextAttempt),
+			CreatedAt:    toTimestamp(deliveries[i].CreatedAt),
+			DeliveredAt:  toTimestamp(deliveries[i].DeliveredAt),
+		})
+	}
+	return values
+}
+
+func FromDeliveries(deliveries *junjopb.Deliveries) []types.Delivery {
+	values := make([]types.Delivery, 0, len(deliveries.GetDeliveries()))
+	for _, delivery := range deliveries.GetDeliveries() {
+		event := delivery.GetEvent()
+		values = append(values, types.Delivery{
+			Key:       types.DeliveryID(delivery.GetId()),
+			WebhookID: types.WebhookID(delivery.GetWebhookId()),
+			Event: types.Event{
+				ID:         event.GetId(),
+				Type:       types.EventType(event.GetType()),
+				Time:       fromTimestamp(event.GetTime()),
+				TopicID:    types.TopicID(event.GetTopicId()),
+				JobID:      types.JobID(event.GetJobId()),
+				TaskID:     types.TaskID(event.GetTaskId()),
+				TaskUnitID: types.TaskUnitID(event.GetTaskUnitId()),
+				OwnerID:    types.OwnerID(event.GetOwnerId()),
+				Status:     types.StatusType(event.GetStatus()),
+				Error:      event.GetError(),
+			},
+			Status:       types.DeliveryStatus(delivery.GetStatus()),
+			Attempts:     int(delivery.GetAttempts()),
+			ResponseCode: int(delivery.GetResponseCode()),
+			LastError:    delivery.GetLastError(),
+			NextAttempt:  fromTimestamp(delivery.GetNextAttempt()),
+			CreatedAt:    fromTimestamp(delivery.GetCreatedAt()),
+			DeliveredAt:  fromTimestamp(delivery.GetDeliveredAt()),
+		})
+	}
+	return values
+}
+
+// FromInbox converts back a received inbox, ordered like `Junjoold.GetInbox`
+func FromInbox(inbox *junjopb.Inbox) []types.InboxAllTaskUnit {
+	values := make([]types.InboxAllTaskUnit, 0, len(inbox.GetEntries()))
+	for _, entry := range inbox.GetEntries() {
+		values = append(values, types.InboxAllTaskUnit{
+			TopicID:   types.TopicID(entry.GetTopicId()),
+			JobID:     types.JobID(entry.GetJobId()),
+			TaskID:    types.TaskID(entry.GetTaskId()),
+			TaskUnits: FromTaskUnits(entry.GetTaskUnits()),
+			Priority:  types.Priority(entry.GetPriority()),
+		})
+	}
+	return values
+}
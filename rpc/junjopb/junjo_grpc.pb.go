@@ -0,0 +1,2567 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: junjo/v1/junjo.proto
+
+// The `Junjoold` facade over gRPC, the generated code is committed in `rpc/junjopb`
+// Regenerate it from `rpc` with `buf generate proto`
+
+package junjopb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Junjo_CreateTopic_FullMethodName               = "/junjo.v1.Junjo/CreateTopic"
+	Junjo_GetTopics_FullMethodName                 = "/junjo.v1.Junjo/GetTopics"
+	Junjo_GetTopic_FullMethodName                  = "/junjo.v1.Junjo/GetTopic"
+	Junjo_UpdateTopic_FullMethodName               = "/junjo.v1.Junjo/UpdateTopic"
+	Junjo_DeprecateTopic_FullMethodName            = "/junjo.v1.Junjo/DeprecateTopic"
+	Junjo_CreateOwner_FullMethodName               = "/junjo.v1.Junjo/CreateOwner"
+	Junjo_GetOwners_FullMethodName                 = "/junjo.v1.Junjo/GetOwners"
+	Junjo_GetOwner_FullMethodName                  = "/junjo.v1.Junjo/GetOwner"
+	Junjo_UpdateOwner_FullMethodName               = "/junjo.v1.Junjo/UpdateOwner"
+	Junjo_DeprecateOwner_FullMethodName            = "/junjo.v1.Junjo/DeprecateOwner"
+	Junjo_SetOwnerMembers_FullMethodName           = "/junjo.v1.Junjo/SetOwnerMembers"
+	Junjo_MigrateOwner_FullMethodName              = "/junjo.v1.Junjo/MigrateOwner"
+	Junjo_CreateTaskDefinition_FullMethodName      = "/junjo.v1.Junjo/CreateTaskDefinition"
+	Junjo_GetTaskDefinitions_FullMethodName        = "/junjo.v1.Junjo/GetTaskDefinitions"
+	Junjo_GetTaskDefinition_FullMethodName         = "/junjo.v1.Junjo/GetTaskDefinition"
+	Junjo_UpdateTaskDefinition_FullMethodName      = "/junjo.v1.Junjo/UpdateTaskDefinition"
+	Junjo_DeprecateTaskDefinition_FullMethodName   = "/junjo.v1.Junjo/DeprecateTaskDefinition"
+	Junjo_GetTaskDefinitionVersions_FullMethodName = "/junjo.v1.Junjo/GetTaskDefinitionVersions"
+	Junjo_GetTaskDefinitionVersion_FullMethodName  = "/junjo.v1.Junjo/GetTaskDefinitionVersion"
+	Junjo_MigrateTaskDefinition_FullMethodName     = "/junjo.v1.Junjo/MigrateTaskDefinition"
+	Junjo_LaunchJob_FullMethodName                 = "/junjo.v1.Junjo/LaunchJob"
+	Junjo_GetJobs_FullMethodName                   = "/junjo.v1.Junjo/GetJobs"
+	Junjo_GetJob_FullMethodName                    = "/junjo.v1.Junjo/GetJob"
+	Junjo_CancelJob_FullMethodName                 = "/junjo.v1.Junjo/CancelJob"
+	Junjo_AddJobDependencies_FullMethodName        = "/junjo.v1.Junjo/AddJobDependencies"
+	Junjo_GetDependentJobs_FullMethodName          = "/junjo.v1.Junjo/GetDependentJobs"
+	Junjo_RenderJobDOT_FullMethodName              = "/junjo.v1.Junjo/RenderJobDOT"
+	Junjo_GetTasks_FullMethodName                  = "/junjo.v1.Junjo/GetTasks"
+	Junjo_GetTask_FullMethodName                   = "/junjo.v1.Junjo/GetTask"
+	Junjo_CancelTask_FullMethodName                = "/junjo.v1.Junjo/CancelTask"
+	Junjo_RenderTaskDOT_FullMethodName             = "/junjo.v1.Junjo/RenderTaskDOT"
+	Junjo_GetTaskUnits_FullMethodName              = "/junjo.v1.Junjo/GetTaskUnits"
+	Junjo_GetTaskUnit_FullMethodName               = "/junjo.v1.Junjo/GetTaskUnit"
+	Junjo_GetTaskUnitState_FullMethodName          = "/junjo.v1.Junjo/GetTaskUnitState"
+	Junjo_SubmitCommand_FullMethodName             = "/junjo.v1.Junjo/SubmitCommand"
+	Junjo_ClaimTaskUnit_FullMethodName             = "/junjo.v1.Junjo/ClaimTaskUnit"
+	Junjo_ReleaseTaskUnit_FullMethodName           = "/junjo.v1.Junjo/ReleaseTaskUnit"
+	Junjo_ReassignTaskUnit_FullMethodName          = "/junjo.v1.Junjo/ReassignTaskUnit"
+	Junjo_Approve_FullMethodName                   = "/junjo.v1.Junjo/Approve"
+	Junjo_Reject_FullMethodName                    = "/junjo.v1.Junjo/Reject"
+	Junjo_ReportCommands_FullMethodName            = "/junjo.v1.Junjo/ReportCommands"
+	Junjo_GetInbox_FullMethodName                  = "/junjo.v1.Junjo/GetInbox"
+	Junjo_WatchInbox_FullMethodName                = "/junjo.v1.Junjo/WatchInbox"
+	Junjo_SetConcurrencyLimit_FullMethodName       = "/junjo.v1.Junjo/SetConcurrencyLimit"
+	Junjo_GetConcurrencyLimits_FullMethodName      = "/junjo.v1.Junjo/GetConcurrencyLimits"
+	Junjo_SaveTemplate_FullMethodName              = "/junjo.v1.Junjo/SaveTemplate"
+	Junjo_GetTemplates_FullMethodName              = "/junjo.v1.Junjo/GetTemplates"
+	Junjo_GetTemplateVersions_FullMethodName       = "/junjo.v1.Junjo/GetTemplateVersions"
+	Junjo_GetTemplate_FullMethodName               = "/junjo.v1.Junjo/GetTemplate"
+	Junjo_LaunchTemplate_FullMethodName            = "/junjo.v1.Junjo/LaunchTemplate"
+	Junjo_LaunchBatch_FullMethodName               = "/junjo.v1.Junjo/LaunchBatch"
+	Junjo_GetBatch_FullMethodName                  = "/junjo.v1.Junjo/GetBatch"
+	Junjo_CancelBatch_FullMethodName               = "/junjo.v1.Junjo/CancelBatch"
+	Junjo_CreateSchedule_FullMethodName            = "/junjo.v1.Junjo/CreateSchedule"
+	Junjo_GetSchedules_FullMethodName              = "/junjo.v1.Junjo/GetSchedules"
+	Junjo_GetSchedule_FullMethodName               = "/junjo.v1.Junjo/GetSchedule"
+	Junjo_PauseSchedule_FullMethodName             = "/junjo.v1.Junjo/PauseSchedule"
+	Junjo_ResumeSchedule_FullMethodName            = "/junjo.v1.Junjo/ResumeSchedule"
+	Junjo_DeleteSchedule_FullMethodName            = "/junjo.v1.Junjo/DeleteSchedule"
+	Junjo_CreateWebhook_FullMethodName             = "/junjo.v1.Junjo/CreateWebhook"
+	Junjo_GetWebhooks_FullMethodName               = "/junjo.v1.Junjo/GetWebhooks"
+	Junjo_GetWebhook_FullMethodName                = "/junjo.v1.Junjo/GetWebhook"
+	Junjo_DeleteWebhook_FullMethodName             = "/junjo.v1.Junjo/DeleteWebhook"
+	Junjo_GetDeliveries_FullMethodName             = "/junjo.v1.Junjo/GetDeliveries"
+	Junjo_GetAuditRecords_FullMethodName           = "/junjo.v1.Junjo/GetAuditRecords"
+)
+
+// JunjoClient is the client API for Junjo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type JunjoClient interface {
+	CreateTopic(ctx context.Context, in *CreateTopicRequest, opts ...grpc.CallOption) (*Topic, error)
+	GetTopics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Topics, error)
+	GetTopic(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Topic, error)
+	UpdateTopic(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Topic, error)
+	DeprecateTopic(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateOwner(ctx context.Context, in *CreateOwnerRequest, opts ...grpc.CallOption) (*Owner, error)
+	GetOwners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Owners, error)
+	GetOwner(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Owner, error)
+	UpdateOwner(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Owner, error)
+	DeprecateOwner(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Owner, error)
+	// Replace the members of a pool, none turns it back into a simple owner
+	SetOwnerMembers(ctx context.Context, in *SetOwnerMembersRequest, opts ...grpc.CallOption) (*Owner, error)
+	MigrateOwner(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*TaskUnitIDs, error)
+	CreateTaskDefinition(ctx context.Context, in *CreateTaskDefinitionRequest, opts ...grpc.CallOption) (*TaskDefinition, error)
+	GetTaskDefinitions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TaskDefinitions, error)
+	GetTaskDefinition(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*TaskDefinition, error)
+	UpdateTaskDefinition(ctx context.Context, in *UpdateTaskDefinitionRequest, opts ...grpc.CallOption) (*TaskDefinition, error)
+	DeprecateTaskDefinition(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetTaskDefinitionVersions(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*TaskDefinitions, error)
+	GetTaskDefinitionVersion(ctx context.Context, in *TaskDefinitionVersionRequest, opts ...grpc.CallOption) (*TaskDefinition, error)
+	MigrateTaskDefinition(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*TaskUnitIDs, error)
+	// `dag` is the json of a `WorkUnitDag`, like the HTTP api
+	LaunchJob(ctx context.Context, in *LaunchJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// `id` is the topic
+	GetJobs(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Jobs, error)
+	GetJob(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Job, error)
+	CancelJob(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddJobDependencies(ctx context.Context, in *AddJobDependenciesRequest, opts ...grpc.CallOption) (*Job, error)
+	GetDependentJobs(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Jobs, error)
+	RenderJobDOT(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Dot, error)
+	// `id` is the job
+	GetTasks(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Tasks, error)
+	GetTask(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Task, error)
+	CancelTask(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RenderTaskDOT(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Dot, error)
+	// `id` is the task
+	GetTaskUnits(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*TaskUnits, error)
+	GetTaskUnit(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*TaskUnit, error)
+	GetTaskUnitState(ctx context.Context, in *TaskUnitStateRequest, opts ...grpc.CallOption) (*TaskUnitState, error)
+	SubmitCommand(ctx context.Context, in *SubmitCommandRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ClaimTaskUnit(ctx context.Context, in *ClaimTaskUnitRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReleaseTaskUnit(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Hand the unit to another owner than the one of its definition, a started unit goes back to none
+	ReassignTaskUnit(ctx context.Context, in *ReassignTaskUnitRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Review an approval gate, a rejection fails it with the comment as reason
+	Approve(ctx context.Context, in *ReviewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Reject(ctx context.Context, in *ReviewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Report many commands on one stream, a refused command doesn't stop the others
+	ReportCommands(ctx context.Context, opts ...grpc.CallOption) (Junjo_ReportCommandsClient, error)
+	GetInbox(ctx context.Context, in *InboxRequest, opts ...grpc.CallOption) (*Inbox, error)
+	// The inbox right away, then again each time it changes
+	WatchInbox(ctx context.Context, in *InboxRequest, opts ...grpc.CallOption) (Junjo_WatchInboxClient, error)
+	SetConcurrencyLimit(ctx context.Context, in *ConcurrencyLimit, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetConcurrencyLimits(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConcurrencyLimits, error)
+	SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*Template, error)
+	GetTemplates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Templates, error)
+	GetTemplateVersions(ctx context.Context, in *TemplateVersionsRequest, opts ...grpc.CallOption) (*Templates, error)
+	GetTemplate(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Template, error)
+	LaunchTemplate(ctx context.Context, in *LaunchTemplateRequest, opts ...grpc.CallOption) (*Job, error)
+	LaunchBatch(ctx context.Context, in *LaunchBatchRequest, opts ...grpc.CallOption) (*BatchProgress, error)
+	GetBatch(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*BatchProgress, error)
+	CancelBatch(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	// `id` is the topic, all the schedules when empty
+	GetSchedules(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Schedules, error)
+	GetSchedule(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Schedule, error)
+	PauseSchedule(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Schedule, error)
+	ResumeSchedule(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Schedule, error)
+	DeleteSchedule(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	// `id` is the topic, all the webhooks when empty
+	GetWebhooks(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Webhooks, error)
+	GetWebhook(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetDeliveries(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Deliveries, error)
+	// The permission checks of the principals, for the admins
+	GetAuditRecords(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuditRecords, error)
+}
+
+type junjoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJunjoClient(cc grpc.ClientConnInterface) JunjoClient {
+	return &junjoClient{cc}
+}
+
+func (c *junjoClient) CreateTopic(ctx context.Context, in *CreateTopicRequest, opts ...grpc.CallOption) (*Topic, error) {
+	out := new(Topic)
+	err := c.cc.Invoke(ctx, Junjo_CreateTopic_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTopics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Topics, error) {
+	out := new(Topics)
+	err := c.cc.Invoke(ctx, Junjo_GetTopics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTopic(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Topic, error) {
+	out := new(Topic)
+	err := c.cc.Invoke(ctx, Junjo_GetTopic_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) UpdateTopic(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Topic, error) {
+	out := new(Topic)
+	err := c.cc.Invoke(ctx, Junjo_UpdateTopic_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) DeprecateTopic(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_DeprecateTopic_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) CreateOwner(ctx context.Context, in *CreateOwnerRequest, opts ...grpc.CallOption) (*Owner, error) {
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Junjo_CreateOwner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetOwners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Owners, error) {
+	out := new(Owners)
+	err := c.cc.Invoke(ctx, Junjo_GetOwners_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetOwner(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Owner, error) {
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Junjo_GetOwner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) UpdateOwner(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Owner, error) {
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Junjo_UpdateOwner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) DeprecateOwner(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Owner, error) {
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Junjo_DeprecateOwner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) SetOwnerMembers(ctx context.Context, in *SetOwnerMembersRequest, opts ...grpc.CallOption) (*Owner, error) {
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Junjo_SetOwnerMembers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) MigrateOwner(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*TaskUnitIDs, error) {
+	out := new(TaskUnitIDs)
+	err := c.cc.Invoke(ctx, Junjo_MigrateOwner_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) CreateTaskDefinition(ctx context.Context, in *CreateTaskDefinitionRequest, opts ...grpc.CallOption) (*TaskDefinition, error) {
+	out := new(TaskDefinition)
+	err := c.cc.Invoke(ctx, Junjo_CreateTaskDefinition_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTaskDefinitions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TaskDefinitions, error) {
+	out := new(TaskDefinitions)
+	err := c.cc.Invoke(ctx, Junjo_GetTaskDefinitions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTaskDefinition(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*TaskDefinition, error) {
+	out := new(TaskDefinition)
+	err := c.cc.Invoke(ctx, Junjo_GetTaskDefinition_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) UpdateTaskDefinition(ctx context.Context, in *UpdateTaskDefinitionRequest, opts ...grpc.CallOption) (*TaskDefinition, error) {
+	out := new(TaskDefinition)
+	err := c.cc.Invoke(ctx, Junjo_UpdateTaskDefinition_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) DeprecateTaskDefinition(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_DeprecateTaskDefinition_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTaskDefinitionVersions(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*TaskDefinitions, error) {
+	out := new(TaskDefinitions)
+	err := c.cc.Invoke(ctx, Junjo_GetTaskDefinitionVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTaskDefinitionVersion(ctx context.Context, in *TaskDefinitionVersionRequest, opts ...grpc.CallOption) (*TaskDefinition, error) {
+	out := new(TaskDefinition)
+	err := c.cc.Invoke(ctx, Junjo_GetTaskDefinitionVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) MigrateTaskDefinition(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*TaskUnitIDs, error) {
+	out := new(TaskUnitIDs)
+	err := c.cc.Invoke(ctx, Junjo_MigrateTaskDefinition_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) LaunchJob(ctx context.Context, in *LaunchJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Junjo_LaunchJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetJobs(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Jobs, error) {
+	out := new(Jobs)
+	err := c.cc.Invoke(ctx, Junjo_GetJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetJob(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Junjo_GetJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) CancelJob(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_CancelJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) AddJobDependencies(ctx context.Context, in *AddJobDependenciesRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Junjo_AddJobDependencies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetDependentJobs(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Jobs, error) {
+	out := new(Jobs)
+	err := c.cc.Invoke(ctx, Junjo_GetDependentJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) RenderJobDOT(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Dot, error) {
+	out := new(Dot)
+	err := c.cc.Invoke(ctx, Junjo_RenderJobDOT_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTasks(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Tasks, error) {
+	out := new(Tasks)
+	err := c.cc.Invoke(ctx, Junjo_GetTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTask(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Task, error) {
+	out := new(Task)
+	err := c.cc.Invoke(ctx, Junjo_GetTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) CancelTask(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_CancelTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) RenderTaskDOT(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Dot, error) {
+	out := new(Dot)
+	err := c.cc.Invoke(ctx, Junjo_RenderTaskDOT_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTaskUnits(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*TaskUnits, error) {
+	out := new(TaskUnits)
+	err := c.cc.Invoke(ctx, Junjo_GetTaskUnits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTaskUnit(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*TaskUnit, error) {
+	out := new(TaskUnit)
+	err := c.cc.Invoke(ctx, Junjo_GetTaskUnit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTaskUnitState(ctx context.Context, in *TaskUnitStateRequest, opts ...grpc.CallOption) (*TaskUnitState, error) {
+	out := new(TaskUnitState)
+	err := c.cc.Invoke(ctx, Junjo_GetTaskUnitState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) SubmitCommand(ctx context.Context, in *SubmitCommandRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_SubmitCommand_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) ClaimTaskUnit(ctx context.Context, in *ClaimTaskUnitRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_ClaimTaskUnit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) ReleaseTaskUnit(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_ReleaseTaskUnit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) ReassignTaskUnit(ctx context.Context, in *ReassignTaskUnitRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_ReassignTaskUnit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) Approve(ctx context.Context, in *ReviewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_Approve_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) Reject(ctx context.Context, in *ReviewRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_Reject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) ReportCommands(ctx context.Context, opts ...grpc.CallOption) (Junjo_ReportCommandsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Junjo_ServiceDesc.Streams[0], Junjo_ReportCommands_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &junjoReportCommandsClient{stream}
+	return x, nil
+}
+
+type Junjo_ReportCommandsClient interface {
+	Send(*SubmitCommandRequest) error
+	CloseAndRecv() (*ReportSummary, error)
+	grpc.ClientStream
+}
+
+type junjoReportCommandsClient struct {
+	grpc.ClientStream
+}
+
+func (x *junjoReportCommandsClient) Send(m *SubmitCommandRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *junjoReportCommandsClient) CloseAndRecv() (*ReportSummary, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ReportSummary)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *junjoClient) GetInbox(ctx context.Context, in *InboxRequest, opts ...grpc.CallOption) (*Inbox, error) {
+	out := new(Inbox)
+	err := c.cc.Invoke(ctx, Junjo_GetInbox_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) WatchInbox(ctx context.Context, in *InboxRequest, opts ...grpc.CallOption) (Junjo_WatchInboxClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Junjo_ServiceDesc.Streams[1], Junjo_WatchInbox_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &junjoWatchInboxClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Junjo_WatchInboxClient interface {
+	Recv() (*Inbox, error)
+	grpc.ClientStream
+}
+
+type junjoWatchInboxClient struct {
+	grpc.ClientStream
+}
+
+func (x *junjoWatchInboxClient) Recv() (*Inbox, error) {
+	m := new(Inbox)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *junjoClient) SetConcurrencyLimit(ctx context.Context, in *ConcurrencyLimit, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_SetConcurrencyLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetConcurrencyLimits(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConcurrencyLimits, error) {
+	out := new(ConcurrencyLimits)
+	err := c.cc.Invoke(ctx, Junjo_GetConcurrencyLimits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) SaveTemplate(ctx context.Context, in *SaveTemplateRequest, opts ...grpc.CallOption) (*Template, error) {
+	out := new(Template)
+	err := c.cc.Invoke(ctx, Junjo_SaveTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTemplates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Templates, error) {
+	out := new(Templates)
+	err := c.cc.Invoke(ctx, Junjo_GetTemplates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTemplateVersions(ctx context.Context, in *TemplateVersionsRequest, opts ...grpc.CallOption) (*Templates, error) {
+	out := new(Templates)
+	err := c.cc.Invoke(ctx, Junjo_GetTemplateVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetTemplate(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Template, error) {
+	out := new(Template)
+	err := c.cc.Invoke(ctx, Junjo_GetTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) LaunchTemplate(ctx context.Context, in *LaunchTemplateRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Junjo_LaunchTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) LaunchBatch(ctx context.Context, in *LaunchBatchRequest, opts ...grpc.CallOption) (*BatchProgress, error) {
+	out := new(BatchProgress)
+	err := c.cc.Invoke(ctx, Junjo_LaunchBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetBatch(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*BatchProgress, error) {
+	out := new(BatchProgress)
+	err := c.cc.Invoke(ctx, Junjo_GetBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) CancelBatch(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_CancelBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, Junjo_CreateSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetSchedules(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Schedules, error) {
+	out := new(Schedules)
+	err := c.cc.Invoke(ctx, Junjo_GetSchedules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetSchedule(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, Junjo_GetSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) PauseSchedule(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, Junjo_PauseSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) ResumeSchedule(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, Junjo_ResumeSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) DeleteSchedule(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_DeleteSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, Junjo_CreateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetWebhooks(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Webhooks, error) {
+	out := new(Webhooks)
+	err := c.cc.Invoke(ctx, Junjo_GetWebhooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetWebhook(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, Junjo_GetWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) DeleteWebhook(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Junjo_DeleteWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetDeliveries(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Deliveries, error) {
+	out := new(Deliveries)
+	err := c.cc.Invoke(ctx, Junjo_GetDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *junjoClient) GetAuditRecords(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuditRecords, error) {
+	out := new(AuditRecords)
+	err := c.cc.Invoke(ctx, Junjo_GetAuditRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JunjoServer is the server API for Junjo service.
+// All implementations must embed UnimplementedJunjoServer
+// for forward compatibility
+type JunjoServer interface {
+	CreateTopic(context.Context, *CreateTopicRequest) (*Topic, error)
+	GetTopics(context.Context, *emptypb.Empty) (*Topics, error)
+	GetTopic(context.Context, *IDRequest) (*Topic, error)
+	UpdateTopic(context.Context, *RenameRequest) (*Topic, error)
+	DeprecateTopic(context.Context, *IDRequest) (*emptypb.Empty, error)
+	CreateOwner(context.Context, *CreateOwnerRequest) (*Owner, error)
+	GetOwners(context.Context, *emptypb.Empty) (*Owners, error)
+	GetOwner(context.Context, *IDRequest) (*Owner, error)
+	UpdateOwner(context.Context, *RenameRequest) (*Owner, error)
+	DeprecateOwner(context.Context, *IDRequest) (*Owner, error)
+	// Replace the members of a pool, none turns it back into a simple owner
+	SetOwnerMembers(context.Context, *SetOwnerMembersRequest) (*Owner, error)
+	MigrateOwner(context.Context, *MigrateRequest) (*TaskUnitIDs, error)
+	CreateTaskDefinition(context.Context, *CreateTaskDefinitionRequest) (*TaskDefinition, error)
+	GetTaskDefinitions(context.Context, *emptypb.Empty) (*TaskDefinitions, error)
+	GetTaskDefinition(context.Context, *IDRequest) (*TaskDefinition, error)
+	UpdateTaskDefinition(context.Context, *UpdateTaskDefinitionRequest) (*TaskDefinition, error)
+	DeprecateTaskDefinition(context.Context, *IDRequest) (*emptypb.Empty, error)
+	GetTaskDefinitionVersions(context.Context, *IDRequest) (*TaskDefinitions, error)
+	GetTaskDefinitionVersion(context.Context, *TaskDefinitionVersionRequest) (*TaskDefinition, error)
+	MigrateTaskDefinition(context.Context, *MigrateRequest) (*TaskUnitIDs, error)
+	// `dag` is the json of a `WorkUnitDag`, like the HTTP api
+	LaunchJob(context.Context, *LaunchJobRequest) (*Job, error)
+	// `id` is the topic
+	GetJobs(context.Context, *IDRequest) (*Jobs, error)
+	GetJob(context.Context, *IDRequest) (*Job, error)
+	CancelJob(context.Context, *IDRequest) (*emptypb.Empty, error)
+	AddJobDependencies(context.Context, *AddJobDependenciesRequest) (*Job, error)
+	GetDependentJobs(context.Context, *IDRequest) (*Jobs, error)
+	RenderJobDOT(context.Context, *IDRequest) (*Dot, error)
+	// `id` is the job
+	GetTasks(context.Context, *IDRequest) (*Tasks, error)
+	GetTask(context.Context, *IDRequest) (*Task, error)
+	CancelTask(context.Context, *IDRequest) (*emptypb.Empty, error)
+	RenderTaskDOT(context.Context, *IDRequest) (*Dot, error)
+	// `id` is the task
+	GetTaskUnits(context.Context, *IDRequest) (*TaskUnits, error)
+	GetTaskUnit(context.Context, *IDRequest) (*TaskUnit, error)
+	GetTaskUnitState(context.Context, *TaskUnitStateRequest) (*TaskUnitState, error)
+	SubmitCommand(context.Context, *SubmitCommandRequest) (*emptypb.Empty, error)
+	ClaimTaskUnit(context.Context, *ClaimTaskUnitRequest) (*emptypb.Empty, error)
+	ReleaseTaskUnit(context.Context, *IDRequest) (*emptypb.Empty, error)
+	// Hand the unit to another owner than the one of its definition, a started unit goes back to none
+	ReassignTaskUnit(context.Context, *ReassignTaskUnitRequest) (*emptypb.Empty, error)
+	// Review an approval gate, a rejection fails it with the comment as reason
+	Approve(context.Context, *ReviewRequest) (*emptypb.Empty, error)
+	Reject(context.Context, *ReviewRequest) (*emptypb.Empty, error)
+	// Report many commands on one stream, a refused command doesn't stop the others
+	ReportCommands(Junjo_ReportCommandsServer) error
+	GetInbox(context.Context, *InboxRequest) (*Inbox, error)
+	// The inbox right away, then again each time it changes
+	WatchInbox(*InboxRequest, Junjo_WatchInboxServer) error
+	SetConcurrencyLimit(context.Context, *ConcurrencyLimit) (*emptypb.Empty, error)
+	GetConcurrencyLimits(context.Context, *emptypb.Empty) (*ConcurrencyLimits, error)
+	SaveTemplate(context.Context, *SaveTemplateRequest) (*Template, error)
+	GetTemplates(context.Context, *emptypb.Empty) (*Templates, error)
+	GetTemplateVersions(context.Context, *TemplateVersionsRequest) (*Templates, error)
+	GetTemplate(context.Context, *IDRequest) (*Template, error)
+	LaunchTemplate(context.Context, *LaunchTemplateRequest) (*Job, error)
+	LaunchBatch(context.Context, *LaunchBatchRequest) (*BatchProgress, error)
+	GetBatch(context.Context, *IDRequest) (*BatchProgress, error)
+	CancelBatch(context.Context, *IDRequest) (*emptypb.Empty, error)
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*Schedule, error)
+	// `id` is the topic, all the schedules when empty
+	GetSchedules(context.Context, *IDRequest) (*Schedules, error)
+	GetSchedule(context.Context, *IDRequest) (*Schedule, error)
+	PauseSchedule(context.Context, *IDRequest) (*Schedule, error)
+	ResumeSchedule(context.Context, *IDRequest) (*Schedule, error)
+	DeleteSchedule(context.Context, *IDRequest) (*emptypb.Empty, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	// `id` is the topic, all the webhooks when empty
+	GetWebhooks(context.Context, *IDRequest) (*Webhooks, error)
+	GetWebhook(context.Context, *IDRequest) (*Webhook, error)
+	DeleteWebhook(context.Context, *IDRequest) (*emptypb.Empty, error)
+	GetDeliveries(context.Context, *IDRequest) (*Deliveries, error)
+	// The permission checks of the principals, for the admins
+	GetAuditRecords(context.Context, *emptypb.Empty) (*AuditRecords, error)
+	mustEmbedUnimplementedJunjoServer()
+}
+
+// UnimplementedJunjoServer must be embedded to have forward compatible implementations.
+type UnimplementedJunjoServer struct {
+}
+
+func (UnimplementedJunjoServer) CreateTopic(context.Context, *CreateTopicRequest) (*Topic, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTopic not implemented")
+}
+func (UnimplementedJunjoServer) GetTopics(context.Context, *emptypb.Empty) (*Topics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopics not implemented")
+}
+func (UnimplementedJunjoServer) GetTopic(context.Context, *IDRequest) (*Topic, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopic not implemented")
+}
+func (UnimplementedJunjoServer) UpdateTopic(context.Context, *RenameRequest) (*Topic, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTopic not implemented")
+}
+func (UnimplementedJunjoServer) DeprecateTopic(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeprecateTopic not implemented")
+}
+func (UnimplementedJunjoServer) CreateOwner(context.Context, *CreateOwnerRequest) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOwner not implemented")
+}
+func (UnimplementedJunjoServer) GetOwners(context.Context, *emptypb.Empty) (*Owners, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOwners not implemented")
+}
+func (UnimplementedJunjoServer) GetOwner(context.Context, *IDRequest) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOwner not implemented")
+}
+func (UnimplementedJunjoServer) UpdateOwner(context.Context, *RenameRequest) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOwner not implemented")
+}
+func (UnimplementedJunjoServer) DeprecateOwner(context.Context, *IDRequest) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeprecateOwner not implemented")
+}
+func (UnimplementedJunjoServer) SetOwnerMembers(context.Context, *SetOwnerMembersRequest) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOwnerMembers not implemented")
+}
+func (UnimplementedJunjoServer) MigrateOwner(context.Context, *MigrateRequest) (*TaskUnitIDs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateOwner not implemented")
+}
+func (UnimplementedJunjoServer) CreateTaskDefinition(context.Context, *CreateTaskDefinitionRequest) (*TaskDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTaskDefinition not implemented")
+}
+func (UnimplementedJunjoServer) GetTaskDefinitions(context.Context, *emptypb.Empty) (*TaskDefinitions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskDefinitions not implemented")
+}
+func (UnimplementedJunjoServer) GetTaskDefinition(context.Context, *IDRequest) (*TaskDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskDefinition not implemented")
+}
+func (UnimplementedJunjoServer) UpdateTaskDefinition(context.Context, *UpdateTaskDefinitionRequest) (*TaskDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskDefinition not implemented")
+}
+func (UnimplementedJunjoServer) DeprecateTaskDefinition(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeprecateTaskDefinition not implemented")
+}
+func (UnimplementedJunjoServer) GetTaskDefinitionVersions(context.Context, *IDRequest) (*TaskDefinitions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskDefinitionVersions not implemented")
+}
+func (UnimplementedJunjoServer) GetTaskDefinitionVersion(context.Context, *TaskDefinitionVersionRequest) (*TaskDefinition, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskDefinitionVersion not implemented")
+}
+func (UnimplementedJunjoServer) MigrateTaskDefinition(context.Context, *MigrateRequest) (*TaskUnitIDs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateTaskDefinition not implemented")
+}
+func (UnimplementedJunjoServer) LaunchJob(context.Context, *LaunchJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LaunchJob not implemented")
+}
+func (UnimplementedJunjoServer) GetJobs(context.Context, *IDRequest) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobs not implemented")
+}
+func (UnimplementedJunjoServer) GetJob(context.Context, *IDRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedJunjoServer) CancelJob(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedJunjoServer) AddJobDependencies(context.Context, *AddJobDependenciesRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddJobDependencies not implemented")
+}
+func (UnimplementedJunjoServer) GetDependentJobs(context.Context, *IDRequest) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependentJobs not implemented")
+}
+func (UnimplementedJunjoServer) RenderJobDOT(context.Context, *IDRequest) (*Dot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderJobDOT not implemented")
+}
+func (UnimplementedJunjoServer) GetTasks(context.Context, *IDRequest) (*Tasks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTasks not implemented")
+}
+func (UnimplementedJunjoServer) GetTask(context.Context, *IDRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+}
+func (UnimplementedJunjoServer) CancelTask(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTask not implemented")
+}
+func (UnimplementedJunjoServer) RenderTaskDOT(context.Context, *IDRequest) (*Dot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderTaskDOT not implemented")
+}
+func (UnimplementedJunjoServer) GetTaskUnits(context.Context, *IDRequest) (*TaskUnits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskUnits not implemented")
+}
+func (UnimplementedJunjoServer) GetTaskUnit(context.Context, *IDRequest) (*TaskUnit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskUnit not implemented")
+}
+func (UnimplementedJunjoServer) GetTaskUnitState(context.Context, *TaskUnitStateRequest) (*TaskUnitState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskUnitState not implemented")
+}
+func (UnimplementedJunjoServer) SubmitCommand(context.Context, *SubmitCommandRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitCommand not implemented")
+}
+func (UnimplementedJunjoServer) ClaimTaskUnit(context.Context, *ClaimTaskUnitRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimTaskUnit not implemented")
+}
+func (UnimplementedJunjoServer) ReleaseTaskUnit(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseTaskUnit not implemented")
+}
+func (UnimplementedJunjoServer) ReassignTaskUnit(context.Context, *ReassignTaskUnitRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReassignTaskUnit not implemented")
+}
+func (UnimplementedJunjoServer) Approve(context.Context, *ReviewRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Approve not implemented")
+}
+func (UnimplementedJunjoServer) Reject(context.Context, *ReviewRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reject not implemented")
+}
+func (UnimplementedJunjoServer) ReportCommands(Junjo_ReportCommandsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReportCommands not implemented")
+}
+func (UnimplementedJunjoServer) GetInbox(context.Context, *InboxRequest) (*Inbox, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInbox not implemented")
+}
+func (UnimplementedJunjoServer) WatchInbox(*InboxRequest, Junjo_WatchInboxServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInbox not implemented")
+}
+func (UnimplementedJunjoServer) SetConcurrencyLimit(context.Context, *ConcurrencyLimit) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConcurrencyLimit not implemented")
+}
+func (UnimplementedJunjoServer) GetConcurrencyLimits(context.Context, *emptypb.Empty) (*ConcurrencyLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConcurrencyLimits not implemented")
+}
+func (UnimplementedJunjoServer) SaveTemplate(context.Context, *SaveTemplateRequest) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveTemplate not implemented")
+}
+func (UnimplementedJunjoServer) GetTemplates(context.Context, *emptypb.Empty) (*Templates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplates not implemented")
+}
+func (UnimplementedJunjoServer) GetTemplateVersions(context.Context, *TemplateVersionsRequest) (*Templates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplateVersions not implemented")
+}
+func (UnimplementedJunjoServer) GetTemplate(context.Context, *IDRequest) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedJunjoServer) LaunchTemplate(context.Context, *LaunchTemplateRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LaunchTemplate not implemented")
+}
+func (UnimplementedJunjoServer) LaunchBatch(context.Context, *LaunchBatchRequest) (*BatchProgress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LaunchBatch not implemented")
+}
+func (UnimplementedJunjoServer) GetBatch(context.Context, *IDRequest) (*BatchProgress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatch not implemented")
+}
+func (UnimplementedJunjoServer) CancelBatch(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBatch not implemented")
+}
+func (UnimplementedJunjoServer) CreateSchedule(context.Context, *CreateScheduleRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (UnimplementedJunjoServer) GetSchedules(context.Context, *IDRequest) (*Schedules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedules not implemented")
+}
+func (UnimplementedJunjoServer) GetSchedule(context.Context, *IDRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedule not implemented")
+}
+func (UnimplementedJunjoServer) PauseSchedule(context.Context, *IDRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSchedule not implemented")
+}
+func (UnimplementedJunjoServer) ResumeSchedule(context.Context, *IDRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSchedule not implemented")
+}
+func (UnimplementedJunjoServer) DeleteSchedule(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSchedule not implemented")
+}
+func (UnimplementedJunjoServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedJunjoServer) GetWebhooks(context.Context, *IDRequest) (*Webhooks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhooks not implemented")
+}
+func (UnimplementedJunjoServer) GetWebhook(context.Context, *IDRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhook not implemented")
+}
+func (UnimplementedJunjoServer) DeleteWebhook(context.Context, *IDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedJunjoServer) GetDeliveries(context.Context, *IDRequest) (*Deliveries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeliveries not implemented")
+}
+func (UnimplementedJunjoServer) GetAuditRecords(context.Context, *emptypb.Empty) (*AuditRecords, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditRecords not implemented")
+}
+func (UnimplementedJunjoServer) mustEmbedUnimplementedJunjoServer() {}
+
+// UnsafeJunjoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JunjoServer will
+// result in compilation errors.
+type UnsafeJunjoServer interface {
+	mustEmbedUnimplementedJunjoServer()
+}
+
+func RegisterJunjoServer(s grpc.ServiceRegistrar, srv JunjoServer) {
+	s.RegisterService(&Junjo_ServiceDesc, srv)
+}
+
+func _Junjo_CreateTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).CreateTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_CreateTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).CreateTopic(ctx, req.(*CreateTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTopics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTopics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTopics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTopics(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTopic(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_UpdateTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).UpdateTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_UpdateTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).UpdateTopic(ctx, req.(*RenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_DeprecateTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).DeprecateTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_DeprecateTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).DeprecateTopic(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_CreateOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).CreateOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_CreateOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).CreateOwner(ctx, req.(*CreateOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetOwners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetOwners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetOwners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetOwners(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetOwner(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_UpdateOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).UpdateOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_UpdateOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).UpdateOwner(ctx, req.(*RenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_DeprecateOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).DeprecateOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_DeprecateOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).DeprecateOwner(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_SetOwnerMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOwnerMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).SetOwnerMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_SetOwnerMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).SetOwnerMembers(ctx, req.(*SetOwnerMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_MigrateOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).MigrateOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_MigrateOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).MigrateOwner(ctx, req.(*MigrateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_CreateTaskDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTaskDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).CreateTaskDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_CreateTaskDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).CreateTaskDefinition(ctx, req.(*CreateTaskDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTaskDefinitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTaskDefinitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTaskDefinitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTaskDefinitions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTaskDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTaskDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTaskDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTaskDefinition(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_UpdateTaskDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).UpdateTaskDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_UpdateTaskDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).UpdateTaskDefinition(ctx, req.(*UpdateTaskDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_DeprecateTaskDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).DeprecateTaskDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_DeprecateTaskDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).DeprecateTaskDefinition(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTaskDefinitionVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTaskDefinitionVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTaskDefinitionVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTaskDefinitionVersions(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTaskDefinitionVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskDefinitionVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTaskDefinitionVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTaskDefinitionVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTaskDefinitionVersion(ctx, req.(*TaskDefinitionVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_MigrateTaskDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).MigrateTaskDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_MigrateTaskDefinition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).MigrateTaskDefinition(ctx, req.(*MigrateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_LaunchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LaunchJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).LaunchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_LaunchJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).LaunchJob(ctx, req.(*LaunchJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetJobs(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetJob(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).CancelJob(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_AddJobDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddJobDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).AddJobDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_AddJobDependencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).AddJobDependencies(ctx, req.(*AddJobDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetDependentJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetDependentJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetDependentJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetDependentJobs(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_RenderJobDOT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).RenderJobDOT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_RenderJobDOT_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).RenderJobDOT(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTasks(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTask(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_CancelTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).CancelTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_CancelTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).CancelTask(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_RenderTaskDOT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).RenderTaskDOT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_RenderTaskDOT_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).RenderTaskDOT(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTaskUnits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTaskUnits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTaskUnits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTaskUnits(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTaskUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTaskUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTaskUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTaskUnit(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTaskUnitState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskUnitStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTaskUnitState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTaskUnitState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTaskUnitState(ctx, req.(*TaskUnitStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_SubmitCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).SubmitCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_SubmitCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).SubmitCommand(ctx, req.(*SubmitCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_ClaimTaskUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimTaskUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).ClaimTaskUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_ClaimTaskUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).ClaimTaskUnit(ctx, req.(*ClaimTaskUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_ReleaseTaskUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).ReleaseTaskUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_ReleaseTaskUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).ReleaseTaskUnit(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_ReassignTaskUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReassignTaskUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).ReassignTaskUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_ReassignTaskUnit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).ReassignTaskUnit(ctx, req.(*ReassignTaskUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_Approve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).Approve(ctx, req.(*ReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_Reject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).Reject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_Reject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).Reject(ctx, req.(*ReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_ReportCommands_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JunjoServer).ReportCommands(&junjoReportCommandsServer{stream})
+}
+
+type Junjo_ReportCommandsServer interface {
+	SendAndClose(*ReportSummary) error
+	Recv() (*SubmitCommandRequest, error)
+	grpc.ServerStream
+}
+
+type junjoReportCommandsServer struct {
+	grpc.ServerStream
+}
+
+func (x *junjoReportCommandsServer) SendAndClose(m *ReportSummary) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *junjoReportCommandsServer) Recv() (*SubmitCommandRequest, error) {
+	m := new(SubmitCommandRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Junjo_GetInbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetInbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetInbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetInbox(ctx, req.(*InboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_WatchInbox_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InboxRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JunjoServer).WatchInbox(m, &junjoWatchInboxServer{stream})
+}
+
+type Junjo_WatchInboxServer interface {
+	Send(*Inbox) error
+	grpc.ServerStream
+}
+
+type junjoWatchInboxServer struct {
+	grpc.ServerStream
+}
+
+func (x *junjoWatchInboxServer) Send(m *Inbox) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Junjo_SetConcurrencyLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConcurrencyLimit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).SetConcurrencyLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_SetConcurrencyLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).SetConcurrencyLimit(ctx, req.(*ConcurrencyLimit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetConcurrencyLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetConcurrencyLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetConcurrencyLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetConcurrencyLimits(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_SaveTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).SaveTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_SaveTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).SaveTemplate(ctx, req.(*SaveTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTemplates(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTemplateVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTemplateVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTemplateVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTemplateVersions(ctx, req.(*TemplateVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetTemplate(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_LaunchTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LaunchTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).LaunchTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_LaunchTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).LaunchTemplate(ctx, req.(*LaunchTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_LaunchBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LaunchBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).LaunchBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_LaunchBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).LaunchBatch(ctx, req.(*LaunchBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetBatch(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_CancelBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).CancelBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_CancelBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).CancelBatch(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_CreateSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).CreateSchedule(ctx, req.(*CreateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetSchedules(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetSchedule(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_PauseSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).PauseSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_PauseSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).PauseSchedule(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_ResumeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).ResumeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_ResumeSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).ResumeSchedule(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_DeleteSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).DeleteSchedule(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetWebhooks(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetWebhook(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).DeleteWebhook(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetDeliveries(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Junjo_GetAuditRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JunjoServer).GetAuditRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Junjo_GetAuditRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JunjoServer).GetAuditRecords(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Junjo_ServiceDesc is the grpc.ServiceDesc for Junjo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Junjo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "junjo.v1.Junjo",
+	HandlerType: (*JunjoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTopic",
+			Handler:    _Junjo_CreateTopic_Handler,
+		},
+		{
+			MethodName: "GetTopics",
+			Handler:    _Junjo_GetTopics_Handler,
+		},
+		{
+			MethodName: "GetTopic",
+			Handler:    _Junjo_GetTopic_Handler,
+		},
+		{
+			MethodName: "UpdateTopic",
+			Handler:    _Junjo_UpdateTopic_Handler,
+		},
+		{
+			MethodName: "DeprecateTopic",
+			Handler:    _Junjo_DeprecateTopic_Handler,
+		},
+		{
+			MethodName: "CreateOwner",
+			Handler:    _Junjo_CreateOwner_Handler,
+		},
+		{
+			MethodName: "GetOwners",
+			Handler:    _Junjo_GetOwners_Handler,
+		},
+		{
+			MethodName: "GetOwner",
+			Handler:    _Junjo_GetOwner_Handler,
+		},
+		{
+			MethodName: "UpdateOwner",
+			Handler:    _Junjo_UpdateOwner_Handler,
+		},
+		{
+			MethodName: "DeprecateOwner",
+			Handler:    _Junjo_DeprecateOwner_Handler,
+		},
+		{
+			MethodName: "SetOwnerMembers",
+			Handler:    _Junjo_SetOwnerMembers_Handler,
+		},
+		{
+			MethodName: "MigrateOwner",
+			Handler:    _Junjo_MigrateOwner_Handler,
+		},
+		{
+			MethodName: "CreateTaskDefinition",
+			Handler:    _Junjo_CreateTaskDefinition_Handler,
+		},
+		{
+			MethodName: "GetTaskDefinitions",
+			Handler:    _Junjo_GetTaskDefinitions_Handler,
+		},
+		{
+			MethodName: "GetTaskDefinition",
+			Handler:    _Junjo_GetTaskDefinition_Handler,
+		},
+		{
+			MethodName: "UpdateTaskDefinition",
+			Handler:    _Junjo_UpdateTaskDefinition_Handler,
+		},
+		{
+			MethodName: "DeprecateTaskDefinition",
+			Handler:    _Junjo_DeprecateTaskDefinition_Handler,
+		},
+		{
+			MethodName: "GetTaskDefinitionVersions",
+			Handler:    _Junjo_GetTaskDefinitionVersions_Handler,
+		},
+		{
+			MethodName: "GetTaskDefinitionVersion",
+			Handler:    _Junjo_GetTaskDefinitionVersion_Handler,
+		},
+		{
+			MethodName: "MigrateTaskDefinition",
+			Handler:    _Junjo_MigrateTaskDefinition_Handler,
+		},
+		{
+			MethodName: "LaunchJob",
+			Handler:    _Junjo_LaunchJob_Handler,
+		},
+		{
+			MethodName: "GetJobs",
+			Handler:    _Junjo_GetJobs_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _Junjo_GetJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _Junjo_CancelJob_Handler,
+		},
+		{
+			MethodName: "AddJobDependencies",
+			Handler:    _Junjo_AddJobDependencies_Handler,
+		},
+		{
+			MethodName: "GetDependentJobs",
+			Handler:    _Junjo_GetDependentJobs_Handler,
+		},
+		{
+			MethodName: "RenderJobDOT",
+			Handler:    _Junjo_RenderJobDOT_Handler,
+		},
+		{
+			MethodName: "GetTasks",
+			Handler:    _Junjo_GetTasks_Handler,
+		},
+		{
+			MethodName: "GetTask",
+			Handler:    _Junjo_GetTask_Handler,
+		},
+		{
+			MethodName: "CancelTask",
+			Handler:    _Junjo_CancelTask_Handler,
+		},
+		{
+			MethodName: "RenderTaskDOT",
+			Handler:    _Junjo_RenderTaskDOT_Handler,
+		},
+		{
+			MethodName: "GetTaskUnits",
+			Handler:    _Junjo_GetTaskUnits_Handler,
+		},
+		{
+			MethodName: "GetTaskUnit",
+			Handler:    _Junjo_GetTaskUnit_Handler,
+		},
+		{
+			MethodName: "GetTaskUnitState",
+			Handler:    _Junjo_GetTaskUnitState_Handler,
+		},
+		{
+			MethodName: "SubmitCommand",
+			Handler:    _Junjo_SubmitCommand_Handler,
+		},
+		{
+			MethodName: "ClaimTaskUnit",
+			Handler:    _Junjo_ClaimTaskUnit_Handler,
+		},
+		{
+			MethodName: "ReleaseTaskUnit",
+			Handler:    _Junjo_ReleaseTaskUnit_Handler,
+		},
+		{
+			MethodName: "ReassignTaskUnit",
+			Handler:    _Junjo_ReassignTaskUnit_Handler,
+		},
+		{
+			MethodName: "Approve",
+			Handler:    _Junjo_Approve_Handler,
+		},
+		{
+			MethodName: "Reject",
+			Handler:    _Junjo_Reject_Handler,
+		},
+		{
+			MethodName: "GetInbox",
+			Handler:    _Junjo_GetInbox_Handler,
+		},
+		{
+			MethodName: "SetConcurrencyLimit",
+			Handler:    _Junjo_SetConcurrencyLimit_Handler,
+		},
+		{
+			MethodName: "GetConcurrencyLimits",
+			Handler:    _Junjo_GetConcurrencyLimits_Handler,
+		},
+		{
+			MethodName: "SaveTemplate",
+			Handler:    _Junjo_SaveTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplates",
+			Handler:    _Junjo_GetTemplates_Handler,
+		},
+		{
+			MethodName: "GetTemplateVersions",
+			Handler:    _Junjo_GetTemplateVersions_Handler,
+		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _Junjo_GetTemplate_Handler,
+		},
+		{
+			MethodName: "LaunchTemplate",
+			Handler:    _Junjo_LaunchTemplate_Handler,
+		},
+		{
+			MethodName: "LaunchBatch",
+			Handler:    _Junjo_LaunchBatch_Handler,
+		},
+		{
+			MethodName: "GetBatch",
+			Handler:    _Junjo_GetBatch_Handler,
+		},
+		{
+			MethodName: "CancelBatch",
+			Handler:    _Junjo_CancelBatch_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _Junjo_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "GetSchedules",
+			Handler:    _Junjo_GetSchedules_Handler,
+		},
+		{
+			MethodName: "GetSchedule",
+			Handler:    _Junjo_GetSchedule_Handler,
+		},
+		{
+			MethodName: "PauseSchedule",
+			Handler:    _Junjo_PauseSchedule_Handler,
+		},
+		{
+			MethodName: "ResumeSchedule",
+			Handler:    _Junjo_ResumeSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _Junjo_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _Junjo_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhooks",
+			Handler:    _Junjo_GetWebhooks_Handler,
+		},
+		{
+			MethodName: "GetWebhook",
+			Handler:    _Junjo_GetWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _Junjo_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "GetDeliveries",
+			Handler:    _Junjo_GetDeliveries_Handler,
+		},
+		{
+			MethodName: "GetAuditRecords",
+			Handler:    _Junjo_GetAuditRecords_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReportCommands",
+			Handler:       _Junjo_ReportCommands_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchInbox",
+			Handler:       _Junjo_WatchInbox_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "junjo/v1/junjo.proto",
+}
@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/rpc/junjopb"
+	"github.com/davidroman0O/junjo/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestStreams$ ./rpc
+func TestStreams(t *testing.T) {
+	jj := junjo.NewJ(memory.NewMemoryStorage())
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := Register(jj)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer conn.Close()
+	client := junjopb.NewJunjoClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var topic *junjopb.Topic
+	if topic, err = client.CreateTopic(ctx, &junjopb.CreateTopicRequest{Name: "Provisioning"}); err != nil {
+		t.Error(err)
+		return
+	}
+	var btl *junjopb.Owner
+	if btl, err = client.CreateOwner(ctx, &junjopb.CreateOwnerRequest{Name: "BTL"}); err != nil {
+		t.Error(err)
+		return
+	}
+	var provisioning *junjopb.TaskDefinition
+	if provisioning, err = client.CreateTaskDefinition(ctx, &junjopb.CreateTaskDefinitionRequest{Name: "provisioning", OwnerId: btl.Id}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	definition := FromTaskDefinition(provisioning)
+	unitDag := jj.CreateDagTaskUnits()
+	first, second := unitDag.ConnectDef(unitDag.AddTaskDefinition(&definition), unitDag.AddTaskDefinition(&definition))
+	firstID := string(first.(*types.NodeTaskUnit).Unit.Key)
+	secondID := string(second.(*types.NodeTaskUnit).Unit.Key)
+
+	var data []byte
+	if data, err = json.Marshal(unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = client.LaunchJob(ctx, &junjopb.LaunchJobRequest{TopicId: topic.Id, Dag: data}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	watch, err := client.WatchInbox(ctx, &junjopb.InboxRequest{OwnerId: btl.Id})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var inbox *junjopb.Inbox
+	if inbox, err = watch.Recv(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox.Entries) != 1 || len(inbox.Entries[0].TaskUnits) != 1 || inbox.Entries[0].TaskUnits[0].Id != firstID {
+		t.Errorf("expected the first unit offered, got %v", inbox)
+		return
+	}
+
+	// the second unit is refused, its dependency is only done after it in the stream
+	report, err := client.ReportCommands(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	commands := []*junjopb.SubmitCommandRequest{
+		{UnitId: secondID, Command: &junjopb.Command{Type: string(types.SuccessCmd)}},
+		{UnitId: firstID, Command: &junjopb.Command{Type: string(types.SuccessCmd)}},
+	}
+	for i := 0; i < len(commands); i++ {
+		if err = report.Send(commands[i]); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	var summary *junjopb.ReportSummary
+	if summary, err = report.CloseAndRecv(); err != nil {
+		t.Error(err)
+		return
+	}
+	if summary.Accepted != 1 || len(summary.Errors) != 1 {
+		t.Errorf("expected 1 accepted and 1 refused command, got %v", summary)
+		return
+	}
+	if summary.Errors[0].Index != 0 || summary.Errors[0].Code != codes.FailedPrecondition.String() {
+		t.Errorf("expected the first command refused with %v, got %v", codes.FailedPrecondition, summary.Errors[0])
+		return
+	}
+
+	// the watch sends the change, now the second unit is offered
+	if inbox, err = watch.Recv(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox.Entries) != 1 || len(inbox.Entries[0].TaskUnits) != 1 || inbox.Entries[0].TaskUnits[0].Id != secondID {
+		t.Errorf("expected the second unit offered, got %v", inbox)
+		return
+	}
+
+	if _, err = client.GetJob(ctx, &junjopb.IDRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected %v, got %v", codes.NotFound, err)
+		return
+	}
+}
@@ -0,0 +1,1100 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/rpc/junjopb"
+	"github.com/davidroman0O/junjo/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+/// `Server` expose the `Junjoold` api over gRPC, see `proto/junjo/v1/junjo.proto`
+///
+/// The workers watch their inbox with `WatchInbox` (server stream) and report with `ReportCommands` (client stream)
+/// The generated code lives in `junjopb`, regenerate it with `buf generate proto` from this directory
+///
+/// The errors are mapped to codes like the HTTP statuses:
+/// - `InvalidArgument` for an invalid graph, dependency, template, schedule, webhook or limit
+/// - `FailedPrecondition` when the dependencies of a unit refuse a command
+/// - `ResourceExhausted` when a concurrency limit refuses a command
+/// - `NotFound` for a missing entity
+/// - `Unauthenticated` and `PermissionDenied` when the token doesn't reach the namespace or its roles refuse the change
+///
+/// A namespaced server reads the `x-junjo-namespace` and `authorization: Bearer <token>` metadata of each call
+
+const MetadataNamespace = "x-junjo-namespace"
+
+type Server struct {
+	junjopb.UnimplementedJunjoServer
+	jj         *junjo.Junjoold
+	namespaces *junjo.Namespaces
+}
+
+func NewServer(jj *junjo.Junjoold) *Server {
+	return &Server{
+		jj: jj,
+	}
+}
+
+// NewNamespacedServer serves each call from the engine of its namespace
+func NewNamespacedServer(namespaces *junjo.Namespaces) *Server {
+	return &Server{
+		namespaces: namespaces,
+	}
+}
+
+// Register creates a `grpc.Server` serving `Server`
+func Register(jj *junjo.Junjoold, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	junjopb.RegisterJunjoServer(server, NewServer(jj))
+	return server
+}
+
+// RegisterNamespaces creates a `grpc.Server` serving a namespaced `Server`
+func RegisterNamespaces(namespaces *junjo.Namespaces, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	junjopb.RegisterJunjoServer(server, NewNamespacedServer(namespaces))
+	return server
+}
+
+// Engine of the call, from its metadata when the server is namespaced
+func (s *Server) engine(ctx context.Context) (*junjo.Junjoold, error) {
+	if s.namespaces == nil {
+		return s.jj, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	token := strings.TrimPrefix(first("authorization"), "Bearer ")
+	jj, err := s.namespaces.Access(token, types.Namespace(first(MetadataNamespace)))
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	return jj, nil
+}
+
+func (s *Server) CreateTopic(ctx context.Context, req *junjopb.CreateTopicRequest) (*junjopb.Topic, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	topic, err := jj.CreateTopic(req.GetName(), types.WithTopicDescription(req.GetDescription()))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toTopic(*topic), nil
+}
+
+func (s *Server) GetTopics(ctx context.Context, req *emptypb.Empty) (*junjopb.Topics, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	topics, err := jj.GetTopics()
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	values := &junjopb.Topics{}
+	for i := 0; i < len(topics); i++ {
+		values.Topics = append(values.Topics, toTopic(topics[i]))
+	}
+	return values, nil
+}
+
+func (s *Server) GetTopic(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Topic, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	topic, err := jj.GetTopic(types.TopicID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toTopic(*topic), nil
+}
+
+func (s *Server) UpdateTopic(ctx context.Context, req *junjopb.RenameRequest) (*junjopb.Topic, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	topic, err := jj.UpdateTopic(types.TopicID(req.GetId()), req.GetName())
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toTopic(*topic), nil
+}
+
+func (s *Server) DeprecateTopic(ctx context.Context, req *junjopb.IDRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.DeprecateTopic(types.TopicID(req.GetId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) CreateOwner(ctx context.Context, req *junjopb.CreateOwnerRequest) (*junjopb.Owner, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := jj.CreateOwner(
+		req.GetName(),
+		types.WithOwnerDescription(req.GetDescription()),
+		types.WithOwnerMembers(toOwnerIDs(req.GetMembers())...))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toOwner(*owner), nil
+}
+
+func (s *Server) GetOwners(ctx context.Context, req *emptypb.Empty) (*junjopb.Owners, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := jj.GetOwners()
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	values := &junjopb.Owners{}
+	for i := 0; i < len(owners); i++ {
+		values.Owners = append(values.Owners, toOwner(owners[i]))
+	}
+	return values, nil
+}
+
+func (s *Server) GetOwner(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Owner, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := jj.GetOwner(types.OwnerID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toOwner(*owner), nil
+}
+
+func (s *Server) UpdateOwner(ctx context.Context, req *junjopb.RenameRequest) (*junjopb.Owner, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := jj.UpdateOwner(types.OwnerID(req.GetId()), req.GetName())
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toOwner(*owner), nil
+}
+
+func (s *Server) DeprecateOwner(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Owner, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := jj.DeprecateOwner(types.OwnerID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toOwner(*owner), nil
+}
+
+func (s *Server) MigrateOwner(ctx context.Context, req *junjopb.MigrateRequest) (*junjopb.TaskUnitIDs, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := jj.MigrateOwner(types.OwnerID(req.GetId()), types.OwnerID(req.GetTo()), req.GetReason())
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toTaskUnitIDs(migrated), nil
+}
+
+func (s *Server) SetOwnerMembers(ctx context.Context, req *junjopb.SetOwnerMembersRequest) (*junjopb.Owner, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := jj.SetOwnerMembers(types.OwnerID(req.GetId()), toOwnerIDs(req.GetMembers())...)
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toOwner(*owner), nil
+}
+
+func (s *Server) CreateTaskDefinition(ctx context.Context, req *junjopb.CreateTaskDefinitionRequest) (*junjopb.TaskDefinition, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfgs := []types.TaskDefinitionConfig{
+		types.WithTaskDefDescription(req.GetDescription()),
+		types.WithTaskDefIdentifier(req.GetIdentifier()),
+	}
+	if approval := FromApproval(req.GetApproval()); approval != nil {
+		cfgs = append(cfgs,
+			types.WithTaskDefApproval(approval.Required, approval.Approvers...),
+			types.WithTaskDefApprovalExpiry(approval.ExpiresAfter))
+	}
+	definition, err := jj.CreateTaskDefinition(req.GetName(), types.OwnerID(req.GetOwnerId()), cfgs...)
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toTaskDefinition(*definition), nil
+}
+
+func (s *Server) GetTaskDefinitions(ctx context.Context, req *emptypb.Empty) (*junjopb.TaskDefinitions, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	definitions, err := jj.GetTaskDefinitions()
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	values := &junjopb.TaskDefinitions{}
+	for i := 0; i < len(definitions); i++ {
+		values.TaskDefinitions = append(values.TaskDefinitions, toTaskDefinition(definitions[i]))
+	}
+	return values, nil
+}
+
+func (s *Server) GetTaskDefinition(ctx context.Context, req *junjopb.IDRequest) (*junjopb.TaskDefinition, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	definition, err := jj.GetTaskDefinition(types.TaskDefinitionID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toTaskDefinition(*definition), nil
+}
+
+func (s *Server) GetTaskDefinitionVersions(ctx context.Context, req *junjopb.IDRequest) (*junjopb.TaskDefinitions, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := jj.GetTaskDefinitionVersions(types.TaskDefinitionID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	values := &junjopb.TaskDefinitions{}
+	for i := 0; i < len(versions); i++ {
+		values.TaskDefinitions = append(values.TaskDefinitions, toTaskDefinition(versions[i]))
+	}
+	return values, nil
+}
+
+func (s *Server) GetTaskDefinitionVersion(ctx context.Context, req *junjopb.TaskDefinitionVersionRequest) (*junjopb.TaskDefinition, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	definition, err := jj.GetTaskDefinitionVersion(types.TaskDefinitionID(req.GetId()), int(req.GetVersion()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toTaskDefinition(*definition), nil
+}
+
+func (s *Server) MigrateTaskDefinition(ctx context.Context, req *junjopb.MigrateRequest) (*junjopb.TaskUnitIDs, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := jj.MigrateTaskDefinition(types.TaskDefinitionID(req.GetId()), types.TaskDefinitionID(req.GetTo()))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toTaskUnitIDs(migrated), nil
+}
+
+func (s *Server) UpdateTaskDefinition(ctx context.Context, req *junjopb.UpdateTaskDefinitionRequest) (*junjopb.TaskDefinition, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	definition, err := jj.UpdateTaskDefinition(
+		types.TaskDefinitionID(req.GetId()),
+		types.OwnerID(req.GetOwnerId()),
+		req.GetName(),
+		req.GetDescription(),
+		req.GetIdentifier())
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toTaskDefinition(*definition), nil
+}
+
+func (s *Server) DeprecateTaskDefinition(ctx context.Context, req *junjopb.IDRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.DeprecateTaskDefinition(types.TaskDefinitionID(req.GetId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) LaunchJob(ctx context.Context, req *junjopb.LaunchJobRequest) (*junjopb.Job, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workUnitDag, err := decodeDag(req.GetDag())
+	if err != nil {
+		return nil, err
+	}
+	job, err := jj.LaunchJob(types.TopicID(req.GetTopicId()), workUnitDag,
+		types.WithJobData(req.GetData()),
+		types.WithJobPriority(types.Priority(req.GetPriority())),
+		types.WithJobDependsOn(toJobIDs(req.GetDependsOn())...),
+		types.WithJobUpstreamPolicy(types.UpstreamPolicy(req.GetUpstreamPolicy())))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toJob(*job), nil
+}
+
+func (s *Server) GetJobs(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Jobs, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := jj.GetJobs(types.TopicID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toJobs(jobs), nil
+}
+
+func (s *Server) GetJob(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Job, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := jj.GetJob(types.JobID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toJob(*job), nil
+}
+
+func (s *Server) CancelJob(ctx context.Context, req *junjopb.IDRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.CancelJob(types.JobID(req.GetId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) AddJobDependencies(ctx context.Context, req *junjopb.AddJobDependenciesRequest) (*junjopb.Job, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobID := types.JobID(req.GetJobId())
+	if err := jj.AddJobDependencies(jobID, toJobIDs(req.GetDependsOn())...); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	job, err := jj.GetJob(jobID)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	return toJob(*job), nil
+}
+
+func (s *Server) GetDependentJobs(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Jobs, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := jj.GetDependentJobs(types.JobID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toJobs(jobs), nil
+}
+
+func (s *Server) RenderJobDOT(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Dot, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dot, err := jj.RenderJobDOT(types.JobID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return &junjopb.Dot{Dot: dot}, nil
+}
+
+func (s *Server) GetTasks(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Tasks, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := jj.GetTasks(types.JobID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	values := &junjopb.Tasks{}
+	for i := 0; i < len(tasks); i++ {
+		values.Tasks = append(values.Tasks, toTask(tasks[i]))
+	}
+	return values, nil
+}
+
+func (s *Server) GetTask(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Task, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	task, err := jj.GetTask(types.TaskID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toTask(*task), nil
+}
+
+func (s *Server) CancelTask(ctx context.Context, req *junjopb.IDRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.CancelTask(types.TaskID(req.GetId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) RenderTaskDOT(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Dot, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dot, err := jj.RenderTaskDOT(types.TaskID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return &junjopb.Dot{Dot: dot}, nil
+}
+
+func (s *Server) GetTaskUnits(ctx context.Context, req *junjopb.IDRequest) (*junjopb.TaskUnits, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	units, err := jj.GetTaskUnits(types.TaskID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return &junjopb.TaskUnits{TaskUnits: toTaskUnits(units)}, nil
+}
+
+func (s *Server) GetTaskUnit(ctx context.Context, req *junjopb.IDRequest) (*junjopb.TaskUnit, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := jj.GetTaskUnit(types.TaskUnitID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toTaskUnit(*unit), nil
+}
+
+func (s *Server) GetTaskUnitState(ctx context.Context, req *junjopb.TaskUnitStateRequest) (*junjopb.TaskUnitState, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var state *types.TaskUnitState
+	if req.At != nil {
+		state, err = jj.GetTaskUnitStateAt(types.TaskUnitID(req.GetId()), int(req.GetAt()))
+	} else {
+		state, err = jj.GetTaskUnitState(types.TaskUnitID(req.GetId()))
+	}
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toTaskUnitState(*state), nil
+}
+
+func (s *Server) SubmitCommand(ctx context.Context, req *junjopb.SubmitCommandRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.submit(jj, req); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ClaimTaskUnit(ctx context.Context, req *junjopb.ClaimTaskUnitRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.ClaimTaskUnit(types.TaskUnitID(req.GetUnitId()), types.OwnerID(req.GetOwnerId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ReleaseTaskUnit(ctx context.Context, req *junjopb.IDRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.ReleaseTaskUnit(types.TaskUnitID(req.GetId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) Approve(ctx context.Context, req *junjopb.ReviewRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.Approve(types.TaskUnitID(req.GetUnitId()), types.OwnerID(req.GetOwnerId()), req.GetComment()); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) Reject(ctx context.Context, req *junjopb.ReviewRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.Reject(types.TaskUnitID(req.GetUnitId()), types.OwnerID(req.GetOwnerId()), req.GetComment()); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ReassignTaskUnit(ctx context.Context, req *junjopb.ReassignTaskUnitRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.ReassignTaskUnit(types.TaskUnitID(req.GetUnitId()), types.OwnerID(req.GetOwnerId()), req.GetReason()); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) submit(jj *junjo.Junjoold, req *junjopb.SubmitCommandRequest) error {
+	if req.GetCommand() == nil {
+		return status.Error(codes.InvalidArgument, "a command is required")
+	}
+	if err := jj.SubmitCommand(types.TaskUnitID(req.GetUnitId()), FromCommand(req.GetCommand())); err != nil {
+		return toStatus(err, codes.InvalidArgument)
+	}
+	return nil
+}
+
+// The refused commands are reported in the summary, the stream only fails when it breaks
+func (s *Server) ReportCommands(stream junjopb.Junjo_ReportCommandsServer) error {
+	jj, err := s.engine(stream.Context())
+	if err != nil {
+		return err
+	}
+	summary := &junjopb.ReportSummary{}
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(summary)
+		}
+		if err != nil {
+			return err
+		}
+		if err = s.submit(jj, req); err != nil {
+			refused := status.Convert(err)
+			summary.Errors = append(summary.Errors, &junjopb.ReportError{
+				Index:  index,
+				UnitId: req.GetUnitId(),
+				Code:   refused.Code().String(),
+				Error:  refused.Message(),
+			})
+			continue
+		}
+		summary.Accepted++
+	}
+}
+
+func (s *Server) GetInbox(ctx context.Context, req *junjopb.InboxRequest) (*junjopb.Inbox, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.inbox(jj, req)
+}
+
+// The inbox is sent again each time it changes, until the client leaves
+// It's checked on each change of the owner's tasks and at least every `interval` for the aging
+func (s *Server) WatchInbox(req *junjopb.InboxRequest, stream junjopb.Junjo_WatchInboxServer) error {
+	jj, err := s.engine(stream.Context())
+	if err != nil {
+		return err
+	}
+	interval := junjo.InboxRecheck
+	if req.GetInterval() != nil {
+		if interval = req.GetInterval().AsDuration(); interval <= 0 {
+			return status.Error(codes.InvalidArgument, "interval must be positive")
+		}
+	}
+
+	ownerID := types.OwnerID(req.GetOwnerId())
+	var last *junjopb.Inbox
+	for {
+		changed := jj.InboxChanged(ownerID)
+		inbox, err := s.inbox(jj, req)
+		if err != nil {
+			return err
+		}
+		if last == nil || !proto.Equal(last, inbox) {
+			if err = stream.Send(inbox); err != nil {
+				return err
+			}
+			last = inbox
+		}
+		recheck := time.NewTimer(interval)
+		select {
+		case <-stream.Context().Done():
+			recheck.Stop()
+			return nil
+		case <-changed:
+		case <-recheck.C:
+		}
+		recheck.Stop()
+	}
+}
+
+func (s *Server) inbox(jj *junjo.Junjoold, req *junjopb.InboxRequest) (*junjopb.Inbox, error) {
+	cfgs := []types.QueryConfig{}
+	if req.GetAging() != nil {
+		cfgs = append(cfgs, types.WithQueryAging(req.GetAging().AsDuration()))
+	}
+	if req.GetTopicId() != "" {
+		cfgs = append(cfgs, types.WithQueryTopic(types.TopicID(req.GetTopicId())))
+	}
+
+	inbox, err := jj.GetInbox(types.OwnerID(req.GetOwnerId()), cfgs...)
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	values := &junjopb.Inbox{}
+	for i := 0; i < len(inbox); i++ {
+		values.Entries = append(values.Entries, &junjopb.InboxEntry{
+			TopicId:   string(inbox[i].TopicID),
+			JobId:     string(inbox[i].JobID),
+			TaskId:    string(inbox[i].TaskID),
+			Priority:  int32(inbox[i].Priority),
+			TaskUnits: toTaskUnits(inbox[i].TaskUnits),
+		})
+	}
+	return values, nil
+}
+
+func (s *Server) SetConcurrencyLimit(ctx context.Context, req *junjopb.ConcurrencyLimit) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.SetConcurrencyLimit(FromConcurrencyLimit(req)); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetAuditRecords(ctx context.Context, req *emptypb.Empty) (*junjopb.AuditRecords, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := jj.GetAuditRecords()
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	values := &junjopb.AuditRecords{}
+	for i := 0; i < len(records); i++ {
+		values.Records = append(values.Records, toAuditRecord(records[i]))
+	}
+	return values, nil
+}
+
+func (s *Server) GetConcurrencyLimits(ctx context.Context, req *emptypb.Empty) (*junjopb.ConcurrencyLimits, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := jj.GetConcurrencyLimits()
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	values := &junjopb.ConcurrencyLimits{}
+	for i := 0; i < len(limits); i++ {
+		values.Limits = append(values.Limits, toConcurrencyLimit(limits[i]))
+	}
+	return values, nil
+}
+
+func (s *Server) SaveTemplate(ctx context.Context, req *junjopb.SaveTemplateRequest) (*junjopb.Template, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workUnitDag, err := decodeDag(req.GetDag())
+	if err != nil {
+		return nil, err
+	}
+	template, err := jj.SaveTemplate(req.GetName(), workUnitDag, types.WithTemplateDescription(req.GetDescription()))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toTemplate(*template), nil
+}
+
+func (s *Server) GetTemplates(ctx context.Context, req *emptypb.Empty) (*junjopb.Templates, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := jj.GetTemplates()
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	return toTemplates(templates), nil
+}
+
+func (s *Server) GetTemplateVersions(ctx context.Context, req *junjopb.TemplateVersionsRequest) (*junjopb.Templates, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := jj.GetTemplateVersions(req.GetName())
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toTemplates(templates), nil
+}
+
+func (s *Server) GetTemplate(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Template, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	template, err := jj.GetTemplate(types.TemplateID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toTemplate(*template), nil
+}
+
+func (s *Server) LaunchTemplate(ctx context.Context, req *junjopb.LaunchTemplateRequest) (*junjopb.Job, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := jj.LaunchTemplate(
+		types.TopicID(req.GetTopicId()),
+		types.TemplateID(req.GetTemplateId()),
+		types.WithJobData(req.GetData()),
+		types.WithJobPriority(types.Priority(req.GetPriority())))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toJob(*job), nil
+}
+
+func (s *Server) LaunchBatch(ctx context.Context, req *junjopb.LaunchBatchRequest) (*junjopb.BatchProgress, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workUnitDag, err := decodeDag(req.GetDag())
+	if err != nil {
+		return nil, err
+	}
+	params := make([]map[string]string, 0, len(req.GetParams()))
+	for _, param := range req.GetParams() {
+		params = append(params, param.GetData())
+	}
+	batchID, err := jj.LaunchBatch(types.TopicID(req.GetTopicId()), workUnitDag, params, types.WithJobPriority(types.Priority(req.GetPriority())))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	progress, err := jj.GetBatch(batchID)
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	return toBatchProgress(*progress), nil
+}
+
+func (s *Server) GetBatch(ctx context.Context, req *junjopb.IDRequest) (*junjopb.BatchProgress, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := jj.GetBatch(types.BatchID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toBatchProgress(*progress), nil
+}
+
+func (s *Server) CancelBatch(ctx context.Context, req *junjopb.IDRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.CancelBatch(types.BatchID(req.GetId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) CreateSchedule(ctx context.Context, req *junjopb.CreateScheduleRequest) (*junjopb.Schedule, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfgs := []types.ScheduleConfig{
+		types.WithScheduleTimezone(req.GetTimezone()),
+		types.WithScheduleTemplate(types.TemplateID(req.GetTemplateId())),
+		types.WithScheduleDag(req.GetDag()),
+		types.WithSchedulePriority(types.Priority(req.GetPriority())),
+		types.WithScheduleCatchUp(req.GetCatchUp()),
+	}
+	if req.GetData() != nil {
+		cfgs = append(cfgs, types.WithScheduleData(req.GetData()))
+	}
+	if req.GetOverlap() != "" {
+		cfgs = append(cfgs, types.WithScheduleOverlap(types.OverlapPolicy(req.GetOverlap())))
+	}
+	schedule, err := jj.CreateSchedule(types.TopicID(req.GetTopicId()), req.GetName(), req.GetCron(), cfgs...)
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toSchedule(*schedule), nil
+}
+
+func (s *Server) GetSchedules(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Schedules, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := jj.GetSchedules(types.TopicID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	return toSchedules(schedules), nil
+}
+
+func (s *Server) GetSchedule(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Schedule, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := jj.GetSchedule(types.ScheduleID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toSchedule(*schedule), nil
+}
+
+func (s *Server) PauseSchedule(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Schedule, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := jj.PauseSchedule(types.ScheduleID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toSchedule(*schedule), nil
+}
+
+func (s *Server) ResumeSchedule(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Schedule, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := jj.ResumeSchedule(types.ScheduleID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toSchedule(*schedule), nil
+}
+
+func (s *Server) DeleteSchedule(ctx context.Context, req *junjopb.IDRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.DeleteSchedule(types.ScheduleID(req.GetId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) CreateWebhook(ctx context.Context, req *junjopb.CreateWebhookRequest) (*junjopb.Webhook, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]types.EventType, 0, len(req.GetEvents()))
+	for _, event := range req.GetEvents() {
+		events = append(events, types.EventType(event))
+	}
+	webhook, err := jj.CreateWebhook(req.GetUrl(),
+		types.WithWebhookSecret(req.GetSecret()),
+		types.WithWebhookTopic(types.TopicID(req.GetTopicId())),
+		types.WithWebhookOwner(types.OwnerID(req.GetOwnerId())),
+		types.WithWebhookEvents(events...))
+	if err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return toWebhook(*webhook), nil
+}
+
+func (s *Server) GetWebhooks(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Webhooks, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := jj.GetWebhooks(types.TopicID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+	return toWebhooks(webhooks), nil
+}
+
+func (s *Server) GetWebhook(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Webhook, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := jj.GetWebhook(types.WebhookID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toWebhook(*webhook), nil
+}
+
+func (s *Server) DeleteWebhook(ctx context.Context, req *junjopb.IDRequest) (*emptypb.Empty, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := jj.DeleteWebhook(types.WebhookID(req.GetId())); err != nil {
+		return nil, toStatus(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetDeliveries(ctx context.Context, req *junjopb.IDRequest) (*junjopb.Deliveries, error) {
+	jj, err := s.engine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := jj.GetDeliveries(types.WebhookID(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, codes.NotFound)
+	}
+	return toDeliveries(deliveries), nil
+}
+
+// The dags are sent as their json, like the HTTP api
+func decodeDag(data []byte) (*types.WorkUnitDag, error) {
+	if len(data) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a dag is required")
+	}
+	workUnitDag := &types.WorkUnitDag{}
+	if err := json.Unmarshal(data, workUnitDag); err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("dag: %v", err))
+	}
+	return workUnitDag, nil
+}
+
+// Code of the known errors, `fallback` for the others
+func toStatus(err error, fallback codes.Code) error {
+	code := fallback
+	switch {
+	case errors.Is(err, types.ErrTaskUnitNotAvailable),
+		errors.Is(err, types.ErrTaskUnitClosed),
+		errors.Is(err, types.ErrTaskUnitClaimed),
+		errors.Is(err, types.ErrApprovalExpired),
+		errors.Is(err, types.ErrTaskDefinitionDeprecated),
+		errors.Is(err, types.ErrOwnerDeprecated),
+		errors.Is(err, types.ErrTopicDeprecated):
+		code = codes.FailedPrecondition
+	case errors.Is(err, types.ErrOwnerNotMember),
+		errors.Is(err, types.ErrNotApprover),
+		errors.Is(err, types.ErrNamespaceDenied),
+		errors.Is(err, types.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, types.ErrUnauthenticated):
+		code = codes.Unauthenticated
+	case errors.Is(err, types.ErrConcurrencyLimitReached):
+		code = codes.ResourceExhausted
+	case errors.Is(err, types.ErrJobGraphInvalid),
+		errors.Is(err, types.ErrJobDependencyInvalid),
+		errors.Is(err, types.ErrTemplateInvalid),
+		errors.Is(err, types.ErrScheduleInvalid),
+		errors.Is(err, types.ErrWebhookInvalid),
+		errors.Is(err, types.ErrOwnerPoolInvalid),
+		errors.Is(err, types.ErrReassignInvalid),
+		errors.Is(err, types.ErrMigrationInvalid),
+		errors.Is(err, types.ErrCommandIndexInvalid),
+		errors.Is(err, types.ErrApprovalInvalid),
+		errors.Is(err, types.ErrNamespaceInvalid),
+		errors.Is(err, types.ErrFanOutInvalid),
+		errors.Is(err, types.ErrJoinInvalid),
+		errors.Is(err, types.ErrConcurrencyLimitInvalid),
+		errors.Is(err, types.ErrWorkUnitDagVersion):
+		code = codes.InvalidArgument
+	case errors.Is(err, types.ErrBatchNotFound),
+		errors.Is(err, types.ErrTemplateNotFound),
+		errors.Is(err, types.ErrScheduleNotFound),
+		errors.Is(err, types.ErrWebhookNotFound),
+		strings.Contains(err.Error(), "not found"):
+		code = codes.NotFound
+	case errors.Is(err, types.ErrTopicIDAlreadyExists),
+		errors.Is(err, types.ErrTopicNameAlreadyExists),
+		errors.Is(err, types.ErrOwnerIDAlreadyExists),
+		errors.Is(err, types.ErrOwnerNameAlreadyExists):
+		code = codes.AlreadyExists
+	}
+	return status.Error(code, err.Error())
+}
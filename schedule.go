@@ -0,0 +1,174 @@
+package junjo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Schedules replace the external crontabs, run a `Scheduler` next to your engine to launch their jobs
+///
+///	jj.CreateSchedule(topic.Key, "nightly health check", "0 2 * * *", types.WithScheduleTemplate(healthCheck.Key))
+
+// Create a `Schedule` on a `Topic`, it starts ticking from now
+func (j *Junjoold) CreateSchedule(topicID types.TopicID, name string, cron string, cfgs ...types.ScheduleConfig) (*types.Schedule, error) {
+	if err := j.authorizeTopic(types.ActionScheduleManage, topicID); err != nil {
+		return nil, err
+	}
+	value := types.NewSchedule("", topicID, name, cron, cfgs...)
+	if err := j.validateSchedule(value); err != nil {
+		return nil, err
+	}
+	// it would only launch jobs to be refused
+	if topic, err := j.storageImplementation.GetTopic(topicID); err == nil && topic.Deprecated {
+		return nil, fmt.Errorf("%w: %v", types.ErrTopicDeprecated, topicID)
+	}
+	return j.storageImplementation.CreateSchedule(topicID, name, cron, cfgs...)
+}
+
+func (j *Junjoold) validateSchedule(schedule *types.Schedule) error {
+	if schedule.Name == "" {
+		return fmt.Errorf("%w: schedule needs a name", types.ErrScheduleInvalid)
+	}
+	if _, err := types.ParseCron(schedule.Cron); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrScheduleInvalid, err)
+	}
+	if _, err := schedule.Location(); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrScheduleInvalid, err)
+	}
+	switch schedule.Overlap {
+	case types.OverlapSkip, types.OverlapQueue, types.OverlapAllow:
+	default:
+		return fmt.Errorf("%w: unknown overlap policy %q", types.ErrScheduleInvalid, schedule.Overlap)
+	}
+
+	has, err := j.storageImplementation.HasTopic(schedule.TopicID)
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("%w: topic %v not found", types.ErrScheduleInvalid, schedule.TopicID)
+	}
+
+	if (schedule.TemplateID == "") == (len(schedule.Dag) == 0) {
+		return fmt.Errorf("%w: schedule needs either a template or a dag", types.ErrScheduleInvalid)
+	}
+	if schedule.TemplateID != "" {
+		if _, err = j.storageImplementation.GetTemplate(schedule.TemplateID); err != nil {
+			return fmt.Errorf("%w: %v", types.ErrScheduleInvalid, err)
+		}
+		return nil
+	}
+	workUnitDag := j.CreateDagTaskUnits()
+	if err = json.Unmarshal(schedule.Dag, workUnitDag); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrScheduleInvalid, err)
+	}
+	if err = j.ValidateTemplate(workUnitDag); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrScheduleInvalid, err)
+	}
+	return nil
+}
+
+func (j *Junjoold) GetSchedule(id types.ScheduleID) (*types.Schedule, error) {
+	return j.storageImplementation.GetSchedule(id)
+}
+
+// Schedules of a `Topic`, all of them with an empty `TopicID`
+func (j *Junjoold) GetSchedules(topicID types.TopicID) ([]types.Schedule, error) {
+	schedules, err := j.storageImplementation.GetSchedules()
+	if err != nil {
+		return nil, err
+	}
+	if topicID == "" {
+		return schedules, nil
+	}
+	filtered := []types.Schedule{}
+	for i := 0; i < len(schedules); i++ {
+		if schedules[i].TopicID == topicID {
+			filtered = append(filtered, schedules[i])
+		}
+	}
+	return filtered, nil
+}
+
+func (j *Junjoold) DeleteSchedule(id types.ScheduleID) error {
+	if err := j.authorizeSchedule(id); err != nil {
+		return err
+	}
+	return j.storageImplementation.DeleteSchedule(id)
+}
+
+// Stop launching jobs, the queued ticks are kept
+func (j *Junjoold) PauseSchedule(id types.ScheduleID) (*types.Schedule, error) {
+	if err := j.authorizeSchedule(id); err != nil {
+		return nil, err
+	}
+	schedule, err := j.storageImplementation.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Paused = true
+	if err = j.storageImplementation.UpdateSchedule(*schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// Start ticking again from now, the ticks of the pause are never launched
+func (j *Junjoold) ResumeSchedule(id types.ScheduleID) (*types.Schedule, error) {
+	if err := j.authorizeSchedule(id); err != nil {
+		return nil, err
+	}
+	schedule, err := j.storageImplementation.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Paused {
+		schedule.Paused = false
+		schedule.LastTick = time.Now()
+	}
+	if err = j.storageImplementation.UpdateSchedule(*schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// Create and assign the `Job` of one tick of the schedule
+func (j *Junjoold) launchSchedule(schedule *types.Schedule) (*types.Job, error) {
+	data := make(map[string]string, len(schedule.Data))
+	for k, v := range schedule.Data {
+		data[k] = v
+	}
+	cfgs := []types.JobConfig{
+		types.WithJobData(data),
+		types.WithJobPriority(schedule.Priority),
+		types.WithJobScheduleID(schedule.Key),
+	}
+
+	if schedule.TemplateID != "" {
+		return j.launchTemplate(schedule.TopicID, schedule.TemplateID, cfgs...)
+	}
+
+	workUnitDag := j.CreateDagTaskUnits()
+	if err := json.Unmarshal(schedule.Dag, workUnitDag); err != nil {
+		return nil, err
+	}
+	clone, err := workUnitDag.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return j.launchJob(schedule.TopicID, clone, cfgs...)
+}
+
+func (j *Junjoold) authorizeSchedule(id types.ScheduleID) error {
+	if j.principal == nil {
+		return nil
+	}
+	schedule, err := j.storageImplementation.GetSchedule(id)
+	if err != nil {
+		return err
+	}
+	return j.authorize(types.ActionScheduleManage, types.Scope{TopicID: schedule.TopicID}, string(id))
+}
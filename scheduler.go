@@ -0,0 +1,272 @@
+package junjo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// The `Scheduler` checks the schedules every interval and launches the jobs of the ticks that passed
+///
+///	scheduler := jj.NewScheduler()
+///	go scheduler.Run(ctx)
+///
+/// The last tick of each schedule is stored, after a downtime the missed ticks are launched when the schedule has `CatchUp`
+/// Each check also fails the approval gates past their expiry, see `ExpireApprovals`
+
+type ScheduleOutcome string
+
+const (
+	ScheduleLaunched ScheduleOutcome = "launched"
+	ScheduleSkipped  ScheduleOutcome = "skipped" // the previous job was still running
+	ScheduleQueued   ScheduleOutcome = "queued"  // waiting for the previous job
+	ScheduleMissed   ScheduleOutcome = "missed"  // passed during a downtime without catch-up
+	ScheduleFailed   ScheduleOutcome = "failed"
+)
+
+// What happened to one tick of a `Schedule`
+type ScheduleRun struct {
+	ScheduleID types.ScheduleID `json:"scheduleID"`
+	Tick       time.Time        `json:"tick"`
+	Outcome    ScheduleOutcome  `json:"outcome"`
+	JobID      types.JobID      `json:"jobID,omitempty"`
+	Missed     int              `json:"missed,omitempty"` // ticks missed up to `Tick`
+	Err        error            `json:"-"`
+}
+
+func (r ScheduleRun) String() string {
+	message := fmt.Sprintf("schedule %v tick %v: %v", r.ScheduleID, r.Tick.Format(time.RFC3339), r.Outcome)
+	if r.Missed > 0 {
+		message += fmt.Sprintf(" %v tick(s)", r.Missed)
+	}
+	if r.JobID != "" {
+		message += fmt.Sprintf(" job %v", r.JobID)
+	}
+	if r.Err != nil {
+		message += fmt.Sprintf(", error: %v", r.Err)
+	}
+	return message
+}
+
+type Scheduler struct {
+	jj         *Junjoold
+	interval   time.Duration
+	maxCatchUp int
+	report     func(ScheduleRun)
+	now        func() time.Time
+}
+
+type SchedulerConfig func(s *Scheduler)
+
+// Delay between two checks of the schedules, 15 seconds by default
+func WithSchedulerInterval(interval time.Duration) SchedulerConfig {
+	return func(s *Scheduler) {
+		s.interval = interval
+	}
+}
+
+// Most ticks launched at once for one schedule after a downtime, the older ones are missed, 100 by default
+func WithSchedulerMaxCatchUp(maximum int) SchedulerConfig {
+	return func(s *Scheduler) {
+		s.maxCatchUp = maximum
+	}
+}
+
+// Receive each tick handled by `Run`, they are logged by default
+func WithSchedulerReport(report func(ScheduleRun)) SchedulerConfig {
+	return func(s *Scheduler) {
+		s.report = report
+	}
+}
+
+// Replace the clock, useful for your tests
+func WithSchedulerClock(now func() time.Time) SchedulerConfig {
+	return func(s *Scheduler) {
+		s.now = now
+	}
+}
+
+// Create a `Scheduler` for the schedules of the storage, call `Run` to start it in the background
+func (j *Junjoold) NewScheduler(cfgs ...SchedulerConfig) *Scheduler {
+	scheduler := &Scheduler{
+		jj:         j,
+		interval:   15 * time.Second,
+		maxCatchUp: 100,
+		report: func(run ScheduleRun) {
+			log.Println(run)
+		},
+		now: time.Now,
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](scheduler)
+	}
+	return scheduler
+}
+
+// Tick once every interval until the context is done
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		runs, err := s.Tick()
+		if err != nil {
+			log.Printf("scheduler: %v", err)
+		}
+		for i := 0; i < len(runs); i++ {
+			s.report(runs[i])
+		}
+		expired, err := s.jj.ExpireApprovals(s.now())
+		if err != nil {
+			log.Printf("scheduler: approvals: %v", err)
+		}
+		for i := 0; i < len(expired); i++ {
+			log.Printf("scheduler: approval %v expired", expired[i])
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Handle the ticks of every schedule that passed since their last tick
+func (s *Scheduler) Tick() ([]ScheduleRun, error) {
+	schedules, err := s.jj.storageImplementation.GetSchedules()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	runs := []ScheduleRun{}
+	for i := 0; i < len(schedules); i++ {
+		if schedules[i].Paused {
+			continue
+		}
+		scheduleRuns, err := s.tick(&schedules[i], now)
+		runs = append(runs, scheduleRuns...)
+		if err != nil {
+			runs = append(runs, ScheduleRun{ScheduleID: schedules[i].Key, Tick: now, Outcome: ScheduleFailed, Err: err})
+		}
+	}
+	return runs, nil
+}
+
+func (s *Scheduler) tick(schedule *types.Schedule, now time.Time) ([]ScheduleRun, error) {
+	cron, err := types.ParseCron(schedule.Cron)
+	if err != nil {
+		return nil, err
+	}
+	location, err := schedule.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	keep := s.maxCatchUp
+	if !schedule.CatchUp || keep < 1 {
+		keep = 1
+	}
+
+	// only the last ticks are kept, a long downtime of a frequent schedule would be too many
+	runs := []ScheduleRun{}
+	missed := ScheduleRun{ScheduleID: schedule.Key, Outcome: ScheduleMissed}
+	ticks := []time.Time{}
+	for tick := cron.Next(schedule.LastTick.In(location)); !tick.IsZero() && !tick.After(now); tick = cron.Next(tick) {
+		if len(ticks) == keep {
+			missed.Tick = ticks[0]
+			missed.Missed++
+			ticks = ticks[1:]
+		}
+		ticks = append(ticks, tick)
+	}
+	if missed.Missed > 0 {
+		runs = append(runs, missed)
+		schedule.LastTick = missed.Tick
+	}
+
+	// a queued tick goes before the new ones
+	if schedule.Pending > 0 {
+		running, err := s.running(schedule.LastJobID)
+		if err != nil {
+			return runs, err
+		}
+		if !running {
+			run := s.launch(schedule, now)
+			runs = append(runs, run)
+			if run.Outcome == ScheduleLaunched {
+				schedule.Pending--
+			}
+		}
+	}
+
+	for i := 0; i < len(ticks); i++ {
+		run := ScheduleRun{ScheduleID: schedule.Key, Tick: ticks[i]}
+		running := false
+		if schedule.Overlap != types.OverlapAllow {
+			if running, err = s.running(schedule.LastJobID); err != nil {
+				return runs, err
+			}
+		}
+		switch {
+		case running && schedule.Overlap == types.OverlapSkip:
+			run.Outcome = ScheduleSkipped
+		case running || schedule.Pending > 0:
+			run.Outcome = ScheduleQueued
+			schedule.Pending++
+		default:
+			run = s.launch(schedule, ticks[i])
+		}
+		runs = append(runs, run)
+		schedule.LastTick = ticks[i]
+	}
+
+	if len(runs) == 0 {
+		return runs, nil
+	}
+
+	// keep a pause or a deletion made while we were launching
+	current, err := s.jj.storageImplementation.GetSchedule(schedule.Key)
+	if errors.Is(err, types.ErrScheduleNotFound) {
+		return runs, nil
+	}
+	if err != nil {
+		return runs, err
+	}
+	schedule.Paused = current.Paused
+	return runs, s.jj.storageImplementation.UpdateSchedule(*schedule)
+}
+
+func (s *Scheduler) launch(schedule *types.Schedule, tick time.Time) ScheduleRun {
+	run := ScheduleRun{ScheduleID: schedule.Key, Tick: tick}
+	job, err := s.jj.launchSchedule(schedule)
+	if err != nil {
+		run.Outcome = ScheduleFailed
+		run.Err = err
+		return run
+	}
+	run.Outcome = ScheduleLaunched
+	run.JobID = job.Key
+	schedule.LastJobID = job.Key
+	return run
+}
+
+// A job is running until it succeeds or fails, a deleted job doesn't run anymore
+func (s *Scheduler) running(jobID types.JobID) (bool, error) {
+	if jobID == "" {
+		return false, nil
+	}
+	has, err := s.jj.storageImplementation.HasJob(jobID)
+	if err != nil || !has {
+		return false, err
+	}
+	job, err := s.jj.storageImplementation.GetJob(jobID)
+	if err != nil {
+		return false, err
+	}
+	return job.Status != types.SuccessStatus && job.Status != types.ErrorStatus, nil
+}
@@ -0,0 +1,213 @@
+package junjo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestCron$ .
+func TestCron(t *testing.T) {
+	from := time.Date(2024, time.February, 10, 10, 7, 30, 0, time.UTC) // a saturday
+
+	cases := map[string]time.Time{
+		"*/15 * * * *": time.Date(2024, time.February, 10, 10, 15, 0, 0, time.UTC),
+		"0 2 * * *":    time.Date(2024, time.February, 11, 2, 0, 0, 0, time.UTC),
+		"@monthly":     time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		"30 9 * * mon": time.Date(2024, time.February, 12, 9, 30, 0, 0, time.UTC),
+		"0 0 29 feb *": time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * 7":    time.Date(2024, time.February, 11, 0, 0, 0, 0, time.UTC), // the 1st or a sunday
+	}
+	for expression, expected := range cases {
+		cron, err := types.ParseCron(expression)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if next := cron.Next(from); !next.Equal(expected) {
+			t.Errorf("%v: expected %v, got %v", expression, expected, next)
+			return
+		}
+	}
+
+	for _, expression := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *"} {
+		if _, err := types.ParseCron(expression); err == nil {
+			t.Errorf("%v: expected an error", expression)
+			return
+		}
+	}
+
+	cron, err := types.ParseCron("0 0 30 feb *")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if next := cron.Next(from); !next.IsZero() {
+		t.Errorf("expected no 30th of february, got %v", next)
+		return
+	}
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestScheduler$ .
+func TestScheduler(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Health checks"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var check *types.TaskDefinition
+	if check, err = jj.CreateTaskDefinition("check", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	unitDag.AddTaskDefinition(check)()
+	var dag []byte
+	if dag, err = json.Marshal(unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, err = jj.CreateSchedule(topic.Key, "broken", "0 * * *", types.WithScheduleDag(dag)); err == nil {
+		t.Error("expected an invalid cron expression")
+		return
+	}
+
+	var queued, catchUp, latest *types.Schedule
+	if queued, err = jj.CreateSchedule(topic.Key, "queued", "0 * * * *", types.WithScheduleDag(dag), types.WithScheduleOverlap(types.OverlapQueue)); err != nil {
+		t.Error(err)
+		return
+	}
+	if catchUp, err = jj.CreateSchedule(topic.Key, "catch up", "0 * * * *", types.WithScheduleDag(dag), types.WithScheduleOverlap(types.OverlapAllow), types.WithScheduleCatchUp(true)); err != nil {
+		t.Error(err)
+		return
+	}
+	if latest, err = jj.CreateSchedule(topic.Key, "latest", "0 * * * *", types.WithScheduleDag(dag), types.WithScheduleOverlap(types.OverlapAllow)); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = jj.PauseSchedule(catchUp.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = jj.PauseSchedule(latest.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	now := time.Now()
+	scheduler := jj.NewScheduler(WithSchedulerClock(func() time.Time { return now }))
+
+	outcomes := func(id types.ScheduleID) ([]ScheduleRun, error) {
+		runs, err := scheduler.Tick()
+		if err != nil {
+			return nil, err
+		}
+		filtered := []ScheduleRun{}
+		for i := 0; i < len(runs); i++ {
+			if runs[i].Err != nil {
+				return nil, runs[i].Err
+			}
+			if runs[i].ScheduleID == id {
+				filtered = append(filtered, runs[i])
+			}
+		}
+		return filtered, nil
+	}
+
+	// first tick launched, the second waits for it
+	now = now.Add(time.Hour)
+	var runs []ScheduleRun
+	if runs, err = outcomes(queued.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(runs) != 1 || runs[0].Outcome != ScheduleLaunched {
+		t.Errorf("expected a launch, got %v", runs)
+		return
+	}
+	first := runs[0].JobID
+
+	now = now.Add(time.Hour)
+	if runs, err = outcomes(queued.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(runs) != 1 || runs[0].Outcome != ScheduleQueued {
+		t.Errorf("expected a queued tick, got %v", runs)
+		return
+	}
+
+	var tasks []types.Task
+	if tasks, err = jj.GetTasks(first); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(tasks[0].TaskUnitIDs[0], types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if runs, err = outcomes(queued.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(runs) != 1 || runs[0].Outcome != ScheduleLaunched || runs[0].JobID == first {
+		t.Errorf("expected the queued tick to be launched, got %v", runs)
+		return
+	}
+
+	var job *types.Job
+	if job, err = jj.GetJob(runs[0].JobID); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.ScheduleID != queued.Key || job.TopicID != topic.Key {
+		t.Errorf("expected a job of schedule %v on topic %v, got %v", queued.Key, topic.Key, job)
+		return
+	}
+
+	// five hours of downtime
+	for _, id := range []types.ScheduleID{catchUp.Key, latest.Key} {
+		if _, err = jj.ResumeSchedule(id); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	now = time.Now().Add(5 * time.Hour)
+	if runs, err = scheduler.Tick(); err != nil {
+		t.Error(err)
+		return
+	}
+	launched := map[types.ScheduleID]int{}
+	missed := map[types.ScheduleID]int{}
+	for i := 0; i < len(runs); i++ {
+		switch runs[i].Outcome {
+		case ScheduleLaunched:
+			launched[runs[i].ScheduleID]++
+		case ScheduleMissed:
+			missed[runs[i].ScheduleID] += runs[i].Missed
+		}
+	}
+	if launched[catchUp.Key] != 5 || missed[catchUp.Key] != 0 {
+		t.Errorf("expected 5 launches with catch up, got %v and %v missed", launched[catchUp.Key], missed[catchUp.Key])
+		return
+	}
+	if launched[latest.Key] != 1 || missed[latest.Key] != 4 {
+		t.Errorf("expected 1 launch and 4 missed without catch up, got %v and %v", launched[latest.Key], missed[latest.Key])
+		return
+	}
+}
@@ -0,0 +1,244 @@
+package junjo
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestMemorySnapshot$ .
+func TestMemorySnapshot(t *testing.T) {
+	storage := memory.NewMemoryStorage()
+	var err error
+	jj := NewJ(storage)
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var logging *types.TaskDefinition
+	if logging, err = jj.CreateTaskDefinition("log", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	unitDag.ConnectDef(unitDag.AddTaskDefinition(provisioning), unitDag.AddTaskDefinition(logging))
+
+	var units []*types.TaskUnit
+	if units, err = unitDag.ToTaskUnits(); err != nil {
+		t.Error(err)
+		return
+	}
+	units[0].Commands = append(units[0].Commands, types.Command{
+		Type:    types.LogCmd,
+		Details: "booting",
+		Data:    map[string]string{"ip": "10.0.0.1"},
+	})
+
+	var ids []types.TaskUnitID
+	if ids, err = jj.CreateTaskUnits(units); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var task *types.Task
+	if task, err = jj.CreateTask(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var job *types.Job
+	if job, err = jj.CreateJob(types.WithJobData(map[string]string{"machine": "uuid"})); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err = jj.AssignTaskUnits(task.Key, ids); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.AssignTask(job.Key, task.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.AssignJob(topic.Key, job.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err = storage.UpdateTaskUnitStatus(units[1].Key, types.ErrorStatus, fmt.Errorf("disk not found")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err = storage.Snapshot(&buffer); err != nil {
+		t.Error(err)
+		return
+	}
+	raw := buffer.Bytes()
+
+	restored := memory.NewMemoryStorage()
+	if err = restored.Restore(bytes.NewReader(raw)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var restoredTopic *types.Topic
+	if restoredTopic, err = restored.GetTopic(topic.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if restoredTopic.Name != "Provisioning" || restoredTopic.Jobs[job.Key] == nil {
+		t.Error(fmt.Errorf("topic should be restored with its job"))
+		return
+	}
+
+	restoredJob := restoredTopic.Jobs[job.Key]
+	if restoredJob.Data["machine"] != "uuid" || restoredJob.Tasks[task.Key] == nil {
+		t.Error(fmt.Errorf("job should be restored with its data and task"))
+		return
+	}
+
+	restoredTask := restoredJob.Tasks[task.Key]
+	if len(restoredTask.TaskUnits) != 2 {
+		t.Error(fmt.Errorf("task should have 2 units, instead have %v", len(restoredTask.TaskUnits)))
+		return
+	}
+
+	for _, unit := range restoredTask.TaskUnits {
+		if len(unit.DependsOn) != len(unit.DependsOnIDs) {
+			t.Error(fmt.Errorf("unit %v should have its dependencies rebuilt", unit.Key))
+			return
+		}
+	}
+
+	var first *types.TaskUnit
+	if first, err = restored.GetTaskUnit(units[0].Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(first.Commands) != 1 || first.Commands[0].Data["ip"] != "10.0.0.1" {
+		t.Error(fmt.Errorf("commands should be restored"))
+		return
+	}
+
+	var second *types.TaskUnit
+	if second, err = restored.GetTaskUnit(units[1].Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if second.Status != types.ErrorStatus || second.Error == nil || second.Error.Error() != "disk not found" {
+		t.Error(fmt.Errorf("error status should be restored"))
+		return
+	}
+
+	// same content should give the same snapshot
+	var again bytes.Buffer
+	if err = restored.Snapshot(&again); err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(raw, again.Bytes()) {
+		t.Error(fmt.Errorf("snapshot of a restored storage should be identical"))
+		return
+	}
+
+	if err = restored.Restore(bytes.NewReader([]byte(`{"version": 99}`))); err == nil {
+		t.Error(fmt.Errorf("unknown version should be rejected"))
+		return
+	}
+}
+
+// Written by the first version of `Snapshot`, before the namespaces, the definition versions, the creation dates and the topic of the jobs
+const snapshotV1 = `{
+  "version": 1,
+  "owners": [{"id": "btl", "name": "BTL", "description": ""}],
+  "definitions": [
+    {"id": "provisioning", "name": "provisioning", "description": "", "details": "", "identifier": "", "ownerID": "btl"},
+    {"id": "log", "name": "log", "description": "", "details": "", "identifier": "", "ownerID": "btl"}
+  ],
+  "topics": [
+    {"id": "topic", "inputType": "", "name": "Provisioning", "description": "", "jobIds": ["job"],
+      "totalCompleted": null, "totalPending": null, "totalError": null, "totalPause": null, "deprecated": false}
+  ],
+  "jobs": [{"id": "job", "taskIds": ["task"], "status": "none", "data": {"machine": "uuid"}, "topicID": ""}],
+  "tasks": [{"id": "task", "jobID": "job", "status": "none", "taskUnitIds": ["first", "second"]}],
+  "units": [
+    {"id": "first", "taskDefinitionID": "provisioning", "dependsOnIds": [], "commands": [], "status": "none", "taskID": "task", "data": null},
+    {"id": "second", "taskDefinitionID": "log", "dependsOnIds": ["first"], "commands": [], "status": "none", "taskID": "task", "data": null}
+  ]
+}`
+
+// go test -timeout 30s -v -count=1 -run ^TestMemorySnapshotV1$ .
+func TestMemorySnapshotV1(t *testing.T) {
+	var err error
+	storage := memory.NewMemoryStorage()
+	if err = storage.Restore(bytes.NewReader([]byte(snapshotV1))); err != nil {
+		t.Error(err)
+		return
+	}
+	jj := NewJ(storage)
+
+	var job *types.Job
+	if job, err = jj.GetJob("job"); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.TopicID != "topic" || job.Namespace != types.DefaultNamespace || job.CreatedAt.IsZero() {
+		t.Errorf("expected the job upgraded, got topic %q namespace %q created at %v", job.TopicID, job.Namespace, job.CreatedAt)
+		return
+	}
+	var unit *types.TaskUnit
+	if unit, err = jj.GetTaskUnit("second"); err != nil {
+		t.Error(err)
+		return
+	}
+	if unit.DefinitionVersion != 1 {
+		t.Errorf("expected the unit pinned to the first version, got %v", unit.DefinitionVersion)
+		return
+	}
+
+	// the job isn't taken for a draft, its first unit is offered
+	var inbox []types.InboxAllTaskUnit
+	if inbox, err = jj.GetInbox("btl"); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inbox) != 1 || len(inbox[0].TaskUnits) != 1 || inbox[0].TaskUnits[0].Key != "first" {
+		t.Errorf("expected the first unit offered, got %v", inbox)
+		return
+	}
+	if err = jj.SubmitCommand("first", types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err = storage.Snapshot(&buffer); err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte(fmt.Sprintf(`"version": %v,`, memory.SnapshotVersion))) {
+		t.Errorf("expected the snapshot written with the version %v", memory.SnapshotVersion)
+		return
+	}
+}
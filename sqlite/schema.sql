@@ -0,0 +1,83 @@
+CREATE TABLE IF NOT EXISTS "meta" (
+	"key"	TEXT NOT NULL,
+	"value"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "owners" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "definitions" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "definition_versions" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "topics" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "jobs" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "tasks" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "units" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "templates" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "limits" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "schedules" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "webhooks" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "deliveries" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
+CREATE TABLE IF NOT EXISTS "audit" (
+	"key"	TEXT NOT NULL,
+	"position"	INTEGER NOT NULL,
+	"data"	TEXT NOT NULL,
+	PRIMARY KEY("key")
+);
@@ -0,0 +1,340 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/jmoiron/sqlx"
+)
+
+/// `Store` keeps the content of a `MemoryStorage` in a SQLite file, one table per kind of entity (owners, definitions, topics, jobs, tasks, units...) with one row per entity
+/// The engine still runs on the `MemoryStorage`, use it through `Storage` so each change is written to the file in its own short transaction
+///
+///	store, err := sqlite.Open("junjo.sqlite")
+///	jj := junjo.NewJ(store.Storage())
+///
+/// A change takes the write lock of the file, reloads the `MemoryStorage` if another process changed the file, applies the change then writes the rows it touched and releases the lock
+/// Many processes can share the same store, each one waits for the others only while they write
+/// `CreateJobGraph` is one change, a job is written with all its tasks and units or not at all
+
+//go:embed schema.sql
+var schema string
+
+// How long a change waits for another process writing the store
+const busyTimeout = 10000
+
+var (
+	ErrStoreLayout = errors.New("snapshot field without table")
+)
+
+// Tables of the store in the order of the snapshot, each one keeps a field of the snapshot
+var tables = []struct {
+	field string
+	name  string
+}{
+	{"owners", "owners"},
+	{"definitions", "definitions"},
+	{"previousDefinitions", "definition_versions"},
+	{"topics", "topics"},
+	{"jobs", "jobs"},
+	{"tasks", "tasks"},
+	{"units", "units"},
+	{"templates", "templates"},
+	{"limits", "limits"},
+	{"schedules", "schedules"},
+	{"webhooks", "webhooks"},
+	{"deliveries", "deliveries"},
+	{"audit", "audit"},
+}
+
+type Store struct {
+	db   *sqlx.DB
+	conn *sqlx.Conn
+	// one change or reload at a time on the connection
+	mu      sync.Mutex
+	storage *memory.MemoryStorage
+	// revision of the file the storage matches, -1 when it has to be reloaded
+	revision int64
+	// rows of the file the storage matches, per table then per key
+	rows map[string]map[string]string
+	// position of the next row of each table, rows keep the order they were added in
+	next map[string]int64
+}
+
+// Open creates the store file and its tables when missing then loads it
+func Open(path string) (*Store, error) {
+	db, err := sqlx.Connect("sqlite", fmt.Sprintf("%v?_pragma=busy_timeout(%v)", path, busyTimeout))
+	if err != nil {
+		return nil, err
+	}
+	conn, err := db.Connx(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	store := &Store{db: db, conn: conn, storage: memory.NewMemoryStorage(), revision: -1}
+	if _, err = conn.ExecContext(context.Background(), schema); err != nil {
+		store.Close()
+		return nil, err
+	}
+	if err = store.read(); err != nil {
+		store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Storage of the default namespace, see `Storage.Namespace` for the others
+func (s *Store) Storage() *Storage {
+	return &Storage{MemoryStorage: s.storage, store: s}
+}
+
+// Close releases the store, every change was already written
+func (s *Store) Close() error {
+	s.conn.Close()
+	return s.db.Close()
+}
+
+// Reloads the storage when the file changed since it was loaded or written
+func (s *Store) read() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	revision, err := s.fileRevision()
+	if err != nil || revision == s.revision {
+		return err
+	}
+	if _, err = s.conn.ExecContext(ctx, "BEGIN"); err != nil {
+		return err
+	}
+	if err = s.load(); err != nil {
+		s.conn.ExecContext(ctx, "ROLLBACK")
+		return err
+	}
+	_, err = s.conn.ExecContext(ctx, "COMMIT")
+	return err
+}
+
+// Applies the change to the storage and writes it in one transaction, a change which fails writes nothing
+// `BEGIN IMMEDIATE` takes the write lock right away, a plain `BEGIN` would only take it on the first write
+func (s *Store) write(change func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	if _, err := s.conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return err
+	}
+	if err := s.apply(change); err != nil {
+		s.conn.ExecContext(ctx, "ROLLBACK")
+		// the storage may have changed before failing, it gets the content of the file back on the next call
+		s.revision = -1
+		return err
+	}
+	if _, err := s.conn.ExecContext(ctx, "COMMIT"); err != nil {
+		s.revision = -1
+		return err
+	}
+	return nil
+}
+
+func (s *Store) apply(change func() error) error {
+	revision, err := s.fileRevision()
+	if err != nil {
+		return err
+	}
+	if revision != s.revision {
+		if err = s.load(); err != nil {
+			return err
+		}
+	}
+	if err = change(); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+// Revision of the file, bumped by each change written, zero for a new store
+func (s *Store) fileRevision() (int64, error) {
+	var value string
+	err := s.conn.GetContext(context.Background(), &value, `SELECT "value" FROM "meta" WHERE "key" = 'revision'`)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// Replaces the content of the storage with the one of the file, within a transaction
+func (s *Store) load() error {
+	ctx := context.Background()
+
+	revision, err := s.fileRevision()
+	if err != nil {
+		return err
+	}
+	version := strconv.Itoa(memory.SnapshotVersion)
+	err = s.conn.GetContext(ctx, &version, `SELECT "value" FROM "meta" WHERE "key" = 'version'`)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	rows := map[string]map[string]string{}
+	next := map[string]int64{}
+	snap := map[string]json.RawMessage{"version": json.RawMessage(version)}
+	for _, table := range tables {
+		records := []struct {
+			Key      string `db:"key"`
+			Position int64  `db:"position"`
+			Data     string `db:"data"`
+		}{}
+		if err = s.conn.SelectContext(ctx, &records, fmt.Sprintf(`SELECT "key", "position", "data" FROM "%v" ORDER BY "position"`, table.name)); err != nil {
+			return err
+		}
+		rows[table.name] = map[string]string{}
+		values := make([]json.RawMessage, len(records))
+		for i := 0; i < len(records); i++ {
+			rows[table.name][records[i].Key] = records[i].Data
+			values[i] = json.RawMessage(records[i].Data)
+			next[table.name] = records[i].Position + 1
+		}
+		if snap[table.field], err = json.Marshal(values); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if err = s.storage.Restore(bytes.NewReader(data)); err != nil {
+		return err
+	}
+	s.revision, s.rows, s.next = revision, rows, next
+	return nil
+}
+
+// Writes the rows of the storage which changed since the last load or save, within a transaction
+func (s *Store) save() error {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := s.storage.Snapshot(&buf); err != nil {
+		return err
+	}
+	snap := map[string]json.RawMessage{}
+	if err := json.Unmarshal(buf.Bytes(), &snap); err != nil {
+		return err
+	}
+
+	// a field we don't know would be lost on the next load
+	known := map[string]bool{"version": true}
+	for _, table := range tables {
+		known[table.field] = true
+	}
+	for field := range snap {
+		if !known[field] {
+			return fmt.Errorf("%w: %v", ErrStoreLayout, field)
+		}
+	}
+
+	changed := false
+	rows := map[string]map[string]string{}
+	next := map[string]int64{}
+	for _, table := range tables {
+		values := []json.RawMessage{}
+		if raw, ok := snap[table.field]; ok {
+			if err := json.Unmarshal(raw, &values); err != nil {
+				return err
+			}
+		}
+		saved := s.rows[table.name]
+		position := s.next[table.name]
+		rows[table.name] = make(map[string]string, len(values))
+		for i := 0; i < len(values); i++ {
+			key, err := rowKey(table.name, values[i])
+			if err != nil {
+				return err
+			}
+			var data bytes.Buffer
+			if err = json.Compact(&data, values[i]); err != nil {
+				return err
+			}
+			rows[table.name][key] = data.String()
+
+			previous, exists := saved[key]
+			switch {
+			case !exists:
+				_, err = s.conn.ExecContext(ctx, fmt.Sprintf(`INSERT INTO "%v" ("key", "position", "data") VALUES (?, ?, ?)`, table.name), key, position, data.String())
+				position++
+			case previous != data.String():
+				_, err = s.conn.ExecContext(ctx, fmt.Sprintf(`UPDATE "%v" SET "data" = ? WHERE "key" = ?`, table.name), data.String(), key)
+			default:
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			changed = true
+		}
+		for key := range saved {
+			if _, exists := rows[table.name][key]; exists {
+				continue
+			}
+			if _, err := s.conn.ExecContext(ctx, fmt.Sprintf(`DELETE FROM "%v" WHERE "key" = ?`, table.name), key); err != nil {
+				return err
+			}
+			changed = true
+		}
+		next[table.name] = position
+	}
+
+	if !changed {
+		return nil
+	}
+	revision := s.revision + 1
+	if _, err := s.conn.ExecContext(ctx, `INSERT OR REPLACE INTO "meta" ("key", "value") VALUES ('version', ?), ('revision', ?)`, string(snap["version"]), strconv.FormatInt(revision, 10)); err != nil {
+		return err
+	}
+	s.revision, s.rows, s.next = revision, rows, next
+	return nil
+}
+
+// Key of a row, the id of its entity, with its version for the previous versions of a definition, the scope of a limit
+func rowKey(table string, value json.RawMessage) (string, error) {
+	switch table {
+	case "definition_versions":
+		var definition types.TaskDefinition
+		if err := json.Unmarshal(value, &definition); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v@%v", definition.Key, definition.Version), nil
+	case "limits":
+		var limit types.ConcurrencyLimit
+		if err := json.Unmarshal(value, &limit); err != nil {
+			return "", err
+		}
+		return strings.Join([]string{string(limit.Namespace), string(limit.OwnerID), string(limit.TaskDefinitionID), string(limit.TopicID)}, "/"), nil
+	}
+	var entity struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(value, &entity); err != nil {
+		return "", err
+	}
+	return entity.ID, nil
+}
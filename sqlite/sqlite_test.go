@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidroman0O/junjo"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestStore$ ./sqlite
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junjo.sqlite")
+	var store *Store
+	var err error
+	if store, err = Open(path); err != nil {
+		t.Error(err)
+		return
+	}
+	jj := junjo.NewJ(store.Storage())
+
+	if _, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err = jj.UpdateTaskDefinition(provisioning.Key, btl.Key, "provisioning", "v2", ""); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	unitDag.AddTaskDefinition(provisioning)
+
+	var units []*types.TaskUnit
+	if units, err = unitDag.ToTaskUnits(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var ids []types.TaskUnitID
+	if ids, err = jj.CreateTaskUnits(units); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var task *types.Task
+	if task, err = jj.CreateTask(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.AssignTaskUnits(task.Key, ids); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var before bytes.Buffer
+	if err = store.Storage().Snapshot(&before); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = store.Close(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var loaded *Store
+	if loaded, err = Open(path); err != nil {
+		t.Error(err)
+		return
+	}
+	defer loaded.Close()
+
+	var after bytes.Buffer
+	if err = loaded.Storage().Snapshot(&after); err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(before.Bytes(), after.Bytes()) {
+		t.Error(fmt.Errorf("store should load what was saved, saved %v, loaded %v", before.String(), after.String()))
+		return
+	}
+}
+
+// go test -timeout 30s -v -count=1 -run ^TestStoreShared$ ./sqlite
+func TestStoreShared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junjo.sqlite")
+	var first, second *Store
+	var err error
+	if first, err = Open(path); err != nil {
+		t.Error(err)
+		return
+	}
+	defer first.Close()
+	// the first store doesn't hold the file between its changes
+	if second, err = Open(path); err != nil {
+		t.Error(err)
+		return
+	}
+	defer second.Close()
+
+	if _, err = first.Storage().CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var topics []types.Topic
+	if topics, err = second.Storage().GetTopics(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(topics) != 1 {
+		t.Error(fmt.Errorf("second store should read the topic of the first, got %v topics", len(topics)))
+		return
+	}
+
+	if _, err = second.Storage().UpdateTopic(topics[0].Key, "Delivery"); err != nil {
+		t.Error(err)
+		return
+	}
+	var topic *types.Topic
+	if topic, err = first.Storage().GetTopic(topics[0].Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if topic.Name != "Delivery" {
+		t.Error(fmt.Errorf("first store should read the change of the second, got %v", topic.Name))
+		return
+	}
+
+	// a failed change writes nothing
+	if _, err = second.Storage().CreateTopic("Delivery"); err == nil {
+		t.Error(fmt.Errorf("topic name should be taken"))
+		return
+	}
+	if topics, err = first.Storage().GetTopics(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(topics) != 1 {
+		t.Error(fmt.Errorf("failed change shouldn't be written, got %v topics", len(topics)))
+		return
+	}
+}
@@ -0,0 +1,610 @@
+package sqlite
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// `Storage` is the `StorageInterface` of a `Store`, a view of its `MemoryStorage` for one namespace
+/// Each change runs in its own transaction, the lock of the file is released as soon as it's written
+/// Each read first reloads the `MemoryStorage` when another process changed the file since
+
+type Storage struct {
+	*memory.MemoryStorage
+	store *Store
+}
+
+// View of the same store for another namespace
+func (s *Storage) Namespace(namespace types.Namespace) *Storage {
+	return &Storage{MemoryStorage: s.MemoryStorage.Namespace(namespace), store: s.store}
+}
+
+func (s *Storage) CreateOwner(name string, cfgs ...types.OwnerConfig) (*types.Owner, error) {
+	var value *types.Owner
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateOwner(name, cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) CreateTaskDefinition(name string, ownerID types.OwnerID, cfgs ...types.TaskDefinitionConfig) (*types.TaskDefinition, error) {
+	var value *types.TaskDefinition
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateTaskDefinition(name, ownerID, cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) CreateTopic(name string, cfgs ...types.TopicConfig) (*types.Topic, error) {
+	var value *types.Topic
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateTopic(name, cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) CreateTask(cfgs ...types.TaskConfig) (*types.Task, error) {
+	var value *types.Task
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateTask(cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) CreateJob(cfgs ...types.JobConfig) (*types.Job, error) {
+	var value *types.Job
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateJob(cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) CreateTaskUnits(units []*types.TaskUnit) ([]types.TaskUnitID, error) {
+	var value []types.TaskUnitID
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateTaskUnits(units)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) AssignJob(topicID types.TopicID, jobID types.JobID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.AssignJob(topicID, jobID)
+	})
+}
+
+func (s *Storage) AssignTask(jobID types.JobID, taskID types.TaskID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.AssignTask(jobID, taskID)
+	})
+}
+
+func (s *Storage) AssignTaskUnits(taskID types.TaskID, ids []types.TaskUnitID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.AssignTaskUnits(taskID, ids)
+	})
+}
+
+func (s *Storage) GetInbox(ownerID types.OwnerID, params *types.QueryParams) ([]types.InboxAllTaskUnit, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetInbox(ownerID, params)
+}
+
+func (s *Storage) GetInboxTopic(ownerID types.OwnerID, topicID types.TopicID, params *types.QueryParams) ([]types.InboxTopicTaskUnit, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetInboxTopic(ownerID, topicID, params)
+}
+
+func (s *Storage) GetJob(jobID types.JobID) (*types.Job, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetJob(jobID)
+}
+
+func (s *Storage) UpdateTaskDefinition(id types.TaskDefinitionID, ownerID types.OwnerID, name string, description string, identifier string) (*types.TaskDefinition, error) {
+	var value *types.TaskDefinition
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.UpdateTaskDefinition(id, ownerID, name, description, identifier)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) DeprecateTaskDefinition(id types.TaskDefinitionID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeprecateTaskDefinition(id)
+	})
+}
+
+func (s *Storage) HasTaskDefinition(id types.TaskDefinitionID) (bool, error) {
+	if err := s.store.read(); err != nil {
+		return false, err
+	}
+	return s.MemoryStorage.HasTaskDefinition(id)
+}
+
+func (s *Storage) GetTaskDefinition(id types.TaskDefinitionID) (*types.TaskDefinition, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTaskDefinition(id)
+}
+
+func (s *Storage) GetTaskDefinitionVersion(id types.TaskDefinitionID, version int) (*types.TaskDefinition, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTaskDefinitionVersion(id, version)
+}
+
+func (s *Storage) GetTaskDefinitionVersions(id types.TaskDefinitionID) ([]types.TaskDefinition, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTaskDefinitionVersions(id)
+}
+
+func (s *Storage) GetTaskDefinitions() ([]types.TaskDefinition, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTaskDefinitions()
+}
+
+func (s *Storage) GetOwners() ([]types.Owner, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetOwners()
+}
+
+func (s *Storage) GetOwner(ownerID types.OwnerID) (*types.Owner, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetOwner(ownerID)
+}
+
+func (s *Storage) UpdateOwner(ownerID types.OwnerID, name string) (*types.Owner, error) {
+	var value *types.Owner
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.UpdateOwner(ownerID, name)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) DeprecateOwner(ownerID types.OwnerID) (*types.Owner, error) {
+	var value *types.Owner
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.DeprecateOwner(ownerID)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) HasOwner(id types.OwnerID) (bool, error) {
+	if err := s.store.read(); err != nil {
+		return false, err
+	}
+	return s.MemoryStorage.HasOwner(id)
+}
+
+func (s *Storage) SetOwnerMembers(ownerID types.OwnerID, members []types.OwnerID) (*types.Owner, error) {
+	var value *types.Owner
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.SetOwnerMembers(ownerID, members)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) ClaimTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.ClaimTaskUnit(taskUnitID, ownerID)
+	})
+}
+
+func (s *Storage) ReleaseTaskUnit(taskUnitID types.TaskUnitID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.ReleaseTaskUnit(taskUnitID)
+	})
+}
+
+func (s *Storage) ReassignTaskUnit(taskUnitID types.TaskUnitID, ownerID types.OwnerID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.ReassignTaskUnit(taskUnitID, ownerID)
+	})
+}
+
+func (s *Storage) MigrateTaskUnit(taskUnitID types.TaskUnitID, definitionID types.TaskDefinitionID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.MigrateTaskUnit(taskUnitID, definitionID)
+	})
+}
+
+func (s *Storage) GetTopics() ([]types.Topic, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTopics()
+}
+
+func (s *Storage) GetTopic(id types.TopicID) (*types.Topic, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTopic(id)
+}
+
+func (s *Storage) UpdateTopic(id types.TopicID, name string) (*types.Topic, error) {
+	var value *types.Topic
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.UpdateTopic(id, name)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) HasTopic(id types.TopicID) (bool, error) {
+	if err := s.store.read(); err != nil {
+		return false, err
+	}
+	return s.MemoryStorage.HasTopic(id)
+}
+
+func (s *Storage) DeprecateTopic(id types.TopicID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeprecateTopic(id)
+	})
+}
+
+func (s *Storage) GetJobs(id types.TopicID) ([]types.Job, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetJobs(id)
+}
+
+func (s *Storage) HasJob(id types.JobID) (bool, error) {
+	if err := s.store.read(); err != nil {
+		return false, err
+	}
+	return s.MemoryStorage.HasJob(id)
+}
+
+func (s *Storage) CancelJob(jobID types.JobID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.CancelJob(jobID)
+	})
+}
+
+func (s *Storage) GetTasks(jobID types.JobID) ([]types.Task, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTasks(jobID)
+}
+
+func (s *Storage) GetTask(taskID types.TaskID) (*types.Task, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTask(taskID)
+}
+
+func (s *Storage) HasTask(id types.TaskID) (bool, error) {
+	if err := s.store.read(); err != nil {
+		return false, err
+	}
+	return s.MemoryStorage.HasTask(id)
+}
+
+func (s *Storage) CancelTask(taskID types.TaskID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.CancelTask(taskID)
+	})
+}
+
+func (s *Storage) GetTaskUnits(taskID types.TaskID) ([]types.TaskUnit, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTaskUnits(taskID)
+}
+
+func (s *Storage) GetTaskUnit(taskUnitID types.TaskUnitID) (*types.TaskUnit, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTaskUnit(taskUnitID)
+}
+
+func (s *Storage) HasTaskUnit(id types.TaskUnitID) (bool, error) {
+	if err := s.store.read(); err != nil {
+		return false, err
+	}
+	return s.MemoryStorage.HasTaskUnit(id)
+}
+
+func (s *Storage) UpdateJobStatus(jobID types.JobID, status types.StatusType) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.UpdateJobStatus(jobID, status)
+	})
+}
+
+func (s *Storage) UpdateTaskStatus(taskID types.TaskID, status types.StatusType) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.UpdateTaskStatus(taskID, status)
+	})
+}
+
+func (s *Storage) UpdateTaskUnitStatus(taskUnitID types.TaskUnitID, status types.StatusType, unitErr error) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.UpdateTaskUnitStatus(taskUnitID, status, unitErr)
+	})
+}
+
+func (s *Storage) MarkTaskUnitAvailable(taskUnitID types.TaskUnitID, at time.Time) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.MarkTaskUnitAvailable(taskUnitID, at)
+	})
+}
+
+func (s *Storage) AddTaskUnitCommand(taskUnitID types.TaskUnitID, cmd types.Command) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.AddTaskUnitCommand(taskUnitID, cmd)
+	})
+}
+
+func (s *Storage) CreateJobGraph(topicID types.TopicID, tasks [][]*types.TaskUnit, cfgs ...types.JobConfig) (*types.Job, error) {
+	var value *types.Job
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateJobGraph(topicID, tasks, cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) ExpandFanOut(unitID types.TaskUnitID, children []*types.TaskUnit) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.ExpandFanOut(unitID, children)
+	})
+}
+
+func (s *Storage) GetBatchJobs(batchID types.BatchID) ([]types.Job, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetBatchJobs(batchID)
+}
+
+func (s *Storage) SetConcurrencyLimit(limit types.ConcurrencyLimit) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.SetConcurrencyLimit(limit)
+	})
+}
+
+func (s *Storage) GetConcurrencyLimits() ([]types.ConcurrencyLimit, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetConcurrencyLimits()
+}
+
+func (s *Storage) AddJobDependencies(jobID types.JobID, upstreamIDs []types.JobID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.AddJobDependencies(jobID, upstreamIDs)
+	})
+}
+
+func (s *Storage) GetDependentJobs(jobID types.JobID) ([]types.Job, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetDependentJobs(jobID)
+}
+
+func (s *Storage) CreateSchedule(topicID types.TopicID, name string, cron string, cfgs ...types.ScheduleConfig) (*types.Schedule, error) {
+	var value *types.Schedule
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateSchedule(topicID, name, cron, cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) GetSchedule(id types.ScheduleID) (*types.Schedule, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetSchedule(id)
+}
+
+func (s *Storage) GetSchedules() ([]types.Schedule, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetSchedules()
+}
+
+func (s *Storage) UpdateSchedule(schedule types.Schedule) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.UpdateSchedule(schedule)
+	})
+}
+
+func (s *Storage) DeleteSchedule(id types.ScheduleID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeleteSchedule(id)
+	})
+}
+
+func (s *Storage) CreateWebhook(url string, cfgs ...types.WebhookConfig) (*types.Webhook, error) {
+	var value *types.Webhook
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateWebhook(url, cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) GetWebhook(id types.WebhookID) (*types.Webhook, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetWebhook(id)
+}
+
+func (s *Storage) GetWebhooks() ([]types.Webhook, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetWebhooks()
+}
+
+func (s *Storage) DeleteWebhook(id types.WebhookID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeleteWebhook(id)
+	})
+}
+
+func (s *Storage) AddDeliveries(deliveries []types.Delivery) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.AddDeliveries(deliveries)
+	})
+}
+
+func (s *Storage) GetDeliveries(webhookID types.WebhookID) ([]types.Delivery, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetDeliveries(webhookID)
+}
+
+func (s *Storage) GetPendingDeliveries() ([]types.Delivery, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetPendingDeliveries()
+}
+
+func (s *Storage) UpdateDelivery(delivery types.Delivery) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.UpdateDelivery(delivery)
+	})
+}
+
+func (s *Storage) GetDraftJobs() ([]types.Job, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetDraftJobs()
+}
+
+func (s *Storage) GetDraftTasks() ([]types.Task, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetDraftTasks()
+}
+
+func (s *Storage) GetDraftTaskUnits() ([]types.TaskUnit, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetDraftTaskUnits()
+}
+
+func (s *Storage) DeleteJob(jobID types.JobID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeleteJob(jobID)
+	})
+}
+
+func (s *Storage) DeleteTask(taskID types.TaskID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeleteTask(taskID)
+	})
+}
+
+func (s *Storage) DeleteTaskUnit(taskUnitID types.TaskUnitID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeleteTaskUnit(taskUnitID)
+	})
+}
+
+func (s *Storage) DeleteDraftJob(jobID types.JobID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeleteDraftJob(jobID)
+	})
+}
+
+func (s *Storage) DeleteDraftTask(taskID types.TaskID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeleteDraftTask(taskID)
+	})
+}
+
+func (s *Storage) DeleteDraftTaskUnit(taskUnitID types.TaskUnitID) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.DeleteDraftTaskUnit(taskUnitID)
+	})
+}
+
+func (s *Storage) CreateTemplate(name string, dag json.RawMessage, cfgs ...types.TemplateConfig) (*types.Template, error) {
+	var value *types.Template
+	err := s.store.write(func() (err error) {
+		value, err = s.MemoryStorage.CreateTemplate(name, dag, cfgs...)
+		return err
+	})
+	return value, err
+}
+
+func (s *Storage) GetTemplate(id types.TemplateID) (*types.Template, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTemplate(id)
+}
+
+func (s *Storage) GetTemplates() ([]types.Template, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTemplates()
+}
+
+func (s *Storage) GetTemplateVersions(name string) ([]types.Template, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetTemplateVersions(name)
+}
+
+func (s *Storage) AddAuditRecord(record types.AuditRecord) error {
+	return s.store.write(func() error {
+		return s.MemoryStorage.AddAuditRecord(record)
+	})
+}
+
+func (s *Storage) GetAuditRecords() ([]types.AuditRecord, error) {
+	if err := s.store.read(); err != nil {
+		return nil, err
+	}
+	return s.MemoryStorage.GetAuditRecords()
+}
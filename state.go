@@ -0,0 +1,29 @@
+package junjo
+
+import (
+	"github.com/davidroman0O/junjo/types"
+)
+
+// Data of the unit patched by all its commands, the workers can report their results bit by bit
+func (j *Junjoold) GetTaskUnitState(taskUnitID types.TaskUnitID) (*types.TaskUnitState, error) {
+	if err := j.authorizeUnit(types.ActionJobRead, taskUnitID); err != nil {
+		return nil, err
+	}
+	unit, err := j.storageImplementation.GetTaskUnit(taskUnitID)
+	if err != nil {
+		return nil, err
+	}
+	return unit.State(), nil
+}
+
+// Data of the unit patched by its first `index` commands, refused with `ErrCommandIndexInvalid` beyond them
+func (j *Junjoold) GetTaskUnitStateAt(taskUnitID types.TaskUnitID, index int) (*types.TaskUnitState, error) {
+	if err := j.authorizeUnit(types.ActionJobRead, taskUnitID); err != nil {
+		return nil, err
+	}
+	unit, err := j.storageImplementation.GetTaskUnit(taskUnitID)
+	if err != nil {
+		return nil, err
+	}
+	return unit.StateAt(index)
+}
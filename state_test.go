@@ -0,0 +1,74 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestTaskUnitState$ .
+func TestTaskUnitState(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Factory"); err != nil {
+		t.Error(err)
+		return
+	}
+	var bench *types.Owner
+	if bench, err = jj.CreateOwner("Bench"); err != nil {
+		t.Error(err)
+		return
+	}
+	var flash *types.TaskDefinition
+	if flash, err = jj.CreateTaskDefinition("flash", bench.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	unitDag := jj.CreateDagTaskUnits()
+	node := unitDag.AddTaskDefinition(flash)()
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+	unitID := node.(*types.NodeTaskUnit).Unit.Key
+
+	// the bench reports the devices as it goes
+	commands := []types.Command{
+		{Type: types.ProgressCmd, Data: map[string]string{"flashed": "12", "retry": "1"}},
+		{Type: types.LogCmd, Details: "metadata only", Data: map[string]string{"flashed": "0"}},
+		{Type: types.ProgressCmd, Data: map[string]string{"flashed": "40", "retry": ""}},
+		{Type: types.SuccessCmd, Data: map[string]string{"report": "ok"}},
+	}
+	for i := 0; i < len(commands); i++ {
+		if err = jj.SubmitCommand(unitID, commands[i]); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	var state *types.TaskUnitState
+	if state, err = jj.GetTaskUnitState(unitID); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, removed := state.Data["retry"]; state.Index != 4 || len(state.Data) != 2 || state.Data["flashed"] != "40" || state.Data["report"] != "ok" || removed {
+		t.Errorf("expected the patches applied in order, got %v", state)
+		return
+	}
+	if state, err = jj.GetTaskUnitStateAt(unitID, 2); err != nil {
+		t.Error(err)
+		return
+	}
+	if state.Data["flashed"] != "12" || state.Data["retry"] != "1" {
+		t.Errorf("expected the state after the log, got %v", state)
+		return
+	}
+	if _, err = jj.GetTaskUnitStateAt(unitID, 5); !errors.Is(err, types.ErrCommandIndexInvalid) {
+		t.Errorf("expected an index beyond the commands refused, got %v", err)
+		return
+	}
+}
@@ -0,0 +1,134 @@
+package junjo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/davidroman0O/junjo/dag"
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Templates are saved `WorkUnitDag` that non-developers can design from the web editor and launch as many times as they want
+/// A template is validated with `AcyclicGraph.Validate`: no cycles, no self references and a single vertex to start from
+
+// Check that a `WorkUnitDag` can be saved as a `Template`, each vertex must have an existing `TaskDefinition`
+func (j *Junjoold) ValidateTemplate(workUnitDag *types.WorkUnitDag) error {
+	// `Validate` reports the roots as pointers, we want the ids in the editor
+	roots := []string{}
+	for _, vertex := range workUnitDag.Graph().Vertices() {
+		if len(workUnitDag.Graph().UpEdges(vertex)) == 0 {
+			roots = append(roots, dag.VertexName(vertex))
+		}
+	}
+	if len(roots) > 1 {
+		sort.Strings(roots)
+		return fmt.Errorf("%w: a template needs a single starting vertex, got %v", types.ErrTemplateInvalid, strings.Join(roots, ", "))
+	}
+	if err := workUnitDag.Graph().Validate(); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrTemplateInvalid, err)
+	}
+	for _, vertex := range workUnitDag.Graph().Vertices() {
+		node, ok := vertex.(*types.NodeTaskUnit)
+		if !ok {
+			return fmt.Errorf("%w: vertex is not a NodeTaskUnit", types.ErrTemplateInvalid)
+		}
+		if node.Definition == nil {
+			return fmt.Errorf("%w: vertex %v has no task definition", types.ErrTemplateInvalid, node.Unit.Key)
+		}
+		has, err := j.storageImplementation.HasTaskDefinition(node.Definition.Key)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return fmt.Errorf("%w: vertex %v references unknown task definition %v", types.ErrTemplateInvalid, node.Unit.Key, node.Definition.Key)
+		}
+	}
+	units, err := workUnitDag.ToTaskUnits()
+	if err != nil {
+		return fmt.Errorf("%w: %v", types.ErrTemplateInvalid, err)
+	}
+	if err = types.ValidateFanOuts(units); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrTemplateInvalid, err)
+	}
+	if err = types.ValidateJoins(units); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrTemplateInvalid, err)
+	}
+	return nil
+}
+
+// Save a new version of a `Template`, the first save of a name is the version 1
+func (j *Junjoold) SaveTemplate(name string, workUnitDag *types.WorkUnitDag, cfgs ...types.TemplateConfig) (*types.Template, error) {
+	if err := j.authorize(types.ActionTemplateManage, types.Scope{}, name); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%w: template needs a name", types.ErrTemplateInvalid)
+	}
+	if err := j.ValidateTemplate(workUnitDag); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(workUnitDag)
+	if err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.CreateTemplate(name, data, cfgs...)
+}
+
+func (j *Junjoold) GetTemplate(id types.TemplateID) (*types.Template, error) {
+	return j.storageImplementation.GetTemplate(id)
+}
+
+// Latest version of each `Template`
+func (j *Junjoold) GetTemplates() ([]types.Template, error) {
+	return j.storageImplementation.GetTemplates()
+}
+
+func (j *Junjoold) GetTemplateVersions(name string) ([]types.Template, error) {
+	return j.storageImplementation.GetTemplateVersions(name)
+}
+
+// Load the `WorkUnitDag` of a `Template` with the current version of its `TaskDefinition`
+func (j *Junjoold) TemplateDag(id types.TemplateID) (*types.WorkUnitDag, error) {
+	template, err := j.storageImplementation.GetTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+
+	workUnitDag := j.CreateDagTaskUnits()
+	if err = json.Unmarshal(template.Dag, workUnitDag); err != nil {
+		return nil, err
+	}
+
+	for _, vertex := range workUnitDag.Graph().Vertices() {
+		node, ok := vertex.(*types.NodeTaskUnit)
+		if !ok || node.Definition == nil {
+			continue
+		}
+		if node.Definition, err = j.storageImplementation.GetTaskDefinition(node.Definition.Key); err != nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrTemplateInvalid, err)
+		}
+	}
+
+	return workUnitDag, nil
+}
+
+// Create and assign a new `Job` from a `Template`, each launch has its own `TaskUnit`
+func (j *Junjoold) LaunchTemplate(topicID types.TopicID, id types.TemplateID, cfgs ...types.JobConfig) (*types.Job, error) {
+	if err := j.authorizeTopic(types.ActionJobLaunch, topicID); err != nil {
+		return nil, err
+	}
+	return j.launchTemplate(topicID, id, cfgs...)
+}
+
+func (j *Junjoold) launchTemplate(topicID types.TopicID, id types.TemplateID, cfgs ...types.JobConfig) (*types.Job, error) {
+	workUnitDag, err := j.TemplateDag(id)
+	if err != nil {
+		return nil, err
+	}
+	if workUnitDag, err = workUnitDag.Clone(); err != nil {
+		return nil, err
+	}
+	return j.launchJob(topicID, workUnitDag, cfgs...)
+}
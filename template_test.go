@@ -0,0 +1,99 @@
+package junjo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestTemplate$ .
+func TestTemplate(t *testing.T) {
+	storage := memory.NewMemoryStorage()
+	var err error
+	jj := NewJ(storage)
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Provisioning"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var provisioning *types.TaskDefinition
+	if provisioning, err = jj.CreateTaskDefinition("provisioning", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var logging *types.TaskDefinition
+	if logging, err = jj.CreateTaskDefinition("log", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// two vertices without dependencies: `AcyclicGraph.Validate` wants a single root
+	invalid := jj.CreateDagTaskUnits()
+	invalid.AddTaskDefinition(provisioning)()
+	invalid.AddTaskDefinition(logging)()
+	if _, err = jj.SaveTemplate("server", invalid); !errors.Is(err, types.ErrTemplateInvalid) {
+		t.Errorf("expected %v, got %v", types.ErrTemplateInvalid, err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	unitDag.ConnectDef(unitDag.AddTaskDefinition(provisioning), unitDag.AddTaskDefinition(logging))
+
+	var first *types.Template
+	if first, err = jj.SaveTemplate("server", unitDag, types.WithTemplateDescription("first")); err != nil {
+		t.Error(err)
+		return
+	}
+	var second *types.Template
+	if second, err = jj.SaveTemplate("server", unitDag, types.WithTemplateDescription("second")); err != nil {
+		t.Error(err)
+		return
+	}
+	if first.Version != 1 || second.Version != 2 {
+		t.Errorf("expected versions 1 and 2, got %v and %v", first.Version, second.Version)
+		return
+	}
+
+	var templates []types.Template
+	if templates, err = jj.GetTemplates(); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(templates) != 1 || templates[0].Key != second.Key {
+		t.Errorf("expected the latest version only, got %v", templates)
+		return
+	}
+
+	// each launch must have its own units
+	var job *types.Job
+	units := map[types.TaskUnitID]bool{}
+	for i := 0; i < 2; i++ {
+		if job, err = jj.LaunchTemplate(topic.Key, first.Key); err != nil {
+			t.Error(err)
+			return
+		}
+		var tasks []types.Task
+		if tasks, err = jj.GetTasks(job.Key); err != nil {
+			t.Error(err)
+			return
+		}
+		for j := 0; j < len(tasks[0].TaskUnitIDs); j++ {
+			units[tasks[0].TaskUnitIDs[j]] = true
+		}
+	}
+	if len(units) != 4 {
+		t.Errorf("expected 4 distinct units, got %v", len(units))
+		return
+	}
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+/// A `TaskDefinition` with an `Approval` is a gate, its units wait for reviews instead of being worked on
+///
+///	wipe, _ := jj.CreateTaskDefinition("approve the wipe", ops.Key, types.WithTaskDefApproval(2, alice.Key, bob.Key, carol.Key))
+///	...
+///	jj.Approve(unit.Key, alice.Key, "backups checked")
+///	jj.Reject(unit.Key, bob.Key, "wrong rack") // fails the unit with the reason
+///
+/// The gate is offered to the approvers who didn't review it yet, it succeeds with the `Required` approvals
+/// It blocks its descendants like any unit and only moves with `ApproveCmd` and `RejectCmd`
+/// An expired gate leaves the inbox right away but only fails once `ExpireApprovals` runs, the `Scheduler` calls it on each check
+
+var (
+	ErrApprovalInvalid = errors.New("invalid approval")
+	ErrNotApprover     = errors.New("owner is not an approver")
+	ErrApprovalExpired = errors.New("approval expired")
+)
+
+// Key of the data of an `ApproveCmd` or `RejectCmd` with the `OwnerID` of the reviewer
+const Reviewer = "reviewer"
+
+type Approval struct {
+	Required     int           `json:"required"`               // approvals needed to succeed
+	Approvers    []OwnerID     `json:"approvers"`              // owners allowed to review
+	ExpiresAfter time.Duration `json:"expiresAfter,omitempty"` // from the moment the unit becomes available, see `TaskUnit.AvailableAt`, never when zero
+}
+
+// Keeps the expiry given before, the options apply in any order
+func WithTaskDefApproval(required int, approvers ...OwnerID) TaskDefinitionConfig {
+	return func(data *TaskDefinition) {
+		if data.Approval == nil {
+			data.Approval = &Approval{}
+		}
+		data.Approval.Required, data.Approval.Approvers = required, approvers
+	}
+}
+
+// Fail the gates still waiting for reviews that long after they became available
+// An expiry without `WithTaskDefApproval` is refused, the gate would have no approver
+func WithTaskDefApprovalExpiry(expiresAfter time.Duration) TaskDefinitionConfig {
+	return func(data *TaskDefinition) {
+		if data.Approval == nil {
+			data.Approval = &Approval{}
+		}
+		data.Approval.ExpiresAfter = expiresAfter
+	}
+}
+
+func (a *Approval) Allows(ownerID OwnerID) bool {
+	if a == nil {
+		return false
+	}
+	for i := 0; i < len(a.Approvers); i++ {
+		if a.Approvers[i] == ownerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the gate of a unit available since `availableAt` can't be reviewed anymore, a gate never available doesn't expire
+func (a *Approval) Expired(availableAt time.Time, now time.Time) bool {
+	return a != nil && a.ExpiresAfter > 0 && !availableAt.IsZero() && !now.Before(availableAt.Add(a.ExpiresAfter))
+}
+
+// Reviewers who approved the unit, once each
+func Approvals(commands []Command) []OwnerID {
+	reviewers := []OwnerID{}
+	seen := map[OwnerID]bool{}
+	for i := 0; i < len(commands); i++ {
+		reviewer := OwnerID(commands[i].Data[Reviewer])
+		if commands[i].Type == ApproveCmd && !seen[reviewer] {
+			seen[reviewer] = true
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	return reviewers
+}
+
+// Whether the owner already reviewed the unit
+func Reviewed(commands []Command, ownerID OwnerID) bool {
+	for i := 0; i < len(commands); i++ {
+		if (commands[i].Type == ApproveCmd || commands[i].Type == RejectCmd) && OwnerID(commands[i].Data[Reviewer]) == ownerID {
+			return true
+		}
+	}
+	return false
+}
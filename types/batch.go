@@ -0,0 +1,20 @@
+package types
+
+import "errors"
+
+var (
+	ErrBatchNotFound = errors.New("batch not found")
+)
+
+// Identify the jobs launched together from the same `WorkUnitDag`
+type BatchID string
+
+// Aggregated progression of the jobs of a batch
+type BatchProgress struct {
+	Key      BatchID            `json:"id"`
+	TopicID  TopicID            `json:"topicID"`
+	Status   StatusType         `json:"status"` // see `RollupStatus`
+	Total    int                `json:"total"`
+	Statuses map[StatusType]int `json:"statuses"`
+	JobIDs   []JobID            `json:"jobIds"`
+}
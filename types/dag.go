@@ -40,7 +40,7 @@ func (d *WorkUnitDag) AddTaskDefinition(taskDescription *TaskDefinition) TaskUni
 // Create a new Vertex based on a TaskUnit, you will have to manually connect each vertexes
 // If the node has no owner, it won't be visible by any
 func (d *WorkUnitDag) AddTaskUnit(cfgs ...NodeTaskUnitConfig) dag.Vertex {
-	cfgs = append(cfgs, WithNodeWithTaskStatus(NoneStatus)) // make sure that all Node have at least a status
+	cfgs = append([]NodeTaskUnitConfig{WithNodeWithTaskStatus(NoneStatus)}, cfgs...) // make sure that all Node have at least a status
 	node := NewNodeTaskUnit(cfgs...)
 	return d.graph.Add(node)
 }
@@ -79,6 +79,66 @@ func (d *WorkUnitDag) MConnectDef(def TaskUnitFactory, sources ...dag.Vertex) da
 	return target
 }
 
+// Clone copies the DAG with a new `TaskUnitID` for each vertex so it can be launched again, definitions are shared and data copied
+func (d *WorkUnitDag) Clone() (*WorkUnitDag, error) {
+	if d.storageImplementation == nil {
+		return nil, fmt.Errorf("cannot clone a dag without storage implementation")
+	}
+	clone := NewWorkUnitDag(d.storageImplementation)
+
+	vertices := map[dag.Vertex]dag.Vertex{}
+	keys := map[TaskUnitID]TaskUnitID{}
+	for _, vertex := range d.graph.Vertices() {
+		node, ok := vertex.(*NodeTaskUnit)
+		if !ok {
+			return nil, fmt.Errorf("vertex is not a NodeTaskUnit")
+		}
+		uuid, err := d.storageImplementation.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		var data map[string]string
+		if node.Unit.Data != nil {
+			data = make(map[string]string, len(node.Unit.Data))
+			for k, v := range node.Unit.Data {
+				data[k] = v
+			}
+		}
+		vertices[vertex] = clone.graph.Add(NewNodeTaskUnit(
+			WithNodeWithTaskKey(TaskUnitID(uuid)),
+			WithNodeWithTaskStatus(node.Unit.Status),
+			WithNodeWithTaskData(data),
+			WithNodeWithTaskPriority(node.Unit.Priority),
+			WithNodeWithTaskDefinition(node.Definition),
+			WithNodeWithTaskOwner(node.Unit.OwnerID),
+			WithNodeWithTaskDefinitionVersion(node.Unit.DefinitionVersion),
+		))
+		keys[node.Unit.Key] = TaskUnitID(uuid)
+	}
+
+	// a fan-in follows the new key of its fan-out, the rest is copied
+	for vertex, cloned := range vertices {
+		node := vertex.(*NodeTaskUnit)
+		if node.Unit.FanOut != nil {
+			fanOut := *node.Unit.FanOut
+			cloned.(*NodeTaskUnit).Unit.FanOut = &fanOut
+		}
+		if node.Unit.FanIn != nil {
+			cloned.(*NodeTaskUnit).Unit.FanIn = &FanIn{FanOutID: keys[node.Unit.FanIn.FanOutID], Quorum: node.Unit.FanIn.Quorum}
+		}
+		if node.Unit.Join != nil {
+			join := *node.Unit.Join
+			cloned.(*NodeTaskUnit).Unit.Join = &join
+		}
+	}
+
+	for _, edge := range d.graph.Edges() {
+		clone.graph.Connect(dag.BasicEdge(vertices[edge.Source()], vertices[edge.Target()]))
+	}
+
+	return clone, nil
+}
+
 func (d *WorkUnitDag) Print() {
 	fmt.Println(d.graph.Graph.StringWithNodeTypes())
 }
@@ -99,6 +159,12 @@ func (d *WorkUnitDag) ToTaskUnits() ([]*TaskUnit, error) {
 			WithTaskUnitStatus(nodeTaskUnit.Unit.Status),
 			WithTaskUnitData(nodeTaskUnit.Unit.Data),
 		)
+		taskUnit.Priority = nodeTaskUnit.Unit.Priority
+		taskUnit.FanOut = nodeTaskUnit.Unit.FanOut
+		taskUnit.FanIn = nodeTaskUnit.Unit.FanIn
+		taskUnit.Join = nodeTaskUnit.Unit.Join
+		taskUnit.OwnerID = nodeTaskUnit.Unit.OwnerID
+		taskUnit.DefinitionVersion = nodeTaskUnit.Unit.DefinitionVersion
 
 		// Collect dependencies
 		immediateAncestors, err := d.graph.ImmediateAncestors(vertex)
@@ -137,23 +203,15 @@ func (d *WorkUnitDag) AvailableNodeUnit() []NodeTaskUnit {
 	var availableUnits []NodeTaskUnit
 	for _, vertex := range d.graph.Vertices() {
 		node, ok := vertex.(*NodeTaskUnit)
-		if !ok || node.Unit.Status != NoneStatus {
+		if !ok || node.Unit.Status != NoneStatus || node.Unit.FanOut != nil {
 			continue
 		}
-		immediateAncestors, err := d.graph.ImmediateAncestors(vertex)
+		joined, err := d.joined(vertex)
 		if err != nil {
 			// todo: handle error
 			continue
 		}
-		allAncestorsSuccess := true
-		for _, ancestorVertex := range immediateAncestors {
-			ancestor, ok := ancestorVertex.(*NodeTaskUnit)
-			if !ok || ancestor.Unit.Status != SuccessStatus {
-				allAncestorsSuccess = false
-				break
-			}
-		}
-		if allAncestorsSuccess {
+		if joined {
 			availableUnits = append(availableUnits, *node)
 		}
 	}
@@ -175,45 +233,30 @@ func (d *WorkUnitDag) CanChangeStatus(vertex dag.Vertex) (bool, error) {
 		return false, nil
 	}
 
-	// Check if all predecessors are in SuccessStatus
-	predecessors, err := d.graph.ImmediateAncestors(vertex)
-	if err != nil {
-		return false, err
-	}
-	for _, predecessorVertex := range predecessors {
-		predecessor, ok := predecessorVertex.(*NodeTaskUnit)
-		if !ok || predecessor.Unit.Status != SuccessStatus {
-			return false, nil // One of the predecessors is not in SuccessStatus, status cannot be changed
-		}
-	}
-
-	return true, nil // All checks passed, status can be changed
+	// Check if enough predecessors are in SuccessStatus, all of them unless the node has a `Join`
+	return d.joined(vertex)
 }
 
 func (d *WorkUnitDag) AvailableNodeUnitWithOwner(ownerID OwnerID) []NodeTaskUnit {
 	var availableUnits []NodeTaskUnit
 	for _, vertex := range d.graph.Vertices() {
 		node, ok := vertex.(*NodeTaskUnit)
-		if !ok || node.Definition.Key == "" {
+		if !ok || node.Definition == nil || node.Definition.Key == "" {
 			continue
 		}
-		if !ok || node.Unit.Status != NoneStatus || node.Definition.OwnerID != ownerID {
+		if !ok || node.Unit.Status != NoneStatus || node.OwnerID() != ownerID {
 			continue
 		}
-		immediateAncestors, err := d.graph.ImmediateAncestors(vertex)
+		// a fan-out is replaced by its children
+		if node.Unit.FanOut != nil {
+			continue
+		}
+		joined, err := d.joined(vertex)
 		if err != nil {
 			// handle error (optional based on your error handling strategy)
 			continue
 		}
-		allAncestorsSuccess := true
-		for _, ancestorVertex := range immediateAncestors {
-			ancestor, ok := ancestorVertex.(*NodeTaskUnit)
-			if !ok || (ancestor.Unit.Status != NoneStatus) {
-				allAncestorsSuccess = false
-				break
-			}
-		}
-		if allAncestorsSuccess {
+		if joined {
 			availableUnits = append(availableUnits, *node)
 		}
 	}
@@ -226,7 +269,7 @@ func (d *WorkUnitDag) CanChangeStatusWithOwner(vertex dag.Vertex, ownerID OwnerI
 	if !ok || targetNode.Definition.Key == "" {
 		return false, fmt.Errorf("vertex is does not have description ownership")
 	}
-	if !ok || targetNode.Definition.OwnerID != ownerID {
+	if !ok || targetNode.OwnerID() != ownerID {
 		return false, nil
 	}
 
@@ -238,19 +281,29 @@ func (d *WorkUnitDag) CanChangeStatusWithOwner(vertex dag.Vertex, ownerID OwnerI
 		return false, nil
 	}
 
-	// Check if all immediate ancestors are in SuccessStatus
+	// Check if enough immediate ancestors are in SuccessStatus, all of them unless the node has a `Join`
+	return d.joined(vertex)
+}
+
+// Whether enough immediate ancestors of the vertex succeeded for its `Join`
+func (d *WorkUnitDag) joined(vertex dag.Vertex) (bool, error) {
+	node, ok := vertex.(*NodeTaskUnit)
+	if !ok {
+		return false, fmt.Errorf("vertex is not a NodeTaskUnit")
+	}
 	immediateAncestors, err := d.graph.ImmediateAncestors(vertex)
 	if err != nil {
 		return false, err
 	}
+	statuses := []StatusType{}
 	for _, ancestorVertex := range immediateAncestors {
 		ancestor, ok := ancestorVertex.(*NodeTaskUnit)
-		if !ok || ancestor.Unit.Status != SuccessStatus {
-			return false, nil // One of the ancestors is not in SuccessStatus, status cannot be changed
+		if !ok {
+			return false, nil
 		}
+		statuses = append(statuses, ancestor.Unit.Status)
 	}
-
-	return true, nil // All checks passed, status can be changed
+	return JoinSatisfied(node.Unit.Join, statuses), nil
 }
 
 // If the nodes has no owner, they won't be visible by any
@@ -261,35 +314,35 @@ func CreateDagFromTaskUnits(si StorageInterface, taskUnits []TaskUnit, definitio
 	// Create a map to hold the NodeUnit instances and to allow for quick lookup by TaskUnitID
 	nodeUnitMap := make(map[TaskUnitID]dag.Vertex)
 
-	var def *TaskDefinition
 	// Step 1: Add all nodes to the Dag and to the nodeUnitMap
 	for idxTask := 0; idxTask < len(taskUnits); idxTask++ {
 
-		for idxDef := 0; idxDef < len(definitions); idxDef++ {
-			if definitions[idxDef].Key == taskUnits[idxTask].TaskDefinitionID {
-				def = &definitions[idxDef]
-			}
-		}
+		// the version pinned by the unit
+		def := taskUnits[idxTask].Definition(definitions)
 
 		nodeUnitCfgs := []NodeTaskUnitConfig{
 			WithNodeWithTaskKey(taskUnits[idxTask].Key),
 			WithNodeWithTaskDefinition(def),
 			WithNodeWithTaskStatus(taskUnits[idxTask].Status),
 			WithNodeWithTaskData(taskUnits[idxTask].Data),
+			WithNodeWithTaskFanOut(taskUnits[idxTask].FanOut),
+			WithNodeWithTaskFanIn(taskUnits[idxTask].FanIn),
+			WithNodeWithTaskJoin(taskUnits[idxTask].Join),
+			WithNodeWithTaskOwner(taskUnits[idxTask].OwnerID),
 		}
 
 		nodeUnit := wdag.AddTaskUnit(nodeUnitCfgs...)
 		nodeUnitMap[taskUnits[idxTask].Key] = nodeUnit
 	}
 
-	// Step 2: Connect the nodes in the Dag based on the DependsOnIDs field
+	// Step 2: Connect the nodes in the Dag based on the DependsOnIDs field, the dependency is the source
 	for idxTaskUnit := 0; idxTaskUnit < len(taskUnits); idxTaskUnit++ {
-		fromNode, exists := nodeUnitMap[taskUnits[idxTaskUnit].Key]
+		toNode, exists := nodeUnitMap[taskUnits[idxTaskUnit].Key]
 		if !exists {
 			return nil, fmt.Errorf("node doesnt exists for TaskUnitID: %s", taskUnits[idxTaskUnit].Key)
 		}
 		for _, dependsOnID := range taskUnits[idxTaskUnit].DependsOnIDs {
-			toNode, exists := nodeUnitMap[dependsOnID]
+			fromNode, exists := nodeUnitMap[dependsOnID]
 			if !exists {
 				return nil, fmt.Errorf("node not found for DependsOnID: %s", dependsOnID)
 			}
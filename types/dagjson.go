@@ -0,0 +1,205 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/davidroman0O/junjo/dag"
+)
+
+/// A `WorkUnitDag` can be stored as a template or sent over the wire as JSON
+///
+///	{
+///		"version": 1,
+///		"definitions": [ TaskDefinition, ... ],
+///		"vertices": [
+///			{ "id": "<TaskUnitID>", "definitionID": "<TaskDefinitionID>", "status": "none", "data": { "key": "value" } },
+///			{ "id": "<TaskUnitID>", "definitionID": "<TaskDefinitionID>", "definitionVersion": 2, "ownerID": "<OwnerID>", "status": "none" },
+///			{ "id": "<TaskUnitID>", "definitionID": "<TaskDefinitionID>", "status": "none", "fanOut": { "listKey": "machines", "itemKey": "machine" } },
+///			{ "id": "<TaskUnitID>", "definitionID": "<TaskDefinitionID>", "status": "none", "fanIn": { "fanOutID": "<TaskUnitID>", "quorum": 8 } },
+///		],
+///		"edges": [
+///			{ "source": "<TaskUnitID>", "target": "<TaskUnitID>" },
+///		]
+///	}
+///
+/// - `definitions` are written once and shared by all the vertices referencing them with `definitionID`
+/// - `definitionID` is omitted when the vertex has no definition, it won't be visible by any owner
+/// - `edges` go from the dependency (`source`) to the dependent vertex (`target`)
+/// - `definitionVersion` and `ownerID` are optional, the version the vertex is pinned to and the owner replacing the one of its definition
+/// - `fanOut` and `fanIn` are optional, see `FanOut`
+/// - `join` is optional, like `{ "mode": "atLeast", "count": 2 }`, see `Join`
+
+// Version of the format written by `WorkUnitDag.MarshalJSON`
+const WorkUnitDagVersion = 1
+
+var (
+	ErrWorkUnitDagVersion = errors.New("unsupported dag version")
+)
+
+type workUnitDagJSON struct {
+	Version     int                     `json:"version"`
+	Definitions []TaskDefinition        `json:"definitions"`
+	Vertices    []workUnitDagVertexJSON `json:"vertices"`
+	Edges       []workUnitDagEdgeJSON   `json:"edges"`
+}
+
+type workUnitDagVertexJSON struct {
+	Key               TaskUnitID        `json:"id"`
+	DefinitionID      TaskDefinitionID  `json:"definitionID,omitempty"`
+	DefinitionVersion int               `json:"definitionVersion,omitempty"` // zero when the vertex isn't pinned
+	OwnerID           OwnerID           `json:"ownerID,omitempty"`
+	Status            StatusType        `json:"status"`
+	Data              map[string]string `json:"data,omitempty"`
+	Priority          *Priority         `json:"priority,omitempty"`
+	FanOut            *FanOut           `json:"fanOut,omitempty"`
+	FanIn             *FanIn            `json:"fanIn,omitempty"`
+	Join              *Join             `json:"join,omitempty"`
+}
+
+type workUnitDagEdgeJSON struct {
+	Source TaskUnitID `json:"source"`
+	Target TaskUnitID `json:"target"`
+}
+
+// MarshalJSON writes the vertices, their definitions and the edges of the DAG
+func (d *WorkUnitDag) MarshalJSON() ([]byte, error) {
+	value := workUnitDagJSON{
+		Version:     WorkUnitDagVersion,
+		Definitions: []TaskDefinition{},
+		Vertices:    []workUnitDagVertexJSON{},
+		Edges:       []workUnitDagEdgeJSON{},
+	}
+
+	definitions := map[TaskDefinitionID]bool{}
+	for _, vertex := range d.graph.Vertices() {
+		node, ok := vertex.(*NodeTaskUnit)
+		if !ok {
+			return nil, fmt.Errorf("vertex is not a NodeTaskUnit")
+		}
+		current := workUnitDagVertexJSON{
+			Key:               node.Unit.Key,
+			DefinitionVersion: node.Unit.DefinitionVersion,
+			OwnerID:           node.Unit.OwnerID,
+			Status:            node.Unit.Status,
+			Data:              node.Unit.Data,
+			Priority:          node.Unit.Priority,
+			FanOut:            node.Unit.FanOut,
+			FanIn:             node.Unit.FanIn,
+			Join:              node.Unit.Join,
+		}
+		if node.Definition != nil {
+			current.DefinitionID = node.Definition.Key
+			if !definitions[node.Definition.Key] {
+				definitions[node.Definition.Key] = true
+				value.Definitions = append(value.Definitions, *node.Definition)
+			}
+		}
+		value.Vertices = append(value.Vertices, current)
+	}
+
+	for _, edge := range d.graph.Edges() {
+		source, okSource := edge.Source().(*NodeTaskUnit)
+		target, okTarget := edge.Target().(*NodeTaskUnit)
+		if !okSource || !okTarget {
+			return nil, fmt.Errorf("edge vertex is not a NodeTaskUnit")
+		}
+		value.Edges = append(value.Edges, workUnitDagEdgeJSON{
+			Source: source.Unit.Key,
+			Target: target.Unit.Key,
+		})
+	}
+
+	// the graph is backed by maps, we want the same json for the same dag
+	sort.Slice(value.Definitions, func(i, j int) bool { return value.Definitions[i].Key < value.Definitions[j].Key })
+	sort.Slice(value.Vertices, func(i, j int) bool { return value.Vertices[i].Key < value.Vertices[j].Key })
+	sort.Slice(value.Edges, func(i, j int) bool {
+		if value.Edges[i].Source == value.Edges[j].Source {
+			return value.Edges[i].Target < value.Edges[j].Target
+		}
+		return value.Edges[i].Source < value.Edges[j].Source
+	})
+
+	return json.Marshal(value)
+}
+
+// UnmarshalJSON replaces the content of the DAG, the storage implementation is kept so you can keep adding vertices
+//
+//	workUnitDag := types.NewWorkUnitDag(storage)
+//	err := json.Unmarshal(data, workUnitDag)
+func (d *WorkUnitDag) UnmarshalJSON(data []byte) error {
+	var value workUnitDagJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value.Version != WorkUnitDagVersion {
+		return fmt.Errorf("%w: %v", ErrWorkUnitDagVersion, value.Version)
+	}
+
+	definitions := map[TaskDefinitionID]*TaskDefinition{}
+	for i := 0; i < len(value.Definitions); i++ {
+		definitions[value.Definitions[i].Key] = &value.Definitions[i]
+	}
+
+	graph := &dag.AcyclicGraph{}
+	vertices := map[TaskUnitID]dag.Vertex{}
+	for i := 0; i < len(value.Vertices); i++ {
+		current := value.Vertices[i]
+		if _, exists := vertices[current.Key]; exists {
+			return fmt.Errorf("duplicated vertex %v", current.Key)
+		}
+		status := current.Status
+		if status == "" {
+			status = NoneStatus
+		}
+		cfgs := []NodeTaskUnitConfig{
+			WithNodeWithTaskKey(current.Key),
+			WithNodeWithTaskStatus(status),
+			WithNodeWithTaskData(current.Data),
+			WithNodeWithTaskPriority(current.Priority),
+			WithNodeWithTaskFanOut(current.FanOut),
+			WithNodeWithTaskFanIn(current.FanIn),
+			WithNodeWithTaskJoin(current.Join),
+			WithNodeWithTaskOwner(current.OwnerID),
+			WithNodeWithTaskDefinitionVersion(current.DefinitionVersion),
+		}
+		if current.DefinitionID != "" {
+			def, exists := definitions[current.DefinitionID]
+			if !exists {
+				return fmt.Errorf("vertex %v references unknown definition %v", current.Key, current.DefinitionID)
+			}
+			cfgs = append(cfgs, WithNodeWithTaskDefinition(def))
+		}
+		vertices[current.Key] = graph.Add(NewNodeTaskUnit(cfgs...))
+	}
+
+	for i := 0; i < len(value.Edges); i++ {
+		source, exists := vertices[value.Edges[i].Source]
+		if !exists {
+			return fmt.Errorf("edge references unknown vertex %v", value.Edges[i].Source)
+		}
+		target, exists := vertices[value.Edges[i].Target]
+		if !exists {
+			return fmt.Errorf("edge references unknown vertex %v", value.Edges[i].Target)
+		}
+		if source == target {
+			return fmt.Errorf("vertex %v can't depend on itself", value.Edges[i].Source)
+		}
+		graph.Connect(dag.BasicEdge(source, target))
+	}
+
+	if cycles := graph.Cycles(); len(cycles) > 0 {
+		names := make([]string, 0, len(cycles[0]))
+		for i := 0; i < len(cycles[0]); i++ {
+			names = append(names, dag.VertexName(cycles[0][i]))
+		}
+		sort.Strings(names)
+		return fmt.Errorf("dag has %v cycle(s), first one: %v", len(cycles), strings.Join(names, ", "))
+	}
+
+	d.graph = graph
+	return nil
+}
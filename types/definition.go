@@ -0,0 +1,49 @@
+package types
+
+import (
+	"errors"
+)
+
+/// A `TaskDefinition` is versioned, updating it saves a new version and never touches the previous ones
+/// Each `TaskUnit` pins the version current when its job was launched, so it always runs with the same instructions
+///
+///	v1, _ := jj.CreateTaskDefinition("flash", btl.Key)
+///	jj.LaunchJob(topic.Key, dag)                       // its units run v1
+///	jj.UpdateTaskDefinition(v1.Key, btl.Key, "flash", "new firmware", "")
+///	jj.LaunchJob(topic.Key, dag)                       // these ones run v2
+///
+/// A deprecated definition can't be used by a new job, the units already created keep their version
+
+var (
+	ErrTaskDefinitionDeprecated      = errors.New("task definition deprecated")
+	ErrTaskDefinitionVersionNotFound = errors.New("task definition version not found")
+)
+
+func WithTaskUnitDefinitionVersion(version int) TaskUnitConfig {
+	return func(data *TaskUnit) {
+		data.DefinitionVersion = version
+	}
+}
+
+func WithNodeWithTaskDefinitionVersion(version int) NodeTaskUnitConfig {
+	return func(data *NodeTaskUnit) {
+		if data.Unit == nil {
+			data.Unit = &TaskUnit{}
+		}
+		data.Unit.DefinitionVersion = version
+	}
+}
+
+// The version of its definition the unit was created with among `definitions`, the last one of its definition when it's not pinned
+func (u *TaskUnit) Definition(definitions []TaskDefinition) *TaskDefinition {
+	var found *TaskDefinition
+	for i := 0; i < len(definitions); i++ {
+		if definitions[i].Key != u.TaskDefinitionID {
+			continue
+		}
+		if u.DefinitionVersion == 0 || definitions[i].Version == u.DefinitionVersion {
+			found = &definitions[i]
+		}
+	}
+	return found
+}
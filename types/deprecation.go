@@ -0,0 +1,30 @@
+package types
+
+import (
+	"errors"
+)
+
+/// Deprecating a `Topic`, an `Owner` or a `TaskDefinition` stops new work, what was already launched goes on
+///
+///	jj.DeprecateOwner(contractor.Key)
+///	jj.LaunchJob(topic.Key, dag)                                  // ErrOwnerDeprecated when one of its units goes to the contractor
+///	jj.MigrateOwner(contractor.Key, backup.Key, "contract ended") // the units the contractor didn't start go to the backup
+///
+/// A started unit stays with its owner and its definition until it's finished, a fan-out launched before the deprecation still expands its children
+
+var (
+	ErrTopicDeprecated  = errors.New("topic deprecated")
+	ErrOwnerDeprecated  = errors.New("owner deprecated")
+	ErrMigrationInvalid = errors.New("migration invalid")
+)
+
+// Data of the `LogCmd` recorded on a unit moved to another definition
+const (
+	MigratedFrom = "migratedFrom"
+	MigratedTo   = "migratedTo"
+)
+
+// The unit wasn't started yet and can move to another owner or definition, a fan-out moves with its children once expanded
+func (u *TaskUnit) Pending() bool {
+	return u.Status == NoneStatus && u.ClaimedBy == ""
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/davidroman0O/junjo/dag"
+)
+
+// Fill colors of the nodes in DOT exports, one per `StatusType`
+var statusDotColors = map[StatusType]string{
+	NoneStatus:     "white",
+	QueuedStatus:   "lightblue",
+	ProgressStatus: "gold",
+	SuccessStatus:  "palegreen",
+	ErrorStatus:    "salmon",
+	PauseStatus:    "orange",
+}
+
+// Name is used by the `dag` package to name the vertex
+func (u *NodeTaskUnit) Name() string {
+	return string(u.Unit.Key)
+}
+
+// DotNode makes `NodeTaskUnit` visible in DOT exports, labeled with the definition and the `OwnerID`
+func (u *NodeTaskUnit) DotNode(name string, opts *dag.DotOpts) *dag.DotNode {
+	return u.dotNode(name, string(u.OwnerID()))
+}
+
+func (u *NodeTaskUnit) dotNode(name string, owner string) *dag.DotNode {
+	definition := "unknown"
+	if u.Definition != nil {
+		definition = u.Definition.Name
+	}
+	if owner == "" {
+		owner = "no owner"
+	}
+	color, ok := statusDotColors[u.Unit.Status]
+	if !ok {
+		color = "lightgrey"
+	}
+	// fan-outs open and fan-ins close the group of children
+	shape := "box"
+	switch {
+	case u.Unit.FanOut != nil:
+		definition = fmt.Sprintf("%s[%s]", definition, u.Unit.FanOut.ListKey)
+		shape = "invtrapezium"
+	case u.Unit.FanIn != nil:
+		shape = "trapezium"
+	}
+	return &dag.DotNode{
+		Name: name,
+		Attrs: map[string]string{
+			"label":     fmt.Sprintf("%s\n%s", definition, owner),
+			"tooltip":   fmt.Sprintf("%s (%s)", u.Unit.Key, u.Unit.Status),
+			"shape":     shape,
+			"style":     "rounded,filled",
+			"fillcolor": color,
+		},
+	}
+}
+
+// Wrapper used to label a node with the name of its `Owner` instead of the id
+type dotTaskUnit struct {
+	*NodeTaskUnit
+	owner string
+}
+
+func (u *dotTaskUnit) DotNode(name string, opts *dag.DotOpts) *dag.DotNode {
+	return u.NodeTaskUnit.dotNode(name, u.owner)
+}
+
+// Wrapper used to draw a `Task` as a cluster of a `Job`
+type dotTaskCluster struct {
+	taskID TaskID
+	graph  *dag.AcyclicGraph
+}
+
+func (c *dotTaskCluster) Name() string {
+	return fmt.Sprintf("task %s", c.taskID)
+}
+
+func (c *dotTaskCluster) Subgraph() dag.Grapher {
+	return c.graph
+}
+
+// Copy the graph with vertices labeled with the owners names when we know them
+func (d *WorkUnitDag) dotGraph(owners []Owner) *dag.AcyclicGraph {
+	names := map[OwnerID]string{}
+	for i := 0; i < len(owners); i++ {
+		names[owners[i].Key] = owners[i].Name
+	}
+
+	graph := &dag.AcyclicGraph{}
+	vertices := map[dag.Vertex]dag.Vertex{}
+	for _, vertex := range d.graph.Vertices() {
+		node, ok := vertex.(*NodeTaskUnit)
+		if !ok {
+			continue
+		}
+		owner := string(node.OwnerID())
+		if name, ok := names[node.OwnerID()]; ok {
+			owner = name
+		}
+		vertices[vertex] = graph.Add(&dotTaskUnit{NodeTaskUnit: node, owner: owner})
+	}
+	for _, edge := range d.graph.Edges() {
+		source, okSource := vertices[edge.Source()]
+		target, okTarget := vertices[edge.Target()]
+		if !okSource || !okTarget {
+			continue
+		}
+		graph.Connect(dag.BasicEdge(source, target))
+	}
+	return graph
+}
+
+// Dot returns the Graphviz DOT representation of the DAG, nodes are colored by their `StatusType`
+// Give the `Owner` list if you want their names instead of their ids
+func (d *WorkUnitDag) Dot(owners ...Owner) []byte {
+	return d.dotGraph(owners).Dot(nil)
+}
+
+// JobDot returns the Graphviz DOT representation of all the DAGs of a `Job` with one cluster per `Task`
+func JobDot(tasks map[TaskID]*WorkUnitDag, owners ...Owner) []byte {
+	graph := &dag.AcyclicGraph{}
+	for taskID, workUnitDag := range tasks {
+		graph.Add(&dotTaskCluster{
+			taskID: taskID,
+			graph:  workUnitDag.dotGraph(owners),
+		})
+	}
+	return graph.Dot(nil)
+}
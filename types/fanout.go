@@ -0,0 +1,170 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidroman0O/junjo/dag"
+)
+
+/// A fan-out vertex expands at runtime into one `TaskUnit` of its definition for each element of a list
+/// The list is read under `ListKey` in the job data, the state of an upstream unit overrides it, see `TaskUnit.State`
+/// A fan-in vertex connected after the fan-out starts once all, or a quorum, of the children succeeded
+///
+///	machines := unitDag.AddFanOut(flashing, "machines", "machine")
+///	unitDag.AddFanIn(report, machines, 8)
+///
+/// The children depend on the same units as their fan-out, the fan-out itself is never offered and takes the status of its children
+
+var (
+	ErrFanOutInvalid = errors.New("invalid fan-out")
+)
+
+// Data key of the element in each child when `ItemKey` is empty
+const FanOutItemKey = "item"
+
+type FanOut struct {
+	ListKey string `json:"listKey"`
+	ItemKey string `json:"itemKey,omitempty"`
+}
+
+func (f *FanOut) Item() string {
+	if f.ItemKey == "" {
+		return FanOutItemKey
+	}
+	return f.ItemKey
+}
+
+type FanIn struct {
+	FanOutID TaskUnitID `json:"fanOutID"`
+	Quorum   int        `json:"quorum,omitempty"` // children which must succeed, all of them when zero
+}
+
+func WithNodeWithTaskFanOut(fanOut *FanOut) NodeTaskUnitConfig {
+	return func(data *NodeTaskUnit) {
+		if data.Unit == nil {
+			data.Unit = &TaskUnit{}
+		}
+		data.Unit.FanOut = fanOut
+	}
+}
+
+func WithNodeWithTaskFanIn(fanIn *FanIn) NodeTaskUnitConfig {
+	return func(data *NodeTaskUnit) {
+		if data.Unit == nil {
+			data.Unit = &TaskUnit{}
+		}
+		data.Unit.FanIn = fanIn
+	}
+}
+
+// Add a vertex which becomes one unit of the definition for each element of the list `listKey`, each child receives its element under `itemKey`
+func (d *WorkUnitDag) AddFanOut(def *TaskDefinition, listKey string, itemKey string) dag.Vertex {
+	vertex := d.AddTaskDefinition(def)()
+	vertex.(*NodeTaskUnit).Unit.FanOut = &FanOut{ListKey: listKey, ItemKey: itemKey}
+	return vertex
+}
+
+// Add a vertex after the fan-out which waits for `quorum` of its children, all of them with zero
+func (d *WorkUnitDag) AddFanIn(def *TaskDefinition, fanOut dag.Vertex, quorum int) dag.Vertex {
+	vertex := d.AddTaskDefinition(def)()
+	vertex.(*NodeTaskUnit).Unit.FanIn = &FanIn{FanOutID: fanOut.(*NodeTaskUnit).Unit.Key, Quorum: quorum}
+	d.Connect(fanOut, vertex)
+	return vertex
+}
+
+// A list is either a json array `["a","b"]` or comma separated `a,b`
+func ParseFanOutList(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	items := []string{}
+	if strings.HasPrefix(value, "[") {
+		var elements []interface{}
+		if err := json.Unmarshal([]byte(value), &elements); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFanOutInvalid, err)
+		}
+		for i := 0; i < len(elements); i++ {
+			switch element := elements[i].(type) {
+			case string:
+				items = append(items, element)
+			default:
+				encoded, err := json.Marshal(element)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %v", ErrFanOutInvalid, err)
+				}
+				items = append(items, string(encoded))
+			}
+		}
+		return items, nil
+	}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// Status of a fan-out from its children: success once the quorum succeeded, error once it can't be reached anymore
+func FanOutStatus(children []TaskUnit, quorum int) StatusType {
+	if quorum <= 0 || quorum > len(children) {
+		quorum = len(children)
+	}
+	succeeded, failed := 0, 0
+	for i := 0; i < len(children); i++ {
+		switch children[i].Status {
+		case SuccessStatus:
+			succeeded++
+		case ErrorStatus:
+			failed++
+		}
+	}
+	switch {
+	case succeeded >= quorum:
+		return SuccessStatus
+	case len(children)-failed < quorum:
+		return ErrorStatus
+	}
+	return ProgressStatus
+}
+
+// Check the fan-outs and fan-ins of the units of one task
+func ValidateFanOuts(units []*TaskUnit) error {
+	byKey := map[TaskUnitID]*TaskUnit{}
+	for i := 0; i < len(units); i++ {
+		byKey[units[i].Key] = units[i]
+	}
+	for i := 0; i < len(units); i++ {
+		unit := units[i]
+		if unit.FanOut != nil {
+			if unit.FanIn != nil {
+				return fmt.Errorf("%w: unit %v can't be both a fan-out and a fan-in", ErrFanOutInvalid, unit.Key)
+			}
+			if unit.FanOut.ListKey == "" {
+				return fmt.Errorf("%w: unit %v needs a list key", ErrFanOutInvalid, unit.Key)
+			}
+			if unit.TaskDefinitionID == "" {
+				return fmt.Errorf("%w: unit %v needs a task definition for its children", ErrFanOutInvalid, unit.Key)
+			}
+		}
+		if unit.FanIn == nil {
+			continue
+		}
+		fanOut, exists := byKey[unit.FanIn.FanOutID]
+		if !exists || fanOut.FanOut == nil {
+			return fmt.Errorf("%w: fan-in %v references %v which is not a fan-out of its task", ErrFanOutInvalid, unit.Key, unit.FanIn.FanOutID)
+		}
+		connected := false
+		for j := 0; j < len(unit.DependsOnIDs); j++ {
+			connected = connected || unit.DependsOnIDs[j] == fanOut.Key
+		}
+		if !connected {
+			return fmt.Errorf("%w: fan-in %v must depend on its fan-out %v", ErrFanOutInvalid, unit.Key, fanOut.Key)
+		}
+		if unit.FanIn.Quorum < 0 {
+			return fmt.Errorf("%w: fan-in %v has a negative quorum", ErrFanOutInvalid, unit.Key)
+		}
+	}
+	return nil
+}
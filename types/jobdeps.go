@@ -0,0 +1,23 @@
+package types
+
+import "errors"
+
+/// A `Job` can depend on other jobs, of any topic: its units stay out of the inboxes until the upstream jobs succeed
+/// The upstream policy decides what a failed upstream job does to the job
+
+var (
+	ErrJobDependencyInvalid = errors.New("invalid job dependency")
+)
+
+type UpstreamPolicy string
+
+const (
+	UpstreamBlock  UpstreamPolicy = "block"  // the job waits, forever if an upstream job failed
+	UpstreamCancel UpstreamPolicy = "cancel" // the job is cancelled when an upstream job fails
+	UpstreamIgnore UpstreamPolicy = "ignore" // a failed upstream job counts as finished
+)
+
+// Whether an upstream job with that status lets the job start
+func UpstreamDone(policy UpstreamPolicy, status StatusType) bool {
+	return status == SuccessStatus || (status == ErrorStatus && policy == UpstreamIgnore)
+}
@@ -0,0 +1,146 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+/// A vertex starts once all its immediate ancestors succeeded, its `Join` can ask for fewer of them
+///
+///	report := unitDag.AddTaskDefinition(report)()
+///	report.(*types.NodeTaskUnit).Unit.Join = types.AnyOf()
+///	unitDag.MConnect(report, probeParis, probeLondon, probeTokyo)
+///
+/// The failure of an ancestor doesn't fail the task when all the vertices after it can still start without it
+
+var (
+	ErrJoinInvalid = errors.New("invalid join")
+)
+
+type JoinMode string
+
+const (
+	JoinAllOf   JoinMode = "all"
+	JoinAnyOf   JoinMode = "any"
+	JoinAtLeast JoinMode = "atLeast" // `Count` of the ancestors
+)
+
+type Join struct {
+	Mode  JoinMode `json:"mode"`
+	Count int      `json:"count,omitempty"`
+}
+
+func AllOf() *Join {
+	return &Join{Mode: JoinAllOf}
+}
+
+func AnyOf() *Join {
+	return &Join{Mode: JoinAnyOf}
+}
+
+func AtLeast(count int) *Join {
+	return &Join{Mode: JoinAtLeast, Count: count}
+}
+
+func WithNodeWithTaskJoin(join *Join) NodeTaskUnitConfig {
+	return func(data *NodeTaskUnit) {
+		if data.Unit == nil {
+			data.Unit = &TaskUnit{}
+		}
+		data.Unit.Join = join
+	}
+}
+
+// Ancestors which must succeed out of `ancestors`, a nil join waits for all of them
+func (j *Join) Required(ancestors int) int {
+	if j == nil || ancestors == 0 {
+		return ancestors
+	}
+	switch j.Mode {
+	case JoinAnyOf:
+		return 1
+	case JoinAtLeast:
+		return j.Count
+	}
+	return ancestors
+}
+
+// Whether enough ancestors succeeded to start
+func JoinSatisfied(join *Join, ancestors []StatusType) bool {
+	succeeded := 0
+	for i := 0; i < len(ancestors); i++ {
+		if ancestors[i] == SuccessStatus {
+			succeeded++
+		}
+	}
+	return succeeded >= join.Required(len(ancestors))
+}
+
+// Whether too many ancestors failed to ever start
+func JoinFailed(join *Join, ancestors []StatusType) bool {
+	failed := 0
+	for i := 0; i < len(ancestors); i++ {
+		if ancestors[i] == ErrorStatus {
+			failed++
+		}
+	}
+	return len(ancestors)-failed < join.Required(len(ancestors))
+}
+
+// Check the joins of the units of one task
+func ValidateJoins(units []*TaskUnit) error {
+	for i := 0; i < len(units); i++ {
+		join := units[i].Join
+		if join == nil {
+			continue
+		}
+		switch join.Mode {
+		case JoinAllOf, JoinAnyOf:
+		case JoinAtLeast:
+			if join.Count < 1 || join.Count > len(units[i].DependsOnIDs) {
+				return fmt.Errorf("%w: unit %v waits for %v of its %v ancestor(s)", ErrJoinInvalid, units[i].Key, join.Count, len(units[i].DependsOnIDs))
+			}
+		default:
+			return fmt.Errorf("%w: unknown mode %q on unit %v", ErrJoinInvalid, join.Mode, units[i].Key)
+		}
+	}
+	return nil
+}
+
+// Statuses of the units rolled up in their task
+// The children of a fan-out are summed up by the fan-out and a failure is a success when the units after it can still start
+func TaskUnitStatuses(units []TaskUnit) []StatusType {
+	byKey := map[TaskUnitID]*TaskUnit{}
+	dependents := map[TaskUnitID][]*TaskUnit{}
+	for i := 0; i < len(units); i++ {
+		byKey[units[i].Key] = &units[i]
+		for j := 0; j < len(units[i].DependsOnIDs); j++ {
+			dependents[units[i].DependsOnIDs[j]] = append(dependents[units[i].DependsOnIDs[j]], &units[i])
+		}
+	}
+
+	statuses := []StatusType{}
+	for i := 0; i < len(units); i++ {
+		if units[i].ParentID != "" {
+			continue
+		}
+		status := units[i].Status
+		if status == ErrorStatus && len(dependents[units[i].Key]) > 0 {
+			tolerated := true
+			for _, dependent := range dependents[units[i].Key] {
+				ancestors := []StatusType{}
+				for j := 0; j < len(dependent.DependsOnIDs); j++ {
+					if ancestor, exists := byKey[dependent.DependsOnIDs[j]]; exists {
+						ancestors = append(ancestors, ancestor.Status)
+					}
+				}
+				tolerated = tolerated && !JoinFailed(dependent.Join, ancestors)
+			}
+			if tolerated {
+				status = SuccessStatus
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -0,0 +1,57 @@
+package types
+
+import "errors"
+
+var (
+	ErrConcurrencyLimitReached = errors.New("concurrency limit reached")
+	ErrConcurrencyLimitInvalid = errors.New("invalid concurrency limit")
+)
+
+/// A `ConcurrencyLimit` caps how many units of an `Owner`, or of one `TaskDefinition`, can be queued or in progress at once
+/// Without `TopicID` the limit counts the units of every topic, with it only the units of that topic
+/// Once a limit is reached the inboxes stop offering the units and the status changes that would start one are refused
+///
+///	jj.SetConcurrencyLimit(types.NewConcurrencyLimit(5, types.WithLimitOwner(firmware.Key)))
+
+type ConcurrencyLimit struct {
+	Namespace        Namespace        `json:"namespace" db:"namespace"`
+	OwnerID          OwnerID          `json:"ownerID,omitempty" db:"ownerID"`
+	TaskDefinitionID TaskDefinitionID `json:"taskDefinitionID,omitempty" db:"taskDefinitionID"`
+	TopicID          TopicID          `json:"topicID,omitempty" db:"topicID"` // empty for a global limit
+	Max              int              `json:"max" db:"max"`                   // zero removes the limit
+}
+
+type ConcurrencyLimitConfig func(data *ConcurrencyLimit)
+
+func WithLimitOwner(ownerID OwnerID) ConcurrencyLimitConfig {
+	return func(data *ConcurrencyLimit) {
+		data.OwnerID = ownerID
+	}
+}
+
+func WithLimitTaskDefinition(id TaskDefinitionID) ConcurrencyLimitConfig {
+	return func(data *ConcurrencyLimit) {
+		data.TaskDefinitionID = id
+	}
+}
+
+func WithLimitTopic(topicID TopicID) ConcurrencyLimitConfig {
+	return func(data *ConcurrencyLimit) {
+		data.TopicID = topicID
+	}
+}
+
+func NewConcurrencyLimit(maximum int, cfgs ...ConcurrencyLimitConfig) ConcurrencyLimit {
+	limit := ConcurrencyLimit{
+		Max: maximum,
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](&limit)
+	}
+	return limit
+}
+
+// Queued and in progress units count against the limits
+func IsActiveStatus(status StatusType) bool {
+	return status == QueuedStatus || status == ProgressStatus
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+/// A `Namespace` isolates the business units sharing one junjo, each one only sees its own topics, owners, definitions and jobs
+/// Every entity records its namespace, the names are unique within a namespace, two of them can have an owner with the same name
+///
+///	namespaces := junjo.NewNamespaces(open, junjo.WithNamespacesAuth(types.StaticTokens{"s3cret": {Name: "ci", Namespaces: []types.Namespace{"billing"}}}))
+///	jj, err := namespaces.Access("s3cret", "billing")
+///
+/// Reaching a namespace the token doesn't grant is refused with `ErrNamespaceDenied`
+
+var (
+	ErrNamespaceInvalid = errors.New("invalid namespace")
+	ErrNamespaceDenied  = errors.New("namespace denied")
+	ErrUnauthenticated  = errors.New("unauthenticated")
+)
+
+type Namespace string
+
+// Namespace used when none is given
+const DefaultNamespace Namespace = "default"
+
+// Lowercase letters, digits and dashes, up to 63 of them, so it fits in a file name or a header
+func ValidateNamespace(namespace Namespace) error {
+	if len(namespace) == 0 || len(namespace) > 63 {
+		return fmt.Errorf("%w: %q must have between 1 and 63 characters", ErrNamespaceInvalid, namespace)
+	}
+	for _, r := range namespace {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return fmt.Errorf("%w: %q must only have lowercase letters, digits and dashes", ErrNamespaceInvalid, namespace)
+		}
+	}
+	return nil
+}
+
+// Tokens known in advance with their principals, the simplest `AuthenticationInterface`
+type StaticTokens map[string]Principal
+
+func (t StaticTokens) Authenticate(token string) (*Principal, error) {
+	principal, ok := t[token]
+	if !ok || token == "" {
+		return nil, ErrUnauthenticated
+	}
+	return &principal, nil
+}
@@ -0,0 +1,43 @@
+package types
+
+import "errors"
+
+/// An `Owner` with members is a pool, its `TaskDefinition` are shared by the members
+/// Every member sees the available units of the pool in their inbox until one of them claims it, then only the claimer sees it
+/// A member can't be a pool itself
+/// A claimed unit only takes the commands of its claimer, they name it under `Claimant`
+///
+///	jj.SubmitCommand(unit.Key, types.Command{Type: types.SuccessCmd, Data: map[string]string{types.Claimant: string(alice.Key)}})
+
+var (
+	ErrOwnerPoolInvalid = errors.New("invalid owner pool")
+	ErrOwnerNotMember   = errors.New("owner is not a member of the pool")
+	ErrTaskUnitClaimed  = errors.New("task unit already claimed by another owner")
+)
+
+// Key of the data of a command with the `OwnerID` of the member sending it, required on a claimed unit
+const Claimant = "claimant"
+
+func WithOwnerMembers(members ...OwnerID) OwnerConfig {
+	return func(data *Owner) {
+		data.Members = members
+	}
+}
+
+func (o *Owner) IsPool() bool {
+	return len(o.Members) > 0
+}
+
+func (o *Owner) HasMember(ownerID OwnerID) bool {
+	for i := 0; i < len(o.Members); i++ {
+		if o.Members[i] == ownerID {
+			return true
+		}
+	}
+	return false
+}
+
+// The unit is offered to that owner, nobody else claimed it
+func (u *TaskUnit) ClaimableBy(ownerID OwnerID) bool {
+	return u.ClaimedBy == "" || u.ClaimedBy == ownerID
+}
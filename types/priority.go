@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+/// Priority of the work, the higher goes first in the inboxes and units with the same priority are served oldest first
+/// A `Job` has a priority, its `Task` and `TaskUnit` inherit it unless they have their own
+
+type Priority int
+
+const (
+	PriorityLow       Priority = -10
+	PriorityNormal    Priority = 0
+	PriorityHigh      Priority = 10
+	PriorityEmergency Priority = 100
+)
+
+// The priority of the unit, the closest one set from the unit to its job
+func EffectivePriority(job *Job, task *Task, unit *TaskUnit) Priority {
+	if unit != nil && unit.Priority != nil {
+		return *unit.Priority
+	}
+	if task != nil && task.Priority != nil {
+		return *task.Priority
+	}
+	if job != nil {
+		return job.Priority
+	}
+	return PriorityNormal
+}
+
+// Add one to the priority for every `aging` elapsed since `createdAt`
+func AgedPriority(priority Priority, createdAt time.Time, aging time.Duration, now time.Time) Priority {
+	if aging <= 0 || createdAt.IsZero() || now.Before(createdAt) {
+		return priority
+	}
+	return priority + Priority(now.Sub(createdAt)/aging)
+}
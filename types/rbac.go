@@ -0,0 +1,145 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+/// Roles limit what a `Principal` can change, a `Junjoold` bound to one with `As` checks each change before touching the storage
+///
+///	{"name": "ci", "namespaces": ["billing"], "roles": [{"role": "topic-manager", "topicID": "..."}]}
+///	{"name": "alice", "namespaces": ["billing"], "roles": [{"role": "owner", "ownerID": "..."}]}
+///
+/// Reading the jobs, their tasks and their units needs `ActionJobRead` on their topic, or to be one of the owners of the unit
+/// Reading an inbox needs `ActionInboxRead` on its owner, the topics, owners, definitions and templates are readable by the whole namespace
+/// The webhooks and their deliveries carry their secret, reading them needs `ActionWebhookManage` on their topic
+/// The audit records are for the admins
+/// Each check is recorded as an `AuditRecord`, allowed or not
+
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrRoleInvalid      = errors.New("invalid role")
+)
+
+type Role string
+
+const (
+	RoleAdmin        Role = "admin"         // everything
+	RoleTopicManager Role = "topic-manager" // the jobs, schedules, webhooks and units of its topic
+	RoleOwner        Role = "owner"         // the units of its owner, or of the pools it's in
+	RoleViewer       Role = "viewer"        // reading the jobs and the inboxes
+)
+
+type Action string
+
+const (
+	ActionTopicCreate      Action = "topic.create"
+	ActionTopicManage      Action = "topic.manage" // rename or deprecate
+	ActionOwnerManage      Action = "owner.manage" // create, rename, deprecate or change the members
+	ActionDefinitionManage Action = "definition.manage"
+	ActionTemplateManage   Action = "template.manage"
+	ActionLimitManage      Action = "limit.manage"
+	ActionJobLaunch        Action = "job.launch" // jobs, batches and templates, the drafts need every topic
+	ActionJobCancel        Action = "job.cancel" // jobs, tasks, batches and their dependencies
+	ActionScheduleManage   Action = "schedule.manage"
+	ActionWebhookManage    Action = "webhook.manage"
+	ActionUnitCommand      Action = "unit.command" // commands, claims, reassignments and reviews
+	ActionJobRead          Action = "job.read"     // jobs, tasks, units and their states
+	ActionInboxRead        Action = "inbox.read"
+	ActionAuditRead        Action = "audit.read"
+)
+
+// Actions of each role, the admins have all of them
+var Permissions = map[Role][]Action{
+	RoleTopicManager: {ActionTopicManage, ActionJobLaunch, ActionJobCancel, ActionScheduleManage, ActionWebhookManage, ActionUnitCommand, ActionJobRead, ActionInboxRead},
+	RoleOwner:        {ActionUnitCommand, ActionJobRead, ActionInboxRead},
+	RoleViewer:       {ActionJobRead, ActionInboxRead},
+}
+
+// A role on a topic, on every topic when `TopicID` is empty
+type RoleBinding struct {
+	Role    Role    `json:"role"`
+	TopicID TopicID `json:"topicID,omitempty"`
+	OwnerID OwnerID `json:"ownerID,omitempty"` // the owner acted as, only for `RoleOwner`
+}
+
+// Who calls junjo, given by the `AuthenticationInterface`
+type Principal struct {
+	Name       string        `json:"name"`
+	Namespaces []Namespace   `json:"namespaces"`
+	Roles      []RoleBinding `json:"roles,omitempty"`
+}
+
+// What an action touches, the topic and the owners who can act on the unit
+type Scope struct {
+	TopicID  TopicID
+	OwnerIDs []OwnerID
+}
+
+func ValidateRoles(roles []RoleBinding) error {
+	for i := 0; i < len(roles); i++ {
+		switch roles[i].Role {
+		case RoleAdmin, RoleTopicManager, RoleViewer:
+			if roles[i].OwnerID != "" {
+				return fmt.Errorf("%w: only %v is bound to an owner", ErrRoleInvalid, RoleOwner)
+			}
+		case RoleOwner:
+			if roles[i].OwnerID == "" {
+				return fmt.Errorf("%w: %v needs an owner", ErrRoleInvalid, RoleOwner)
+			}
+		default:
+			return fmt.Errorf("%w: unknown role %q", ErrRoleInvalid, roles[i].Role)
+		}
+	}
+	return nil
+}
+
+// One of the roles allows the action on the scope
+func (p *Principal) Allows(action Action, scope Scope) bool {
+	for i := 0; i < len(p.Roles); i++ {
+		binding := p.Roles[i]
+		if binding.TopicID != "" && binding.TopicID != scope.TopicID {
+			continue
+		}
+		if binding.Role == RoleAdmin {
+			return true
+		}
+		if !binding.has(action) {
+			continue
+		}
+		if binding.Role != RoleOwner {
+			return true
+		}
+		for k := 0; k < len(scope.OwnerIDs); k++ {
+			if scope.OwnerIDs[k] == binding.OwnerID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (b RoleBinding) has(action Action) bool {
+	actions := Permissions[b.Role]
+	for i := 0; i < len(actions); i++ {
+		if actions[i] == action {
+			return true
+		}
+	}
+	return false
+}
+
+type AuditRecordID string
+
+// A permission check of a `Principal`
+type AuditRecord struct {
+	Key       AuditRecordID `json:"id" db:"id"`
+	Namespace Namespace     `json:"namespace" db:"namespace"`
+	Principal string        `json:"principal" db:"principal"`
+	Action    Action        `json:"action" db:"action"`
+	TopicID   TopicID       `json:"topicID,omitempty" db:"topicID"`
+	Target    string        `json:"target,omitempty" db:"target"` // id or name of what the action touches
+	Allowed   bool          `json:"allowed" db:"allowed"`
+	CreatedAt time.Time     `json:"createdAt" db:"createdAt"`
+}
@@ -0,0 +1,46 @@
+package types
+
+import "errors"
+
+/// A `TaskUnit` can be handed to another `Owner` than the one of its `TaskDefinition`, when its owner is stuck or gone
+/// The change is kept in the commands of the unit as a `DelegateCmd`, sending that command is how an owner delegates its unit
+///
+///	jj.SubmitCommand(unit.Key, types.Command{Type: types.DelegateCmd, Details: "on leave", Data: map[string]string{types.DelegateTo: string(contractor.Key)}})
+
+var ErrReassignInvalid = errors.New("invalid reassignment")
+
+// Keys of the data of a `DelegateCmd`
+const (
+	DelegateFrom = "from"
+	DelegateTo   = "to"
+)
+
+func WithTaskUnitOwner(ownerID OwnerID) TaskUnitConfig {
+	return func(data *TaskUnit) {
+		data.OwnerID = ownerID
+	}
+}
+
+func WithNodeWithTaskOwner(ownerID OwnerID) NodeTaskUnitConfig {
+	return func(data *NodeTaskUnit) {
+		if data.Unit == nil {
+			data.Unit = &TaskUnit{}
+		}
+		data.Unit.OwnerID = ownerID
+	}
+}
+
+// Owner working on the unit, the one of its definition unless it was reassigned
+func (u *TaskUnit) Owner(definition *TaskDefinition) OwnerID {
+	if u.OwnerID != "" || definition == nil {
+		return u.OwnerID
+	}
+	return definition.OwnerID
+}
+
+func (n *NodeTaskUnit) OwnerID() OwnerID {
+	if n.Unit == nil {
+		return ""
+	}
+	return n.Unit.Owner(n.Definition)
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+/// A `Schedule` launches a `Job` on its `Topic` at each tick of a cron expression, from a `Template` or a `WorkUnitDag`
+/// The overlap policy decides what happens to a tick while the previous job of the schedule is not finished
+/// The ticks missed while the engine was down are all launched with `CatchUp`, otherwise only the last one is
+
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrScheduleInvalid  = errors.New("invalid schedule")
+)
+
+type ScheduleID string
+
+type OverlapPolicy string
+
+const (
+	OverlapSkip  OverlapPolicy = "skip"  // the tick is dropped
+	OverlapQueue OverlapPolicy = "queue" // the tick waits for the previous job to finish
+	OverlapAllow OverlapPolicy = "allow" // the jobs run side by side
+)
+
+type Schedule struct {
+	Key        ScheduleID        `json:"id" db:"id"`
+	Namespace  Namespace         `json:"namespace" db:"namespace"`
+	TopicID    TopicID           `json:"topicID" db:"topicID"`
+	Name       string            `json:"name" db:"name"`
+	Cron       string            `json:"cron" db:"cron"`
+	Timezone   string            `json:"timezone,omitempty" db:"timezone"`     // UTC when empty
+	TemplateID TemplateID        `json:"templateID,omitempty" db:"templateID"` // either a template
+	Dag        json.RawMessage   `json:"dag,omitempty" db:"json"`              // or a dag, see `WorkUnitDag.MarshalJSON`
+	Data       map[string]string `json:"data" db:"data"`                       // data of each job
+	Priority   Priority          `json:"priority" db:"priority"`
+	Overlap    OverlapPolicy     `json:"overlap" db:"overlap"`
+	CatchUp    bool              `json:"catchUp" db:"catchUp"`
+	Paused     bool              `json:"paused" db:"paused"`
+	LastTick   time.Time         `json:"lastTick" db:"lastTick"`             // last tick handled, launched or not
+	Pending    int               `json:"pending" db:"pending"`               // ticks waiting with `OverlapQueue`
+	LastJobID  JobID             `json:"lastJobID,omitempty" db:"lastJobID"` // overlaps are checked against it
+	CreatedAt  time.Time         `json:"createdAt" db:"createdAt"`
+}
+
+type ScheduleConfig func(data *Schedule)
+
+func WithScheduleTemplate(id TemplateID) ScheduleConfig {
+	return func(data *Schedule) {
+		data.TemplateID = id
+	}
+}
+
+func WithScheduleDag(dag json.RawMessage) ScheduleConfig {
+	return func(data *Schedule) {
+		data.Dag = dag
+	}
+}
+
+func WithScheduleData(value map[string]string) ScheduleConfig {
+	return func(data *Schedule) {
+		data.Data = value
+	}
+}
+
+func WithSchedulePriority(priority Priority) ScheduleConfig {
+	return func(data *Schedule) {
+		data.Priority = priority
+	}
+}
+
+func WithScheduleOverlap(overlap OverlapPolicy) ScheduleConfig {
+	return func(data *Schedule) {
+		data.Overlap = overlap
+	}
+}
+
+func WithScheduleCatchUp(catchUp bool) ScheduleConfig {
+	return func(data *Schedule) {
+		data.CatchUp = catchUp
+	}
+}
+
+// Name of the location of the cron expression, like `Europe/Paris`
+func WithScheduleTimezone(timezone string) ScheduleConfig {
+	return func(data *Schedule) {
+		data.Timezone = timezone
+	}
+}
+
+func (s *Schedule) Mutate(cfgs ...ScheduleConfig) {
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](s)
+	}
+}
+
+// Location of the cron expression
+func (s *Schedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// NewSchedule creates a `Schedule` which starts ticking after its creation
+func NewSchedule(id ScheduleID, topicID TopicID, name string, cron string, cfgs ...ScheduleConfig) *Schedule {
+	now := time.Now()
+	schedule := &Schedule{
+		Key:       id,
+		TopicID:   topicID,
+		Name:      name,
+		Cron:      cron,
+		Data:      map[string]string{},
+		Overlap:   OverlapSkip,
+		LastTick:  now,
+		CreatedAt: now,
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](schedule)
+	}
+	return schedule
+}
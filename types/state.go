@@ -0,0 +1,71 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+/// The data of a `TaskUnit` is event-sourced, the commands reporting work patch the data the unit was created with
+///
+///	jj.SubmitCommand(unit.Key, Command{Type: ProgressCmd, Data: map[string]string{"flashed": "12"}})
+///	jj.SubmitCommand(unit.Key, Command{Type: ProgressCmd, Data: map[string]string{"flashed": "40", "retry": ""}})
+///	state, _ := jj.GetTaskUnitState(unit.Key) // flashed=40, retry removed
+///
+/// Only `ProgressCmd`, `PauseCmd`, `SuccessCmd` and `ErrorCmd` are patches, the others keep their data as metadata
+/// An empty value removes the key
+
+var (
+	ErrCommandIndexInvalid = errors.New("command index invalid")
+)
+
+// The data of a `TaskUnit` after its first `Index` commands
+type TaskUnitState struct {
+	TaskUnitID TaskUnitID        `json:"taskUnitID"`
+	Index      int               `json:"index"`
+	Commands   int               `json:"commands"` // all the commands of the unit, the state is the current one when it's `Index`
+	Data       map[string]string `json:"data"`
+}
+
+// The command reports work, its data patches the one of the unit
+func (c Command) Patches() bool {
+	switch c.Type {
+	case ProgressCmd, PauseCmd, SuccessCmd, ErrorCmd:
+		return true
+	}
+	return false
+}
+
+// Reduce the first `index` commands over the original data, zero is the original data and `len(u.Commands)` the current one
+func (u *TaskUnit) StateAt(index int) (*TaskUnitState, error) {
+	if index < 0 || index > len(u.Commands) {
+		return nil, fmt.Errorf("%w: %v of %v commands", ErrCommandIndexInvalid, index, len(u.Commands))
+	}
+	data := map[string]string{}
+	for k, v := range u.Data {
+		data[k] = v
+	}
+	for i := 0; i < index; i++ {
+		if !u.Commands[i].Patches() {
+			continue
+		}
+		for k, v := range u.Commands[i].Data {
+			if v == "" {
+				delete(data, k)
+				continue
+			}
+			data[k] = v
+		}
+	}
+	return &TaskUnitState{
+		TaskUnitID: u.Key,
+		Index:      index,
+		Commands:   len(u.Commands),
+		Data:       data,
+	}, nil
+}
+
+// The data after every command
+func (u *TaskUnit) State() *TaskUnitState {
+	state, _ := u.StateAt(len(u.Commands))
+	return state
+}
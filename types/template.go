@@ -0,0 +1,71 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+/// A `Template` is a saved `WorkUnitDag` that can be instantiated as many `Job` as you want
+/// Templates are immutable, saving a template with an existing name creates its next version
+/// The DAG is kept in its JSON format (see `WorkUnitDag.MarshalJSON`) with the layout of the editor
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrTemplateInvalid  = errors.New("invalid template")
+)
+
+type TemplateID string
+
+// Position of a vertex in the editor
+type Coordinates struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type Template struct {
+	Key         TemplateID                 `json:"id" db:"id"`
+	Namespace   Namespace                  `json:"namespace" db:"namespace"`
+	Name        string                     `json:"name" db:"name"`
+	Version     int                        `json:"version" db:"version"`
+	Description string                     `json:"description" db:"description"`
+	Dag         json.RawMessage            `json:"dag" db:"json"`
+	Layout      map[TaskUnitID]Coordinates `json:"layout" db:"coordinates"`
+	CreatedAt   time.Time                  `json:"createdAt" db:"createdAt"`
+}
+
+type TemplateConfig func(data *Template)
+
+func WithTemplateDescription(d string) TemplateConfig {
+	return func(data *Template) {
+		data.Description = d
+	}
+}
+
+func WithTemplateLayout(layout map[TaskUnitID]Coordinates) TemplateConfig {
+	return func(data *Template) {
+		data.Layout = layout
+	}
+}
+
+func (t *Template) Mutate(cfgs ...TemplateConfig) {
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](t)
+	}
+}
+
+// NewTemplate creates the first version of a `Template`, the storage sets the real version
+func NewTemplate(id TemplateID, name string, dag json.RawMessage, cfgs ...TemplateConfig) *Template {
+	template := &Template{
+		Key:       id,
+		Name:      name,
+		Version:   1,
+		Dag:       dag,
+		Layout:    map[TaskUnitID]Coordinates{},
+		CreatedAt: time.Now(),
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](template)
+	}
+	return template
+}
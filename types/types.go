@@ -2,8 +2,10 @@ package types
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 )
 
 // TODO: create better errors for entities
@@ -13,16 +15,26 @@ var (
 
 	ErrOwnerIDAlreadyExists   = errors.New("owner with same id already exists")
 	ErrOwnerNameAlreadyExists = errors.New("owner with same name already exists")
+
+	ErrTaskUnitNotAvailable = errors.New("task unit can't change status, its dependencies are not completed")
+	ErrTaskUnitClosed       = errors.New("task unit can't change, its task or job failed or was canceled")
+
+	ErrJobGraphInvalid = errors.New("invalid job graph")
+	ErrJobNotFound     = errors.New("job not found")
+
+	ErrNotDraft = errors.New("not a draft")
 )
 
-// Owners will have to authenticate and i don't care how
-type AuthenticationInterface struct {
-	//
+// Owners will have to authenticate and i don't care how, bring your own
+type AuthenticationInterface interface {
+	// Who holds the token, with the namespaces it can reach and its roles, refused with `ErrUnauthenticated` when it's unknown
+	Authenticate(token string) (*Principal, error)
 }
 
 // StorageInterface defines the methods required for managing data persistence.
 // - creation: you never have to give an ID of the entitiy, it has to be managed by the type and your implementation
 // - every unassigned Job, Task, TaskUnit are drafts, consider deleting them after a while
+// - an implementation serves one `Namespace`, it stamps it on what it creates and every query, inbox and name check stays in it, see `junjo.Namespaces`
 type StorageInterface interface {
 
 	// Utility function to create a new `uuid` for each entities, bring your own
@@ -43,11 +55,12 @@ type StorageInterface interface {
 	// Create new `Job` without TaskID and no units
 	CreateJob(cfgs ...JobConfig) (*Job, error) // [x]
 
-	//
+	// Units without `DefinitionVersion` are pinned to the current version, refused with `ErrTaskDefinitionDeprecated` when it's deprecated
+	// and with `ErrOwnerDeprecated` when a unit goes to a deprecated owner
 	CreateTaskUnits(units []*TaskUnit) ([]TaskUnitID, error) // [ ]
 
 	// Assign a drafted `Job` to a `Topic` for processing
-	AssignJob(topicID TopicID, jobID JobID) error // [x]
+	AssignJob(topicID TopicID, jobID JobID) error // [x] refused with `ErrTopicDeprecated`
 
 	// Assign a drafted `Task` to a `Job`
 	AssignTask(jobID JobID, taskID TaskID) error // [x]
@@ -55,7 +68,7 @@ type StorageInterface interface {
 	// Assign a drafted array `TaskUnit` to a `Task`
 	AssignTaskUnits(taskID TaskID, ids []TaskUnitID) error // [x]
 
-	// Owners should only see the `TaskUnit` they are supposed to accomplish
+	// Owners should only see the `TaskUnit` they are supposed to accomplish, never the ones of a task or job in error
 	// It is all topics
 	GetInbox(ownerID OwnerID, params *QueryParams) ([]InboxAllTaskUnit, error) // [x]
 
@@ -64,17 +77,40 @@ type StorageInterface interface {
 
 	GetJob(jobID JobID) (*Job, error)
 
+	// Save a new version of the `TaskDefinition`, the previous ones are never changed
 	UpdateTaskDefinition(id TaskDefinitionID, ownerID OwnerID, name string, description string, identifier string) (*TaskDefinition, error)
+	// Mark every version deprecated, the units must keep reading them
 	DeprecateTaskDefinition(id TaskDefinitionID) error
 	HasTaskDefinition(id TaskDefinitionID) (bool, error)
+	// Current version of the `TaskDefinition`
 	GetTaskDefinition(id TaskDefinitionID) (*TaskDefinition, error)
+	// One version, zero is the current one, refused with `ErrTaskDefinitionVersionNotFound`
+	GetTaskDefinitionVersion(id TaskDefinitionID, version int) (*TaskDefinition, error)
+	// Every version, oldest first
+	GetTaskDefinitionVersions(id TaskDefinitionID) ([]TaskDefinition, error)
+	// Current version of each `TaskDefinition`
 	GetTaskDefinitions() ([]TaskDefinition, error)
 
 	GetOwners() ([]Owner, error)
 	GetOwner(ownerID OwnerID) (*Owner, error)
 	UpdateOwner(ownerID OwnerID, name string) (*Owner, error)
+	// Mark the `Owner` deprecated, it must stay readable for the units it has
+	// New definitions, units, reassignments and pool members going to it are refused with `ErrOwnerDeprecated`
 	DeprecateOwner(ownerID OwnerID) (*Owner, error)
 	HasOwner(id OwnerID) (bool, error)
+	// Replace the members of the pool, none turns it back into a simple owner
+	SetOwnerMembers(ownerID OwnerID, members []OwnerID) (*Owner, error)
+
+	// Give the unit to a member of its pool, refused with `ErrTaskUnitClaimed` when another owner has it,
+	// with `ErrTaskUnitNotAvailable` when it's not in none status anymore and with `ErrConcurrencyLimitReached` when its limits are full
+	// The inboxes must stop offering a claimed unit to the others
+	ClaimTaskUnit(taskUnitID TaskUnitID, ownerID OwnerID) error
+	// Offer the unit to every member again
+	ReleaseTaskUnit(taskUnitID TaskUnitID) error
+	ReassignTaskUnit(taskUnitID TaskUnitID, ownerID OwnerID) error // also drops the claim
+	// Move a pending unit to the current version of another `TaskDefinition`
+	// Refused with `ErrTaskUnitNotAvailable` when the unit isn't `Pending` and `ErrTaskDefinitionDeprecated` when the definition is
+	MigrateTaskUnit(taskUnitID TaskUnitID, definitionID TaskDefinitionID) error
 
 	// A owner only own TaskUnit related to TaskDescription
 	// GetInboxTopic(topic TopicID, owner OwnerID) ([]InboxTaskUnit, error)
@@ -86,6 +122,7 @@ type StorageInterface interface {
 
 	UpdateTopic(id TopicID, name string) (*Topic, error)
 	HasTopic(id TopicID) (bool, error)
+	// New jobs of a deprecated `Topic` are refused with `ErrTopicDeprecated`, its jobs go on
 	DeprecateTopic(id TopicID) error
 
 	GetJobs(id TopicID) ([]Job, error)
@@ -105,7 +142,85 @@ type StorageInterface interface {
 
 	UpdateJobStatus(jobID JobID, status StatusType) error
 	UpdateTaskStatus(taskID TaskID, status StatusType) error
+	// Refused with `ErrTaskUnitClosed` once the task or the job of the unit is in error, failed or canceled
 	UpdateTaskUnitStatus(taskUnitID TaskUnitID, status StatusType, error error) error
+	// Record when the dependencies of the unit let it start, only the first time
+	MarkTaskUnitAvailable(taskUnitID TaskUnitID, at time.Time) error
+
+	// Record a `Command` reported on a `TaskUnit`, the status is managed separately, refused with `ErrTaskUnitClosed` like `UpdateTaskUnitStatus`
+	AddTaskUnitCommand(taskUnitID TaskUnitID, cmd Command) error
+
+	// Create a `Job` assigned to a `Topic` with one `Task` for each list of units, all at once or nothing
+	// Prefer it to the `Create*` and `Assign*` calls which leave drafts behind when one of them fail
+	// The units are pinned and checked like with `CreateTaskUnits`, refused with `ErrTopicDeprecated` when the topic is
+	CreateJobGraph(topicID TopicID, tasks [][]*TaskUnit, cfgs ...JobConfig) (*Job, error)
+	// Add the children of a fan-out unit to its task and put the fan-out in progress, all at once or nothing
+	// Refused with `ErrFanOutInvalid` when the unit is not a fan-out waiting to expand, the children keep the version of the fan-out
+	ExpandFanOut(unitID TaskUnitID, children []*TaskUnit) error
+
+	// All the jobs launched together with `LaunchBatch`
+	GetBatchJobs(batchID BatchID) ([]Job, error)
+
+	// Replace the limit with the same owner or definition and topic, a `Max` of zero removes it
+	// `UpdateTaskUnitStatus` must refuse with `ErrConcurrencyLimitReached` to start a unit beyond a limit and the inboxes must stop offering them
+	SetConcurrencyLimit(limit ConcurrencyLimit) error
+	GetConcurrencyLimits() ([]ConcurrencyLimit, error)
+
+	// Add upstream jobs to a `Job`, refused with `ErrJobDependencyInvalid` when one is missing or they would create a cycle
+	// The inboxes don't offer the units of a job until its upstream jobs are done, see `UpstreamDone`
+	AddJobDependencies(jobID JobID, upstreamIDs []JobID) error
+	// The jobs depending on that one
+	GetDependentJobs(jobID JobID) ([]Job, error)
+
+	CreateSchedule(topicID TopicID, name string, cron string, cfgs ...ScheduleConfig) (*Schedule, error)
+	GetSchedule(id ScheduleID) (*Schedule, error)
+	GetSchedules() ([]Schedule, error)
+	// Replace the `Schedule`, the scheduler records its ticks with it
+	UpdateSchedule(schedule Schedule) error
+	DeleteSchedule(id ScheduleID) error
+
+	CreateWebhook(url string, cfgs ...WebhookConfig) (*Webhook, error)
+	GetWebhook(id WebhookID) (*Webhook, error)
+	GetWebhooks() ([]Webhook, error)
+	// Delete a `Webhook` with its deliveries
+	DeleteWebhook(id WebhookID) error
+	// Record pending deliveries, the ones of an event already given to the same webhook are ignored
+	AddDeliveries(deliveries []Delivery) error
+	// Deliveries of a webhook, oldest first
+	GetDeliveries(webhookID WebhookID) ([]Delivery, error)
+	// Pending deliveries of every webhook, by next attempt
+	GetPendingDeliveries() ([]Delivery, error)
+	// Replace the `Delivery`, the dispatcher records its attempts with it
+	UpdateDelivery(delivery Delivery) error
+
+	// Drafts are the `Job` without `Topic`, the `Task` without `Job` and the `TaskUnit` without `Task`
+	GetDraftJobs() ([]Job, error)
+	GetDraftTasks() ([]Task, error)
+	GetDraftTaskUnits() ([]TaskUnit, error)
+
+	// Delete a `Job` with its tasks and their units
+	DeleteJob(jobID JobID) error
+	// Delete a `Task` with its units
+	DeleteTask(taskID TaskID) error
+	// Delete a `TaskUnit`, the units depending on it forget it
+	DeleteTaskUnit(taskUnitID TaskUnitID) error
+	// Same as the `Delete*` above but only while it's still a draft, refused with `ErrNotDraft` when it was assigned in the meantime
+	DeleteDraftJob(jobID JobID) error
+	DeleteDraftTask(taskID TaskID) error
+	DeleteDraftTaskUnit(taskUnitID TaskUnitID) error
+
+	// Save a new version of the `Template` with that name, the first one is version 1
+	CreateTemplate(name string, dag json.RawMessage, cfgs ...TemplateConfig) (*Template, error)
+	GetTemplate(id TemplateID) (*Template, error)
+	// Latest version of each template
+	GetTemplates() ([]Template, error)
+	// All versions of a template, oldest first
+	GetTemplateVersions(name string) ([]Template, error)
+
+	// Record a permission check, see `Principal`
+	AddAuditRecord(record AuditRecord) error
+	// Every audit record, oldest first
+	GetAuditRecords() ([]AuditRecord, error)
 }
 
 type TopicID string
@@ -123,6 +238,9 @@ var (
 	ErrorCmd    CommandType = "error"
 	PauseCmd    CommandType = "pause"
 	LogCmd      CommandType = "log"
+	DelegateCmd CommandType = "delegate" // hands the unit to the owner in `Data[DelegateTo]`, see `ReassignTaskUnit`
+	ApproveCmd  CommandType = "approve"  // review of an approval gate by `Data[Reviewer]`, see `Approval`
+	RejectCmd   CommandType = "reject"   // fails an approval gate with the details as reason
 )
 
 type StatusType string
@@ -136,6 +254,52 @@ var (
 	PauseStatus    StatusType = "pause"
 )
 
+// CommandStatus returns the status a `TaskUnit` takes when receiving that kind of command
+// `LogCmd` doesn't change the status
+func CommandStatus(cmdType CommandType) (StatusType, bool) {
+	switch cmdType {
+	case ProgressCmd:
+		return ProgressStatus, true
+	case SuccessCmd:
+		return SuccessStatus, true
+	case ErrorCmd:
+		return ErrorStatus, true
+	case PauseCmd:
+		return PauseStatus, true
+	}
+	return "", false
+}
+
+// RollupStatus computes the status of a parent (`Task` or `Job`) from the statuses of its children
+// - any error is an error
+// - all success is a success
+// - nothing started is none
+// - everything else is in progress
+func RollupStatus(statuses []StatusType) StatusType {
+	if len(statuses) == 0 {
+		return NoneStatus
+	}
+	success := 0
+	none := 0
+	for i := 0; i < len(statuses); i++ {
+		switch statuses[i] {
+		case ErrorStatus:
+			return ErrorStatus
+		case SuccessStatus:
+			success++
+		case NoneStatus, "":
+			none++
+		}
+	}
+	if success == len(statuses) {
+		return SuccessStatus
+	}
+	if none == len(statuses) {
+		return NoneStatus
+	}
+	return ProgressStatus
+}
+
 type OwnerConfig func(data *Owner)
 
 func WithOwnerDescription(d string) OwnerConfig {
@@ -157,9 +321,12 @@ func NewOwner(id OwnerID, name string, cfgs ...OwnerConfig) *Owner {
 }
 
 type Owner struct {
-	Key         OwnerID `json:"id" db:"id"`
-	Name        string  `json:"name" db:"name"`
-	Description string  `json:"description" db:"description"`
+	Key         OwnerID   `json:"id" db:"id"`
+	Namespace   Namespace `json:"namespace" db:"namespace"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	Members     []OwnerID `json:"members,omitempty" db:"members"` // a pool when not empty, see `ClaimTaskUnit`
+	Deprecated  bool      `json:"deprecated" db:"deprecated"`     // no new unit can go to it, it keeps its started ones
 }
 
 // Command represents an action from a client to report progress or mutate task unit states.
@@ -173,11 +340,16 @@ type Command struct {
 // TaskDefinition is the template of a TaskUnit (instance)
 type TaskDefinition struct {
 	Key         TaskDefinitionID `json:"id" db:"id"`
+	Namespace   Namespace        `json:"namespace" db:"namespace"`
 	Name        string           `json:"name" db:"name"`
 	Description string           `json:"description" db:"description"`
 	Details     string           `json:"details" db:"details"`
-	Identifier  string           `json:"identifier" db:"identifier"` // should be unique for the organization
-	OwnerID     OwnerID          `json:"ownerID" db:"ownerID"`       // one owner can complete that unit
+	Identifier  string           `json:"identifier" db:"identifier"`       // should be unique for the organization
+	OwnerID     OwnerID          `json:"ownerID" db:"ownerID"`             // one owner can complete that unit
+	Approval    *Approval        `json:"approval,omitempty" db:"approval"` // its units are approval gates
+	Version     int              `json:"version" db:"version"`             // each update saves a new version, see `TaskUnit.DefinitionVersion`
+	Deprecated  bool             `json:"deprecated" db:"deprecated"`       // no new job can use it, its versions stay readable
+	CreatedAt   time.Time        `json:"createdAt" db:"createdAt"`         // of the version
 }
 
 type TaskDefinitionConfig func(data *TaskDefinition)
@@ -197,9 +369,11 @@ func WithTaskDefIdentifier(identifier string) TaskDefinitionConfig {
 // NewUnitDescription creates a new UnitDescription with a generated UUID as the ID.
 func NewUnitDescription(id TaskDefinitionID, name string, ownerID OwnerID, cfgs ...TaskDefinitionConfig) *TaskDefinition {
 	unitDescription := &TaskDefinition{
-		Key:     id,
-		Name:    name,
-		OwnerID: ownerID,
+		Key:       id,
+		Name:      name,
+		OwnerID:   ownerID,
+		Version:   1,
+		CreatedAt: time.Now(),
 	}
 	for i := 0; i < len(cfgs); i++ {
 		cfgs[i](unitDescription)
@@ -233,6 +407,15 @@ func WithNodeWithTaskData(value map[string]string) NodeTaskUnitConfig {
 	}
 }
 
+func WithNodeWithTaskPriority(priority *Priority) NodeTaskUnitConfig {
+	return func(data *NodeTaskUnit) {
+		if data.Unit == nil {
+			data.Unit = &TaskUnit{}
+		}
+		data.Unit.Priority = priority
+	}
+}
+
 func WithNodeWithTaskKey(key TaskUnitID) NodeTaskUnitConfig {
 	return func(data *NodeTaskUnit) {
 		if data.Unit == nil {
@@ -312,6 +495,12 @@ func WithTaskUnitData(value map[string]string) TaskUnitConfig {
 	}
 }
 
+func WithTaskUnitPriority(priority Priority) TaskUnitConfig {
+	return func(data *TaskUnit) {
+		data.Priority = &priority
+	}
+}
+
 func WithTaskUnitDepends(units []TaskUnit) TaskUnitConfig {
 	return func(data *TaskUnit) {
 		for i := 0; i < len(units); i++ {
@@ -326,15 +515,26 @@ func WithTaskUnitDepends(units []TaskUnit) TaskUnitConfig {
 // TaskUnit represents a granular work unit within a task's DAG.
 // Using the taskDefinitionID, we know who is owner if it
 type TaskUnit struct {
-	Key              TaskUnitID        `json:"id" db:"id"`
-	TaskDefinitionID TaskDefinitionID  `json:"taskDefinitionID" db:"taskDefinitionID"`
-	DependsOnIDs     []TaskUnitID      `json:"dependsOnIds" db:"dependsOnIds"`
-	DependsOn        []*TaskUnit       `json:"dependsOn,omitempty" db:"-"` // runtime, it will be useful to know what tasks was done before me
-	Commands         []Command         `json:"commands" db:"commands"`     // tracking which commands was done on this taskunit
-	Status           StatusType        `json:"status" db:"status"`
-	Error            error             `json:"error" db:"error"`
-	TaskID           TaskID            `json:"taskID" db:"taskID"`
-	Data             map[string]string `json:"data" db:"data"` // original data, you have to run the commands to get the mutations of the data
+	Key               TaskUnitID        `json:"id" db:"id"`
+	Namespace         Namespace         `json:"namespace" db:"namespace"`
+	TaskDefinitionID  TaskDefinitionID  `json:"taskDefinitionID" db:"taskDefinitionID"`
+	DefinitionVersion int               `json:"definitionVersion,omitempty" db:"definitionVersion"` // pinned when the unit is created, zero follows the current version
+	DependsOnIDs      []TaskUnitID      `json:"dependsOnIds" db:"dependsOnIds"`
+	DependsOn         []*TaskUnit       `json:"dependsOn,omitempty" db:"-"` // runtime, it will be useful to know what tasks was done before me
+	Commands          []Command         `json:"commands" db:"commands"`     // tracking which commands was done on this taskunit
+	Status            StatusType        `json:"status" db:"status"`
+	Error             error             `json:"error" db:"error"`
+	TaskID            TaskID            `json:"taskID" db:"taskID"`
+	Data              map[string]string `json:"data" db:"data"`                     // original data, see `State` for the data patched by the commands
+	Priority          *Priority         `json:"priority,omitempty" db:"priority"`   // nil inherits the priority of the task
+	FanOut            *FanOut           `json:"fanOut,omitempty" db:"fanOut"`       // expands into children at runtime
+	FanIn             *FanIn            `json:"fanIn,omitempty" db:"fanIn"`         // waits for the children of a fan-out
+	ParentID          TaskUnitID        `json:"parentID,omitempty" db:"parentID"`   // fan-out which created that unit
+	Join              *Join             `json:"join,omitempty" db:"join"`           // nil waits for all the ancestors
+	ClaimedBy         OwnerID           `json:"claimedBy,omitempty" db:"claimedBy"` // member of the pool working on it
+	OwnerID           OwnerID           `json:"ownerID,omitempty" db:"ownerID"`     // reassigned owner, overrides the one of the definition
+	CreatedAt         time.Time         `json:"createdAt" db:"createdAt"`
+	AvailableAt       time.Time         `json:"availableAt" db:"availableAt"` // when its dependencies let it start, zero until then
 }
 
 func (j *TaskUnit) Mutate(cfgs ...TaskUnitConfig) {
@@ -351,6 +551,7 @@ func NewTaskUnit(id TaskUnitID, cfgs ...TaskUnitConfig) *TaskUnit {
 		DependsOnIDs: []TaskUnitID{},
 		DependsOn:    []*TaskUnit{},
 		Commands:     make([]Command, 0),
+		CreatedAt:    time.Now(),
 	}
 	for i := 0; i < len(cfgs); i++ {
 		cfgs[i](unit)
@@ -363,12 +564,14 @@ type InboxAllTaskUnit struct {
 	JobID     JobID      `json:"jobID" db:"jobID"`
 	TaskID    TaskID     `json:"taskID" db:"taskID"`
 	TaskUnits []TaskUnit `json:"taskUnits" db:"taskUnits"`
+	Priority  Priority   `json:"priority" db:"-"` // highest priority of the units, aging included
 }
 
 type InboxTopicTaskUnit struct {
 	JobID     JobID      `json:"jobID" db:"jobID"`
 	TaskID    TaskID     `json:"taskID" db:"taskID"`
 	TaskUnits []TaskUnit `json:"taskUnits" db:"taskUnits"`
+	Priority  Priority   `json:"priority" db:"-"` // highest priority of the units, aging included
 }
 
 type TaskConfig func(data *Task)
@@ -393,13 +596,22 @@ func WithTaskUnits(units ...*TaskUnit) TaskConfig {
 	}
 }
 
+func WithTaskPriority(priority Priority) TaskConfig {
+	return func(data *Task) {
+		data.Priority = &priority
+	}
+}
+
 // Task represents a DAG of task units.
 type Task struct {
 	Key         TaskID                   `json:"id" db:"id"`
+	Namespace   Namespace                `json:"namespace" db:"namespace"`
 	JobID       JobID                    `json:"jobID" db:"jobID"`
 	Status      StatusType               `json:"status" db:"status"`
-	TaskUnitIDs []TaskUnitID             `json:"taskUnitIds" db:"taskUnitIds"` // instances of the nodes of the dag, those instances represent the dag
-	TaskUnits   map[TaskUnitID]*TaskUnit `json:"taskUnits,omitempty" db:"-"`   // runtime
+	TaskUnitIDs []TaskUnitID             `json:"taskUnitIds" db:"taskUnitIds"`     // instances of the nodes of the dag, those instances represent the dag
+	TaskUnits   map[TaskUnitID]*TaskUnit `json:"taskUnits,omitempty" db:"-"`       // runtime
+	Priority    *Priority                `json:"priority,omitempty" db:"priority"` // nil inherits the priority of the job
+	CreatedAt   time.Time                `json:"createdAt" db:"createdAt"`
 }
 
 func (j *Task) Mutate(cfgs ...TaskConfig) {
@@ -415,6 +627,7 @@ func NewTask(id TaskID, cfgs ...TaskConfig) *Task {
 		TaskUnitIDs: []TaskUnitID{},
 		TaskUnits:   make(map[TaskUnitID]*TaskUnit),
 		Status:      NoneStatus,
+		CreatedAt:   time.Now(),
 	}
 	for i := 0; i < len(cfgs); i++ {
 		cfgs[i](task)
@@ -454,14 +667,53 @@ func WithJobData(value map[string]string) JobConfig {
 	}
 }
 
+func WithJobBatchID(batchID BatchID) JobConfig {
+	return func(data *Job) {
+		data.BatchID = batchID
+	}
+}
+
+// Wait for the upstream jobs to succeed before offering the units
+func WithJobDependsOn(jobIDs ...JobID) JobConfig {
+	return func(data *Job) {
+		data.DependsOn = append(data.DependsOn, jobIDs...)
+	}
+}
+
+func WithJobUpstreamPolicy(policy UpstreamPolicy) JobConfig {
+	return func(data *Job) {
+		data.Upstream = policy
+	}
+}
+
+func WithJobScheduleID(scheduleID ScheduleID) JobConfig {
+	return func(data *Job) {
+		data.ScheduleID = scheduleID
+	}
+}
+
+// Higher goes first in the inboxes, `Task` and `TaskUnit` inherit it unless they have their own
+func WithJobPriority(priority Priority) JobConfig {
+	return func(data *Job) {
+		data.Priority = priority
+	}
+}
+
 // Actual work that need to be done in that topic
 type Job struct {
-	Key     JobID             `json:"id" db:"id"`
-	TaskIDs []TaskID          `json:"taskIds" db:"taskIds"`
-	Tasks   map[TaskID]*Task  `json:"tasks,omitempty" db:"-"`
-	Status  StatusType        `json:"status" db:"status"`
-	Data    map[string]string `json:"data" db:"data"` // initial data to work with
-	TopicID TopicID           `json:"topicID" db:"topicID"`
+	Key        JobID             `json:"id" db:"id"`
+	Namespace  Namespace         `json:"namespace" db:"namespace"`
+	TaskIDs    []TaskID          `json:"taskIds" db:"taskIds"`
+	Tasks      map[TaskID]*Task  `json:"tasks,omitempty" db:"-"`
+	Status     StatusType        `json:"status" db:"status"`
+	Data       map[string]string `json:"data" db:"data"` // initial data to work with
+	TopicID    TopicID           `json:"topicID" db:"topicID"`
+	BatchID    BatchID           `json:"batchID,omitempty" db:"batchID"`       // set when launched with other jobs
+	ScheduleID ScheduleID        `json:"scheduleID,omitempty" db:"scheduleID"` // set when launched by a `Schedule`
+	Priority   Priority          `json:"priority" db:"priority"`
+	DependsOn  []JobID           `json:"dependsOn,omitempty" db:"dependsOn"`           // upstream jobs, of any topic
+	Upstream   UpstreamPolicy    `json:"upstreamPolicy,omitempty" db:"upstreamPolicy"` // `UpstreamBlock` when empty
+	CreatedAt  time.Time         `json:"createdAt" db:"createdAt"`
 }
 
 func (j *Job) Mutate(cfgs ...JobConfig) {
@@ -473,10 +725,11 @@ func (j *Job) Mutate(cfgs ...JobConfig) {
 // NewJob creates a new Job with a generated UUID as the ID.
 func NewJob(id JobID, cfgs ...JobConfig) *Job {
 	job := &Job{
-		Key:     JobID(GenerateUUID()),
-		Tasks:   make(map[TaskID]*Task),
-		TaskIDs: []TaskID{},
-		Status:  NoneStatus, // Initialize with QueuedStatus
+		Key:       JobID(GenerateUUID()),
+		Tasks:     make(map[TaskID]*Task),
+		TaskIDs:   []TaskID{},
+		Status:    NoneStatus, // Initialize with QueuedStatus
+		CreatedAt: time.Now(),
 	}
 
 	for i := 0; i < len(cfgs); i++ {
@@ -511,6 +764,7 @@ func WithTopicJobs(job ...*Job) TopicConfig {
 // Topic represents a high-level category of related work.
 type Topic struct {
 	Key            TopicID        `json:"id" db:"id"`
+	Namespace      Namespace      `json:"namespace" db:"namespace"`
 	InputType      HashTopic      `json:"inputType" db:"inputType"` // we hash the struct type to check the dev
 	Name           string         `json:"name" db:"name"`
 	Description    string         `json:"description" db:"description"`
@@ -615,10 +869,26 @@ func WithQuerySize(size int) QueryConfig {
 	}
 }
 
+// Every `aging` a unit waits in the inbox adds one to its priority so the routine work is not starved
+func WithQueryAging(aging time.Duration) QueryConfig {
+	return func(p *QueryParams) {
+		p.Aging = &aging
+	}
+}
+
+// Only the units of one `Topic`, like `GetInboxTopic`
+func WithQueryTopic(topicID TopicID) QueryConfig {
+	return func(p *QueryParams) {
+		p.Topic = &topicID
+	}
+}
+
 // Simple Query
 type QueryParams struct {
-	Offset *int `json:"offset"`
-	Size   *int `json:"size"`
+	Offset *int           `json:"offset"`
+	Size   *int           `json:"size"`
+	Aging  *time.Duration `json:"aging"`
+	Topic  *TopicID       `json:"topic"`
 }
 
 func NewQuery(cfgs ...QueryConfig) *QueryParams {
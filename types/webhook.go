@@ -0,0 +1,170 @@
+package types
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+/// A `Webhook` receives the events of a `Topic` or of an `Owner` as signed JSON deliveries, for the owners which can only receive HTTP callbacks
+/// Each event matching a webhook becomes a `Delivery`, retried with an exponential backoff until it succeeds or runs out of attempts
+/// The deliveries stay as the log of the webhook
+///
+///	POST /your/callback
+///	X-Junjo-Event: unit.available
+///	X-Junjo-Delivery: 6b1f...
+///	X-Junjo-Signature: sha256=<hex of the HMAC-SHA256 of the body with the secret>
+///
+///	{"id":"unit.available:<job>:<unit>","type":"unit.available","time":"...","topicID":"...","jobID":"...","taskID":"...","taskUnitID":"...","ownerID":"..."}
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrWebhookInvalid  = errors.New("invalid webhook")
+)
+
+const (
+	HeaderEvent     = "X-Junjo-Event"
+	HeaderDelivery  = "X-Junjo-Delivery"
+	HeaderSignature = "X-Junjo-Signature"
+)
+
+type WebhookID string
+type DeliveryID string
+
+type EventType string
+
+const (
+	EventUnitAvailable EventType = "unit.available" // a unit can be started by its owner
+	EventUnitFailed    EventType = "unit.failed"    // a unit was reported in error
+	EventJobCompleted  EventType = "job.completed"  // a job succeeded or failed, see its status
+)
+
+// Something that happened on a job, the same event always has the same ID so it's only delivered once to each webhook
+type Event struct {
+	ID         string     `json:"id"`
+	Type       EventType  `json:"type"`
+	Time       time.Time  `json:"time"`
+	TopicID    TopicID    `json:"topicID"`
+	JobID      JobID      `json:"jobID"`
+	TaskID     TaskID     `json:"taskID,omitempty"`
+	TaskUnitID TaskUnitID `json:"taskUnitID,omitempty"`
+	OwnerID    OwnerID    `json:"ownerID,omitempty"` // owner of the unit
+	Status     StatusType `json:"status,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+type Webhook struct {
+	Key       WebhookID   `json:"id" db:"id"`
+	Namespace Namespace   `json:"namespace" db:"namespace"`
+	URL       string      `json:"url" db:"url"`
+	Secret    string      `json:"secret,omitempty" db:"secret"`   // signs the deliveries when not empty
+	TopicID   TopicID     `json:"topicID,omitempty" db:"topicID"` // the events of the topic
+	OwnerID   OwnerID     `json:"ownerID,omitempty" db:"ownerID"` // or the events of the units of the owner and of their jobs
+	Events    []EventType `json:"events" db:"events"`             // all of them when empty
+	CreatedAt time.Time   `json:"createdAt" db:"createdAt"`
+}
+
+type WebhookConfig func(data *Webhook)
+
+func WithWebhookTopic(id TopicID) WebhookConfig {
+	return func(data *Webhook) {
+		data.TopicID = id
+	}
+}
+
+func WithWebhookOwner(id OwnerID) WebhookConfig {
+	return func(data *Webhook) {
+		data.OwnerID = id
+	}
+}
+
+func WithWebhookEvents(events ...EventType) WebhookConfig {
+	return func(data *Webhook) {
+		data.Events = events
+	}
+}
+
+func WithWebhookSecret(secret string) WebhookConfig {
+	return func(data *Webhook) {
+		data.Secret = secret
+	}
+}
+
+func (w *Webhook) Mutate(cfgs ...WebhookConfig) {
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](w)
+	}
+}
+
+// NewWebhook creates a `Webhook` on an url, give it a topic or an owner
+func NewWebhook(id WebhookID, url string, cfgs ...WebhookConfig) *Webhook {
+	webhook := &Webhook{
+		Key:       id,
+		URL:       url,
+		Events:    []EventType{},
+		CreatedAt: time.Now(),
+	}
+	for i := 0; i < len(cfgs); i++ {
+		cfgs[i](webhook)
+	}
+	return webhook
+}
+
+// The webhook wants that type of event, the owners involved in the event are given by the caller
+func (w *Webhook) Matches(event Event, owners ...OwnerID) bool {
+	if len(w.Events) > 0 {
+		wanted := false
+		for i := 0; i < len(w.Events); i++ {
+			if w.Events[i] == event.Type {
+				wanted = true
+			}
+		}
+		if !wanted {
+			return false
+		}
+	}
+	if w.TopicID != "" && w.TopicID != event.TopicID {
+		return false
+	}
+	if w.OwnerID == "" {
+		return true
+	}
+	for i := 0; i < len(owners); i++ {
+		if owners[i] == w.OwnerID {
+			return true
+		}
+	}
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed" // out of attempts
+)
+
+// One event sent to one webhook, with the outcome of its last attempt
+type Delivery struct {
+	Key          DeliveryID     `json:"id" db:"id"`
+	Namespace    Namespace      `json:"namespace" db:"namespace"`
+	WebhookID    WebhookID      `json:"webhookID" db:"webhookID"`
+	Event        Event          `json:"event" db:"event"`
+	Status       DeliveryStatus `json:"status" db:"status"`
+	Attempts     int            `json:"attempts" db:"attempts"`
+	ResponseCode int            `json:"responseCode,omitempty" db:"responseCode"`
+	LastError    string         `json:"lastError,omitempty" db:"lastError"`
+	NextAttempt  time.Time      `json:"nextAttempt" db:"nextAttempt"`
+	CreatedAt    time.Time      `json:"createdAt" db:"createdAt"`
+	DeliveredAt  time.Time      `json:"deliveredAt" db:"deliveredAt"`
+}
+
+// Value of the `X-Junjo-Signature` header of a body, receivers compare it with `hmac.Equal`
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
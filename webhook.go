@@ -0,0 +1,266 @@
+package junjo
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/davidroman0O/junjo/types"
+)
+
+/// Webhooks tell the owners living behind an HTTP callback what happened, run a `Dispatcher` next to your engine to send them
+///
+///	jj.CreateWebhook("https://btl.example.com/junjo", types.WithWebhookOwner(btl.Key), types.WithWebhookSecret(secret))
+///
+/// The events are found when the inboxes are notified, with the same limits: the changes made by another process are not seen
+/// A unit is announced available once its dependencies and upstream jobs allow it, the concurrency limits are not checked
+
+// Create a `Webhook` for the events of a `Topic` or of an `Owner`
+func (j *Junjoold) CreateWebhook(url string, cfgs ...types.WebhookConfig) (*types.Webhook, error) {
+	value := types.NewWebhook("", url, cfgs...)
+	// the webhooks of an owner hear of every topic
+	if err := j.authorize(types.ActionWebhookManage, types.Scope{TopicID: value.TopicID}, url); err != nil {
+		return nil, err
+	}
+	if err := j.validateWebhook(value); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.CreateWebhook(url, cfgs...)
+}
+
+func (j *Junjoold) validateWebhook(webhook *types.Webhook) error {
+	target, err := url.Parse(webhook.URL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", types.ErrWebhookInvalid, err)
+	}
+	if (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: url %q is not http or https", types.ErrWebhookInvalid, webhook.URL)
+	}
+	for i := 0; i < len(webhook.Events); i++ {
+		switch webhook.Events[i] {
+		case types.EventUnitAvailable, types.EventUnitFailed, types.EventJobCompleted:
+		default:
+			return fmt.Errorf("%w: unknown event %q", types.ErrWebhookInvalid, webhook.Events[i])
+		}
+	}
+
+	if webhook.TopicID == "" && webhook.OwnerID == "" {
+		return fmt.Errorf("%w: webhook needs a topic or an owner", types.ErrWebhookInvalid)
+	}
+	if webhook.TopicID != "" {
+		has, err := j.storageImplementation.HasTopic(webhook.TopicID)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return fmt.Errorf("%w: topic %v not found", types.ErrWebhookInvalid, webhook.TopicID)
+		}
+	}
+	if webhook.OwnerID != "" {
+		has, err := j.storageImplementation.HasOwner(webhook.OwnerID)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return fmt.Errorf("%w: owner %v not found", types.ErrWebhookInvalid, webhook.OwnerID)
+		}
+	}
+	return nil
+}
+
+// Reading a webhook needs to manage the webhooks of its topic, it carries its secret
+func (j *Junjoold) GetWebhook(id types.WebhookID) (*types.Webhook, error) {
+	webhook, err := j.storageImplementation.GetWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = j.authorize(types.ActionWebhookManage, types.Scope{TopicID: webhook.TopicID}, string(id)); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Webhooks of a `Topic`, all of them with an empty `TopicID`
+func (j *Junjoold) GetWebhooks(topicID types.TopicID) ([]types.Webhook, error) {
+	if err := j.authorizeTopic(types.ActionWebhookManage, topicID); err != nil {
+		return nil, err
+	}
+	webhooks, err := j.storageImplementation.GetWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	if topicID == "" {
+		return webhooks, nil
+	}
+	filtered := []types.Webhook{}
+	for i := 0; i < len(webhooks); i++ {
+		if webhooks[i].TopicID == topicID {
+			filtered = append(filtered, webhooks[i])
+		}
+	}
+	return filtered, nil
+}
+
+func (j *Junjoold) DeleteWebhook(id types.WebhookID) error {
+	if j.principal != nil {
+		webhook, err := j.storageImplementation.GetWebhook(id)
+		if err != nil {
+			return err
+		}
+		if err = j.authorize(types.ActionWebhookManage, types.Scope{TopicID: webhook.TopicID}, string(id)); err != nil {
+			return err
+		}
+	}
+	return j.storageImplementation.DeleteWebhook(id)
+}
+
+// The delivery log of a webhook, oldest first
+func (j *Junjoold) GetDeliveries(id types.WebhookID) ([]types.Delivery, error) {
+	if _, err := j.GetWebhook(id); err != nil {
+		return nil, err
+	}
+	return j.storageImplementation.GetDeliveries(id)
+}
+
+// Record the events of the task for the webhooks, a failure only loses the events
+// Each event has the same ID every time it's found, the storage keeps only the first delivery
+func (j *Junjoold) announceTask(taskID types.TaskID) {
+	webhooks, err := j.storageImplementation.GetWebhooks()
+	if err != nil || len(webhooks) == 0 {
+		return
+	}
+	task, err := j.storageImplementation.GetTask(taskID)
+	if err != nil || task.JobID == "" {
+		return
+	}
+	job, err := j.storageImplementation.GetJob(task.JobID)
+	if err != nil || job.TopicID == "" {
+		return
+	}
+	units, err := j.storageImplementation.GetTaskUnits(taskID)
+	if err != nil {
+		return
+	}
+	// the versions pinned by the units, not the current ones
+	definitions, err := j.unitDefinitions(units)
+	if err != nil {
+		return
+	}
+	// the members of a pool hear of its units
+	members := map[types.OwnerID][]types.OwnerID{}
+	involved := func(ownerID types.OwnerID) []types.OwnerID {
+		if _, ok := members[ownerID]; !ok {
+			ids, err := j.poolMembers(ownerID)
+			if err != nil {
+				ids = []types.OwnerID{ownerID}
+			}
+			members[ownerID] = ids
+		}
+		return members[ownerID]
+	}
+
+	now := time.Now()
+	deliveries := []types.Delivery{}
+	add := func(event types.Event, ownerIDs ...types.OwnerID) {
+		for i := 0; i < len(webhooks); i++ {
+			if webhooks[i].Matches(event, ownerIDs...) {
+				deliveries = append(deliveries, types.Delivery{
+					WebhookID:   webhooks[i].Key,
+					Event:       event,
+					Status:      types.DeliveryPending,
+					NextAttempt: now,
+					CreatedAt:   now,
+				})
+			}
+		}
+	}
+	unitEvent := func(eventType types.EventType, unit types.TaskUnit) types.Event {
+		return types.Event{
+			ID:         fmt.Sprintf("%v:%v:%v", eventType, job.Key, unit.Key),
+			Type:       eventType,
+			Time:       now,
+			TopicID:    job.TopicID,
+			JobID:      job.Key,
+			TaskID:     taskID,
+			TaskUnitID: unit.Key,
+			OwnerID:    j.unitOwner(&unit),
+			Status:     unit.Status,
+		}
+	}
+
+	for i := 0; i < len(units); i++ {
+		if units[i].Status != types.ErrorStatus {
+			continue
+		}
+		event := unitEvent(types.EventUnitFailed, units[i])
+		if units[i].Error != nil {
+			event.Error = units[i].Error.Error()
+		}
+		add(event, involved(event.OwnerID)...)
+	}
+
+	waiting, err := j.jobWaiting(job.Key)
+	if err != nil {
+		return
+	}
+	if !waiting && job.Status != types.SuccessStatus && job.Status != types.ErrorStatus {
+		workUnitDag, err := types.CreateDagFromTaskUnits(j.storageImplementation, units, definitions)
+		if err != nil {
+			return
+		}
+		stored := map[types.TaskUnitID]types.TaskUnit{}
+		for i := 0; i < len(units); i++ {
+			stored[units[i].Key] = units[i]
+		}
+		available := workUnitDag.AvailableNodeUnit()
+		for i := 0; i < len(available); i++ {
+			event := unitEvent(types.EventUnitAvailable, stored[available[i].Unit.Key])
+			ownerIDs := involved(event.OwnerID)
+			if available[i].Definition != nil && available[i].Definition.Approval != nil {
+				ownerIDs = append(append([]types.OwnerID{}, ownerIDs...), available[i].Definition.Approval.Approvers...)
+			}
+			add(event, ownerIDs...)
+		}
+	}
+
+	if job.Status == types.SuccessStatus || job.Status == types.ErrorStatus {
+		add(types.Event{
+			ID:      fmt.Sprintf("%v:%v", types.EventJobCompleted, job.Key),
+			Type:    types.EventJobCompleted,
+			Time:    now,
+			TopicID: job.TopicID,
+			JobID:   job.Key,
+			Status:  job.Status,
+		}, j.jobOwners(job.Key, involved)...)
+	}
+
+	if len(deliveries) > 0 {
+		j.storageImplementation.AddDeliveries(deliveries)
+	}
+}
+
+// Owners of the units of every task of the job, with the members of their pools
+func (j *Junjoold) jobOwners(jobID types.JobID, involved func(types.OwnerID) []types.OwnerID) []types.OwnerID {
+	tasks, err := j.storageImplementation.GetTasks(jobID)
+	if err != nil {
+		return nil
+	}
+	ownerIDs := []types.OwnerID{}
+	seen := map[types.OwnerID]bool{}
+	for i := 0; i < len(tasks); i++ {
+		units, err := j.storageImplementation.GetTaskUnits(tasks[i].Key)
+		if err != nil {
+			continue
+		}
+		for k := 0; k < len(units); k++ {
+			members := involved(j.unitOwner(&units[k]))
+			for m := 0; m < len(members); m++ {
+				if !seen[members[m]] {
+					seen[members[m]] = true
+					ownerIDs = append(ownerIDs, members[m])
+				}
+			}
+		}
+	}
+	return ownerIDs
+}
@@ -0,0 +1,142 @@
+package junjo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/junjo/memory"
+	"github.com/davidroman0O/junjo/types"
+)
+
+// go test -timeout 30s -v -count=1 -run ^TestWebhooks$ .
+func TestWebhooks(t *testing.T) {
+	var err error
+	jj := NewJ(memory.NewMemoryStorage())
+
+	var mu sync.Mutex
+	refused := false
+	received := []types.Event{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(types.HeaderSignature) != types.Sign("s3cret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// the first delivery is refused once
+		if !refused {
+			refused = true
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var event types.Event
+		if err := json.Unmarshal(body, &event); err != nil || string(event.Type) != r.Header.Get(types.HeaderEvent) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	var topic *types.Topic
+	if topic, err = jj.CreateTopic("Lab"); err != nil {
+		t.Error(err)
+		return
+	}
+	var btl *types.Owner
+	if btl, err = jj.CreateOwner("BTL"); err != nil {
+		t.Error(err)
+		return
+	}
+	var flashing *types.TaskDefinition
+	if flashing, err = jj.CreateTaskDefinition("flashing", btl.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	var webhook *types.Webhook
+	if webhook, err = jj.CreateWebhook(server.URL, types.WithWebhookOwner(btl.Key), types.WithWebhookSecret("s3cret")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	unitDag := jj.CreateDagTaskUnits()
+	first, second := unitDag.ConnectDef(unitDag.AddTaskDefinition(flashing), unitDag.AddTaskDefinition(flashing))
+	if _, err = jj.LaunchJob(topic.Key, unitDag); err != nil {
+		t.Error(err)
+		return
+	}
+
+	now := time.Now()
+	dispatcher := jj.NewDispatcher(WithDispatcherBackoff(time.Minute), WithDispatcherClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	var attempts []DeliveryAttempt
+	if attempts, err = dispatcher.Tick(ctx); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(attempts) != 1 || attempts[0].Status != types.DeliveryPending || attempts[0].ResponseCode != http.StatusInternalServerError {
+		t.Errorf("expected the first attempt refused, got %v", attempts)
+		return
+	}
+
+	// retried after the backoff only
+	if attempts, err = dispatcher.Tick(ctx); err != nil || len(attempts) != 0 {
+		t.Errorf("expected no attempt before the backoff, got %v %v", attempts, err)
+		return
+	}
+	now = now.Add(time.Minute)
+
+	if err = jj.SubmitCommand(first.(*types.NodeTaskUnit).Unit.Key, types.Command{Type: types.SuccessCmd}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = jj.SubmitCommand(second.(*types.NodeTaskUnit).Unit.Key, types.Command{Type: types.ErrorCmd, Details: "bricked"}); err != nil {
+		t.Error(err)
+		return
+	}
+	if attempts, err = dispatcher.Tick(ctx); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(attempts) != 4 {
+		t.Errorf("expected 4 attempts, got %v", attempts)
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// the retried delivery comes after the new ones
+	expected := []types.EventType{types.EventUnitAvailable, types.EventUnitFailed, types.EventJobCompleted, types.EventUnitAvailable}
+	if len(received) != len(expected) {
+		t.Errorf("expected %v, got %v", expected, received)
+		return
+	}
+	for i := 0; i < len(expected); i++ {
+		if received[i].Type != expected[i] {
+			t.Errorf("expected %v, got %v", expected, received)
+			return
+		}
+	}
+	if received[1].Error != "bricked" || received[2].Status != types.ErrorStatus {
+		t.Errorf("expected the failure and the failed job, got %v", received[1:3])
+		return
+	}
+
+	var deliveries []types.Delivery
+	if deliveries, err = jj.GetDeliveries(webhook.Key); err != nil {
+		t.Error(err)
+		return
+	}
+	if len(deliveries) != 4 || deliveries[0].Attempts != 2 || deliveries[0].Status != types.DeliveryDelivered {
+		t.Errorf("expected the first delivery delivered on the second attempt, got %v", deliveries)
+		return
+	}
+}